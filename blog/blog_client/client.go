@@ -5,14 +5,29 @@ import(
   "fmt"
   "io"
   "log"
+  "os"
+  "strconv"
   "github.com/villegasl/go_grpc_course/blog/blogpb"
 
   "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
   "google.golang.org/grpc/status"
   "google.golang.org/grpc/codes"
 )
 
 func main() {
+  // tools run as commands, without one the calls below are made
+  if len(os.Args) > 1 {
+    switch os.Args[1] {
+    case "import":
+      runImport(os.Args[2:])
+      return
+    case "export":
+      runExport(os.Args[2:])
+      return
+    }
+  }
+
   fmt.Println("Starting client\n\n")
 
   // Creating a client connection
@@ -69,9 +84,24 @@ func main() {
 
   // deleteBlog(c, uint64(2))
   // readBlog(c, uint64(2))
+  // writeBlogContent(c, "token", uint64(1), []byte("Content too large for UpdateBlog"))
+  // streamBlogContent(c, uint64(1))
 
   listBlog(c)
 
+  // admin := blogpb.NewBlogAdminServiceClient(cc)
+  // databaseStats(admin)
+  // cacheStats(admin)
+  // listQuarantine(admin)
+  // resolveQuarantine(admin, 1, true)
+  // compactDatabase(admin)
+  // rotateEncryptionKey(admin)
+
+  // the same calls over gRPC-Web, as made by browsers
+  // web := grpcweb.NewClient("http://localhost:8082", true)
+  // readBlogWeb(web, uint64(1))
+  // listBlogWeb(web)
+
   // req1 := &blogpb.UpdateBlogRequest {
   //   Blog: &blogpb.Blog {
   //     Id: uint64(1),
@@ -208,6 +238,251 @@ func listBlog(c blogpb.BlogServiceClient) {
   }
 }
 
+func compactDatabase(c blogpb.BlogAdminServiceClient) {
+  fmt.Printf("Starting CompactDatabase\n\n")
+  res, err := c.CompactDatabase(context.Background(), &blogpb.CompactDatabaseRequest{})
+  if err != nil {
+    log.Fatalf("Error while calling CompactDatabase RPC: %v\n\n", err)
+  }
+  fmt.Printf("Database compacted from %v to %v bytes\n\n", res.GetSizeBefore(), res.GetSizeAfter())
+}
+
+func databaseStats(c blogpb.BlogAdminServiceClient) {
+  res, err := c.DatabaseStats(context.Background(), &blogpb.DatabaseStatsRequest{})
+  if err != nil {
+    log.Fatalf("Error while calling DatabaseStats RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from DatabaseStats: %v\n\n", res)
+}
+
+func cacheStats(c blogpb.BlogAdminServiceClient) {
+  res, err := c.CacheStats(context.Background(), &blogpb.CacheStatsRequest{})
+  if err != nil {
+    log.Fatalf("Error while calling CacheStats RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from CacheStats: %v\n\n", res)
+}
+
+func listQuarantine(c blogpb.BlogAdminServiceClient) {
+  res, err := c.ListQuarantine(context.Background(), &blogpb.ListQuarantineRequest{})
+  if err != nil {
+    log.Fatalf("Error while calling ListQuarantine RPC: %v\n\n", err)
+  }
+  for _, q := range res.GetBlogs() {
+    fmt.Printf("%v\n", q)
+  }
+}
+
+func resolveQuarantine(c blogpb.BlogAdminServiceClient, id uint64, approve bool) {
+  res, err := c.ResolveQuarantine(context.Background(), &blogpb.ResolveQuarantineRequest{
+    QuarantineId: id,
+    Approve:      approve,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling ResolveQuarantine RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from ResolveQuarantine: %v\n\n", res)
+}
+
+func rotateEncryptionKey(c blogpb.BlogAdminServiceClient) {
+  res, err := c.RotateEncryptionKey(context.Background(), &blogpb.RotateEncryptionKeyRequest{})
+  if err != nil {
+    log.Fatalf("Error while calling RotateEncryptionKey RPC: %v\n\n", err)
+  }
+  fmt.Printf("Re-encrypted %v values and retired %v data keys\n\n", res.GetReencrypted(), res.GetRetiredKeys())
+}
+
+// withToken returns a context carrying token as the bearer token of the
+// call, for servers started with -auth-tokens.
+func withToken(token string) context.Context {
+  return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func getBlogAcl(c blogpb.BlogServiceClient, token string, id uint64) {
+  res, err := c.GetBlogAcl(withToken(token), &blogpb.GetBlogAclRequest{
+    BlogId: id,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling GetBlogAcl RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from GetBlogAcl: %v\n\n", res)
+}
+
+func setBlogAcl(c blogpb.BlogServiceClient, token string, id uint64, acl *blogpb.BlogAcl) {
+  res, err := c.SetBlogAcl(withToken(token), &blogpb.SetBlogAclRequest{
+    BlogId: id,
+    Acl:    acl,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling SetBlogAcl RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from SetBlogAcl: %v\n\n", res)
+}
+
+func likeBlog(c blogpb.BlogServiceClient, id uint64, userID string) {
+  res, err := c.LikeBlog(context.Background(), &blogpb.LikeBlogRequest{
+    BlogId: id,
+    UserId: userID,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling LikeBlog RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from LikeBlog: %v\n\n", res)
+}
+
+func topBlogs(c blogpb.BlogServiceClient, metric blogpb.TopBlogsRequest_Metric, windowSeconds int64) {
+  res, err := c.TopBlogs(context.Background(), &blogpb.TopBlogsRequest{
+    Metric:        metric,
+    WindowSeconds: windowSeconds,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling TopBlogs RPC: %v\n\n", err)
+  }
+  for _, top := range res.GetBlogs() {
+    fmt.Printf("%v: %v\n", top.GetCount(), top.GetBlog())
+  }
+}
+
+func relatedBlogs(c blogpb.BlogServiceClient, id uint64) {
+  res, err := c.RelatedBlogs(context.Background(), &blogpb.RelatedBlogsRequest{
+    BlogId: id,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling RelatedBlogs RPC: %v\n\n", err)
+  }
+  for _, related := range res.GetBlogs() {
+    fmt.Printf("%.3f: %v\n", related.GetScore(), related.GetBlog())
+  }
+}
+
+func upsertBlogTranslation(c blogpb.BlogServiceClient, id uint64, t *blogpb.BlogTranslation) {
+  res, err := c.UpsertBlogTranslation(context.Background(), &blogpb.UpsertBlogTranslationRequest{
+    BlogId:      id,
+    Translation: t,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling UpsertBlogTranslation RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from UpsertBlogTranslation: %v\n\n", res)
+}
+
+// readBlogIn reads a blog in the first of locales it is translated to.
+func readBlogIn(c blogpb.BlogServiceClient, id uint64, locales ...string) {
+  res, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{
+    BlogId:  id,
+    Locales: locales,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling ReadBlog RPC: %v\n\n", err)
+  }
+  fmt.Printf("Blog in %q: %v\n\n", res.GetBlog().GetLocale(), res.GetBlog())
+}
+
+func createSeries(c blogpb.BlogServiceClient, series *blogpb.Series) uint64 {
+  res, err := c.CreateSeries(context.Background(), &blogpb.CreateSeriesRequest{
+    Series: series,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling CreateSeries RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from CreateSeries: %v\n\n", res)
+  return res.GetSeries().GetId()
+}
+
+// addToSeries appends a blog to a series, or inserts it at position (from 1)
+// when it isn't 0.
+func addToSeries(c blogpb.BlogServiceClient, seriesID, blogID uint64, position int32) {
+  res, err := c.AddToSeries(context.Background(), &blogpb.AddToSeriesRequest{
+    SeriesId: seriesID,
+    BlogId:   blogID,
+    Position: position,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling AddToSeries RPC: %v\n\n", err)
+  }
+  fmt.Printf("Response from AddToSeries: %v\n\n", res)
+}
+
+// readBlogWithNavigation reads a blog with its previous and next posts in
+// its series.
+func readBlogWithNavigation(c blogpb.BlogServiceClient, id uint64) {
+  res, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{
+    BlogId:         id,
+    WithNavigation: true,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling ReadBlog RPC: %v\n\n", err)
+  }
+  fmt.Printf("Blog: %v\nNavigation: %v\n\n", res.GetBlog(), res.GetNavigation())
+}
+
+// writeBlogContent uploads content as the content of a blog, in 64KB
+// messages. When the server holds another offset for the upload, it starts
+// over from the offset the server sent back.
+func writeBlogContent(c blogpb.BlogServiceClient, token string, id uint64, content []byte) {
+  offset := uint64(0)
+  for attempt := 0; attempt < 2; attempt++ {
+    var trailer metadata.MD
+    stream, err := c.WriteBlogContent(withToken(token), grpc.Trailer(&trailer))
+    if err != nil {
+      log.Fatalf("Error while calling WriteBlogContent RPC: %v\n\n", err)
+    }
+    for start := offset; ; start += 64 << 10 {
+      end := start + 64<<10
+      if end >= uint64(len(content)) {
+        end = uint64(len(content))
+      }
+      req := &blogpb.WriteBlogContentRequest{
+        Data:     content[start:end],
+        Complete: end == uint64(len(content)),
+      }
+      if start == offset {
+        req.BlogId = id
+        req.Offset = offset
+      }
+      if stream.Send(req) != nil || req.GetComplete() {
+        break
+      }
+    }
+    res, err := stream.CloseAndRecv()
+    if err == nil {
+      fmt.Printf("Response from WriteBlogContent: offset:%v blog_id:%v content_size:%v\n\n", res.GetOffset(), res.GetBlog().GetId(), res.GetBlog().GetContentSize())
+      return
+    }
+    v := trailer.Get("x-upload-offset")
+    if status.Code(err) != codes.FailedPrecondition || len(v) == 0 {
+      log.Fatalf("Error while calling WriteBlogContent RPC: %v\n\n", err)
+    }
+    if offset, err = strconv.ParseUint(v[0], 10, 64); err != nil || offset > uint64(len(content)) {
+      log.Fatalf("Invalid upload offset %q\n\n", v[0])
+    }
+    fmt.Printf("Resuming the upload of blog %v from offset %v\n\n", id, offset)
+  }
+}
+
+// streamBlogContent reads the whole content of a blog, however large.
+func streamBlogContent(c blogpb.BlogServiceClient, id uint64) []byte {
+  stream, err := c.StreamBlogContent(context.Background(), &blogpb.StreamBlogContentRequest{
+    BlogId: id,
+  })
+  if err != nil {
+    log.Fatalf("Error while calling StreamBlogContent RPC: %v\n\n", err)
+  }
+  var content []byte
+  for {
+    res, err := stream.Recv()
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      log.Fatalf("Error while reading the content of blog %v: %v\n\n", id, err)
+    }
+    content = append(content, res.GetData()...)
+  }
+  fmt.Printf("Read %v bytes of content of blog %v\n\n", len(content), id)
+  return content
+}
+
 // func doUnary(c greetpb.GreetServiceClient) {
 //   fmt.Println("Starting to do a Unary RPC")
 //   req := &greetpb.GreetRequest {
@@ -398,4 +673,4 @@ func listBlog(c blogpb.BlogServiceClient) {
 //     return
 //   }
 //   log.Printf("Response from GreetWithDeadline: %v\n\n", res.GetResult())
-// }
\ No newline at end of file
+// }
@@ -0,0 +1,444 @@
+package main
+
+import(
+  "context"
+  "flag"
+  "fmt"
+  "html/template"
+  "io"
+  "io/ioutil"
+  "log"
+  "os"
+  "path/filepath"
+  "sort"
+  "strings"
+  "unicode"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "google.golang.org/grpc"
+)
+
+// defaultTheme holds the templates of the exported site. A theme directory
+// given with -theme replaces any of them by a file of the same name, and its
+// other files, such as style.css, are copied along with the site.
+var defaultTheme = map[string]string{
+  "layout.html": `{{define "layout"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{if .Title}}{{.Title}} - {{end}}{{.Site}}</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<header><a href="{{.Root}}index.html">{{.Site}}</a> · <a href="{{.Root}}tags/index.html">Tags</a></header>
+<main>{{template "content" .}}</main>
+</body>
+</html>
+{{end}}
+{{define "list"}}<ul class="posts">
+{{range .}}<li><a href="{{.URL}}">{{.Title}}</a> by <a href="{{.Author.URL}}">{{.Author.Name}}</a></li>
+{{end}}</ul>
+{{end}}`,
+  "index.html": `{{define "content"}}<h1>{{.Site}}</h1>
+{{template "list" .Posts}}{{end}}{{template "layout" .}}`,
+  "post.html": `{{define "content"}}<article{{with .Post.Locale}} lang="{{.}}"{{end}}>
+<h1>{{.Post.Title}}</h1>
+<p class="meta">by <a href="{{.Post.Author.URL}}">{{.Post.Author.Name}}</a>{{range .Post.Tags}} <a class="tag" href="{{.URL}}">#{{.Name}}</a>{{end}}</p>
+{{paragraphs .Post.Content}}
+</article>{{end}}{{template "layout" .}}`,
+  "author.html": `{{define "content"}}<h1>Posts by {{.Title}}</h1>
+{{template "list" .Posts}}{{end}}{{template "layout" .}}`,
+  "tag.html": `{{define "content"}}<h1>Posts tagged {{.Title}}</h1>
+{{template "list" .Posts}}{{end}}{{template "layout" .}}`,
+  "tags.html": `{{define "content"}}<h1>Tags</h1>
+<ul class="tags">
+{{range .Links}}<li><a href="{{.URL}}">{{.Name}}</a> ({{.Count}})</li>
+{{end}}</ul>{{end}}{{template "layout" .}}`,
+}
+
+const defaultStyle = `body { font-family: sans-serif; max-width: 45em; margin: 2em auto; padding: 0 1em; line-height: 1.5; }
+header { border-bottom: 1px solid #ddd; padding-bottom: .5em; }
+.meta, .tag { color: #666; }
+`
+
+// siteLink is a link to a page of the site, relative to the page it is on.
+type siteLink struct {
+  Name  string
+  URL   string
+  Count int
+}
+
+type sitePost struct {
+  ID      uint64
+  Title   string
+  Content string
+  Locale  string
+  URL     string
+  Author  siteLink
+  Tags    []siteLink
+}
+
+// sitePage is what the templates of every page are executed with. Root
+// leads back to the top of the site.
+type sitePage struct {
+  Site  string
+  Root  string
+  Title string
+  Post  *sitePost
+  Posts []*sitePost
+  Links []siteLink
+}
+
+// slugs gives the names of authors and tags file names, different ones to
+// names that only differ in case or punctuation.
+type slugs struct {
+  byName map[string]string
+  used   map[string]bool
+}
+
+func newSlugs() *slugs {
+  return &slugs{byName: make(map[string]string), used: make(map[string]bool)}
+}
+
+func (s *slugs) slug(name string) string {
+  if slug, ok := s.byName[name]; ok {
+    return slug
+  }
+  var b strings.Builder
+  dash := false
+  for _, r := range strings.ToLower(name) {
+    if unicode.IsLetter(r) || unicode.IsDigit(r) {
+      b.WriteRune(r)
+      dash = false
+    } else if !dash && b.Len() > 0 {
+      b.WriteByte('-')
+      dash = true
+    }
+  }
+  base := strings.TrimSuffix(b.String(), "-")
+  if base == "" {
+    base = "unnamed"
+  }
+  slug := base
+  for i := 2; s.used[slug]; i++ {
+    slug = fmt.Sprintf("%v-%v", base, i)
+  }
+  s.used[slug] = true
+  s.byName[name] = slug
+  return slug
+}
+
+// paragraphs renders plain text content as HTML paragraphs, one per block
+// of lines separated by a blank line.
+func paragraphs(content string) template.HTML {
+  var b strings.Builder
+  for _, block := range strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n\n") {
+    block = strings.TrimSpace(block)
+    if block == "" {
+      continue
+    }
+    lines := strings.Split(block, "\n")
+    for i, line := range lines {
+      lines[i] = template.HTMLEscapeString(line)
+    }
+    b.WriteString("<p>" + strings.Join(lines, "<br>\n") + "</p>\n")
+  }
+  return template.HTML(b.String())
+}
+
+// loadTheme parses the default templates with the ones of dir, if set,
+// replacing them, and returns the other files of dir to copy.
+func loadTheme(dir string) (map[string]*template.Template, []string, error) {
+  sources := make(map[string]string)
+  for name, text := range defaultTheme {
+    sources[name] = text
+  }
+  var assets []string
+  if dir != "" {
+    err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+      if err != nil || info.IsDir() {
+        return err
+      }
+      rel, _ := filepath.Rel(dir, path)
+      if _, ok := defaultTheme[rel]; !ok {
+        assets = append(assets, rel)
+        return nil
+      }
+      text, err := ioutil.ReadFile(path)
+      sources[rel] = string(text)
+      return err
+    })
+    if err != nil {
+      return nil, nil, err
+    }
+  }
+  pages := make(map[string]*template.Template)
+  for name := range defaultTheme {
+    if name == "layout.html" {
+      continue
+    }
+    // every page gets its own copy of the layout, since they each define
+    // their content block
+    t := template.New(name).Funcs(template.FuncMap{"paragraphs": paragraphs})
+    if _, err := t.Parse(sources["layout.html"]); err != nil {
+      return nil, nil, fmt.Errorf("layout.html: %v", err)
+    }
+    if _, err := t.Parse(sources[name]); err != nil {
+      return nil, nil, fmt.Errorf("%v: %v", name, err)
+    }
+    pages[name] = t
+  }
+  return pages, assets, nil
+}
+
+// runExport implements the "export" command, which writes every public blog
+// the caller can read, or every one with -include-private, as a static HTML
+// site: an index, a page per post, per author and per tag, and an index of
+// the tags.
+//
+//   blog_client export -out site -theme mytheme -title "My blog"
+func runExport(args []string) {
+  fs := flag.NewFlagSet("export", flag.ExitOnError)
+  addr := fs.String("addr", "localhost:50051", "address of the blog server")
+  out := fs.String("out", "site", "directory the site is written to")
+  themeDir := fs.String("theme", "", "directory of templates replacing the default ones (layout.html, index.html, post.html, author.html, tag.html, tags.html) and of files to copy, such as style.css")
+  title := fs.String("title", "Blog", "title of the site")
+  locales := fs.String("locales", "", "comma separated locales to export the posts in, best first, the original by default")
+  token := fs.String("token", "", "bearer token of the calls, for servers started with -auth-tokens")
+  includePrivate := fs.Bool("include-private", false, "export the blogs that aren't public too")
+  fs.Parse(args)
+
+  pages, assets, err := loadTheme(*themeDir)
+  if err != nil {
+    log.Fatalf("Could not load the theme: %v", err)
+  }
+
+  cc, err := grpc.Dial(*addr, grpc.WithInsecure())
+  if err != nil {
+    log.Fatalf("Could not connect: %v", err)
+  }
+  defer cc.Close()
+  c := blogpb.NewBlogServiceClient(cc)
+  ctx := context.Background()
+  if *token != "" {
+    ctx = withToken(*token)
+  }
+  req := &blogpb.ListBlogRequest{}
+  if *locales != "" {
+    req.Locales = strings.Split(*locales, ",")
+  }
+  blogs, err := listAll(ctx, c, req)
+  if err != nil {
+    log.Fatalf("Could not list the blogs: %v", err)
+  }
+  if !*includePrivate {
+    if blogs, err = publicBlogs(c, req, blogs); err != nil {
+      log.Fatalf("Could not list the public blogs: %v", err)
+    }
+  }
+
+  authorSlugs, tagSlugs := newSlugs(), newSlugs()
+  // tags/index.html lists the tags, no tag gets its name
+  tagSlugs.used["index"] = true
+  var posts []*sitePost
+  byAuthor := make(map[string][]*sitePost)
+  byTag := make(map[string][]*sitePost)
+  for _, blog := range blogs {
+    content := blog.GetContent()
+    if blog.GetContentTruncated() {
+      if content, err = readContent(ctx, c, blog.GetId()); err != nil {
+        log.Fatalf("Could not read the content of blog %v: %v", blog.GetId(), err)
+      }
+    }
+    post := &sitePost{
+      ID:      blog.GetId(),
+      Title:   blog.GetTitle(),
+      Content: content,
+      Locale:  blog.GetLocale(),
+      URL:     fmt.Sprintf("posts/%v.html", blog.GetId()),
+      Author: siteLink{
+        Name: blog.GetAuthorId(),
+        URL:  "authors/" + authorSlugs.slug(blog.GetAuthorId()) + ".html",
+      },
+    }
+    for _, tag := range blog.GetTags() {
+      post.Tags = append(post.Tags, siteLink{Name: tag, URL: "tags/" + tagSlugs.slug(tag) + ".html"})
+      byTag[tag] = append(byTag[tag], post)
+    }
+    posts = append(posts, post)
+    byAuthor[blog.GetAuthorId()] = append(byAuthor[blog.GetAuthorId()], post)
+  }
+  // newest first, ids grow with time
+  sort.Slice(posts, func(i, j int) bool {
+    return posts[i].ID > posts[j].ID
+  })
+
+  // the links of the posts are relative to the top of the site, pages one
+  // level down reach it through Root
+  write := func(path, name string, page *sitePage) {
+    page.Site = *title
+    if strings.Contains(path, "/") {
+      page.Root = "../"
+    }
+    if err := writePage(filepath.Join(*out, filepath.FromSlash(path)), pages[name], page); err != nil {
+      log.Fatalf("Could not write %v: %v", path, err)
+    }
+  }
+  write("index.html", "index.html", &sitePage{Posts: rooted(posts, "")})
+  for _, post := range posts {
+    write(post.URL, "post.html", &sitePage{Title: post.Title, Post: rooted([]*sitePost{post}, "../")[0]})
+  }
+  for author, list := range byAuthor {
+    sortPosts(list)
+    write("authors/"+authorSlugs.slug(author)+".html", "author.html", &sitePage{Title: author, Posts: rooted(list, "../")})
+  }
+  var tags []siteLink
+  for tag, list := range byTag {
+    sortPosts(list)
+    url := "tags/" + tagSlugs.slug(tag) + ".html"
+    write(url, "tag.html", &sitePage{Title: tag, Posts: rooted(list, "../")})
+    tags = append(tags, siteLink{Name: tag, URL: strings.TrimPrefix(url, "tags/"), Count: len(list)})
+  }
+  sort.Slice(tags, func(i, j int) bool {
+    return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+  })
+  write("tags/index.html", "tags.html", &sitePage{Title: "Tags", Links: tags})
+
+  if err := ioutil.WriteFile(filepath.Join(*out, "style.css"), []byte(defaultStyle), 0644); err != nil {
+    log.Fatalf("Could not write style.css: %v", err)
+  }
+  for _, asset := range assets {
+    if err := copyFile(filepath.Join(*themeDir, asset), filepath.Join(*out, asset)); err != nil {
+      log.Fatalf("Could not copy %v: %v", asset, err)
+    }
+  }
+  fmt.Printf("Exported %v posts, %v authors and %v tags to %v\n", len(posts), len(byAuthor), len(byTag), *out)
+}
+
+func sortPosts(posts []*sitePost) {
+  sort.Slice(posts, func(i, j int) bool {
+    return posts[i].ID > posts[j].ID
+  })
+}
+
+// rooted returns copies of posts whose links start with root, the way back
+// to the top of the site from the page they are shown on.
+func rooted(posts []*sitePost, root string) []*sitePost {
+  copies := make([]*sitePost, len(posts))
+  for i, post := range posts {
+    p := *post
+    p.URL = root + p.URL
+    p.Author.URL = root + p.Author.URL
+    p.Tags = nil
+    for _, tag := range post.Tags {
+      p.Tags = append(p.Tags, siteLink{Name: tag.Name, URL: root + tag.URL})
+    }
+    copies[i] = &p
+  }
+  return copies
+}
+
+func writePage(path string, t *template.Template, page *sitePage) error {
+  if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+    return err
+  }
+  f, err := os.Create(path)
+  if err != nil {
+    return err
+  }
+  err = t.Execute(f, page)
+  if closeErr := f.Close(); err == nil {
+    err = closeErr
+  }
+  return err
+}
+
+func copyFile(from, to string) error {
+  if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+    return err
+  }
+  src, err := os.Open(from)
+  if err != nil {
+    return err
+  }
+  defer src.Close()
+  dst, err := os.Create(to)
+  if err != nil {
+    return err
+  }
+  _, err = io.Copy(dst, src)
+  if closeErr := dst.Close(); err == nil {
+    err = closeErr
+  }
+  return err
+}
+
+// listAll returns every blog ListBlog sends.
+func listAll(ctx context.Context, c blogpb.BlogServiceClient, req *blogpb.ListBlogRequest) ([]*blogpb.Blog, error) {
+  stream, err := c.ListBlog(ctx, req)
+  if err != nil {
+    return nil, err
+  }
+  var blogs []*blogpb.Blog
+  for {
+    res, err := stream.Recv()
+    if err == io.EOF {
+      return blogs, nil
+    }
+    if err != nil {
+      return nil, err
+    }
+    blogs = append(blogs, res.GetBlog())
+  }
+}
+
+// publicBlogs returns the blogs of list anyone can read. The server only
+// sends the ACL of the blogs the caller can edit, the others are public if
+// they are listed without a token too.
+func publicBlogs(c blogpb.BlogServiceClient, req *blogpb.ListBlogRequest, list []*blogpb.Blog) ([]*blogpb.Blog, error) {
+  var anonymous map[uint64]bool
+  var public []*blogpb.Blog
+  for _, blog := range list {
+    if acl := blog.GetAcl(); acl != nil {
+      if acl.GetPublic() {
+        public = append(public, blog)
+      }
+      continue
+    }
+    if anonymous == nil {
+      listed, err := listAll(context.Background(), c, req)
+      if err != nil {
+        return nil, err
+      }
+      anonymous = make(map[uint64]bool)
+      for _, blog := range listed {
+        anonymous[blog.GetId()] = true
+      }
+    }
+    if anonymous[blog.GetId()] {
+      public = append(public, blog)
+    }
+  }
+  return public, nil
+}
+
+// readContent returns the whole content of blog id with StreamBlogContent.
+func readContent(ctx context.Context, c blogpb.BlogServiceClient, id uint64) (string, error) {
+  stream, err := c.StreamBlogContent(ctx, &blogpb.StreamBlogContentRequest{
+    BlogId: id,
+  })
+  if err != nil {
+    return "", err
+  }
+  var content []byte
+  for {
+    res, err := stream.Recv()
+    if err == io.EOF {
+      return string(content), nil
+    }
+    if err != nil {
+      return "", err
+    }
+    content = append(content, res.GetData()...)
+  }
+}
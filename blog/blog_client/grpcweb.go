@@ -0,0 +1,39 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "log"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/villegasl/go_grpc_course/blog/grpcweb"
+  "github.com/golang/protobuf/proto"
+)
+
+func readBlogWeb(c *grpcweb.Client, id uint64) {
+  fmt.Println("Sending Read Blog gRPC-Web Request with blog id", id)
+  err := c.Invoke(context.Background(), "/blog.BlogService/ReadBlog", &blogpb.ReadBlogRequest{
+    BlogId: id,
+  }, func() proto.Message {
+    return &blogpb.ReadBlogResponse{}
+  }, func(msg proto.Message) error {
+    fmt.Printf("Response from ReadBlog: %v\n\n", msg)
+    return nil
+  })
+  if err != nil {
+    log.Fatalf("Error while calling ReadBlog over gRPC-Web: %v\n\n", err)
+  }
+}
+
+func listBlogWeb(c *grpcweb.Client) {
+  fmt.Printf("Starting ListBlog gRPC-Web server streaming\n\n")
+  err := c.Invoke(context.Background(), "/blog.BlogService/ListBlog", &blogpb.ListBlogRequest{}, func() proto.Message {
+    return &blogpb.ListBlogResponse{}
+  }, func(msg proto.Message) error {
+    fmt.Printf("%v\n", msg.(*blogpb.ListBlogResponse).GetBlog())
+    return nil
+  })
+  if err != nil {
+    log.Fatalf("Error while calling ListBlog over gRPC-Web: %v\n\n", err)
+  }
+}
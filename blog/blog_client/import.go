@@ -0,0 +1,608 @@
+package main
+
+import(
+  "bufio"
+  "bytes"
+  "context"
+  "encoding/csv"
+  "encoding/json"
+  "flag"
+  "fmt"
+  "io"
+  "io/ioutil"
+  "log"
+  "os"
+  "path/filepath"
+  "regexp"
+  "sort"
+  "strconv"
+  "strings"
+  "unicode/utf8"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+  "gopkg.in/yaml.v2"
+)
+
+const (
+  // importInlineContent is the largest content sent along with its blog,
+  // larger content is uploaded with WriteBlogContent once the blog exists.
+  importInlineContent = 1 << 20
+  // importMaxContent is the largest content the server accepts.
+  importMaxContent = 1 << 30
+  importChunkSize  = 64 << 10
+)
+
+// importFields are the Blog fields an import sets, with the CSV columns and
+// front matter keys they are read from by default. source names the record
+// in the report and the checkpoint.
+var importFields = []struct {
+  field   string
+  aliases []string
+}{
+  {"source", []string{"source", "id", "slug"}},
+  {"author_id", []string{"author_id", "author"}},
+  {"title", []string{"title"}},
+  {"content", []string{"content", "body"}},
+  {"original_locale", []string{"original_locale", "locale", "lang"}},
+  {"tags", []string{"tags"}},
+  {"public", []string{"public"}},
+}
+
+// importLocalePattern is the check the server makes on locales.
+var importLocalePattern = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+
+// importRecord is a blog read from the files to import.
+type importRecord struct {
+  source   string
+  blog     *blogpb.Blog
+  errors   []string
+  warnings []string
+}
+
+func (r *importRecord) errorf(format string, args ...interface{}) {
+  r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *importRecord) warnf(format string, args ...interface{}) {
+  r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+// set stores the value read for field into the blog of r.
+func (r *importRecord) set(field string, value interface{}) {
+  switch field {
+  case "source":
+    if s := strings.TrimSpace(fmt.Sprint(value)); s != "" {
+      r.source = s
+    }
+  case "author_id":
+    r.blog.AuthorId = strings.TrimSpace(fmt.Sprint(value))
+  case "title":
+    r.blog.Title = strings.TrimSpace(fmt.Sprint(value))
+  case "content":
+    r.blog.Content = fmt.Sprint(value)
+  case "original_locale":
+    r.blog.OriginalLocale = strings.TrimSpace(fmt.Sprint(value))
+  case "tags":
+    var tags []string
+    switch v := value.(type) {
+    case []interface{}:
+      for _, t := range v {
+        tags = append(tags, fmt.Sprint(t))
+      }
+    default:
+      tags = strings.Split(fmt.Sprint(v), ",")
+    }
+    for _, t := range tags {
+      if t = strings.TrimSpace(t); t != "" {
+        r.blog.Tags = append(r.blog.Tags, t)
+      }
+    }
+  case "public":
+    public, ok := value.(bool)
+    if !ok {
+      var err error
+      if public, err = strconv.ParseBool(strings.TrimSpace(fmt.Sprint(value))); err != nil {
+        r.errorf("public must be true or false, not %q", value)
+        return
+      }
+    }
+    r.blog.Acl = &blogpb.BlogAcl{Public: public}
+  }
+}
+
+// parseMapping turns "title=Headline,content=Body" into the names each
+// field is read from, the default aliases for the fields it doesn't name.
+func parseMapping(spec string) (map[string][]string, error) {
+  mapping := make(map[string][]string)
+  for _, f := range importFields {
+    mapping[f.field] = f.aliases
+  }
+  if spec == "" {
+    return mapping, nil
+  }
+  for _, pair := range strings.Split(spec, ",") {
+    kv := strings.SplitN(pair, "=", 2)
+    field := strings.TrimSpace(kv[0])
+    if _, ok := mapping[field]; !ok || len(kv) != 2 {
+      return nil, fmt.Errorf("invalid mapping %q, expected field=name with a field among source, author_id, title, content, original_locale, tags and public", pair)
+    }
+    mapping[field] = []string{strings.TrimSpace(kv[1])}
+  }
+  return mapping, nil
+}
+
+// fieldOf returns the field name is read into, or "" if it is none.
+func fieldOf(mapping map[string][]string, name string) string {
+  for _, f := range importFields {
+    for _, alias := range mapping[f.field] {
+      if strings.EqualFold(alias, name) {
+        return f.field
+      }
+    }
+  }
+  return ""
+}
+
+// readCSV reads one blog per row of a CSV file whose first row names the
+// columns. Columns that map to no field are reported once.
+func readCSV(path string, mapping map[string][]string, report func(format string, args ...interface{})) ([]*importRecord, error) {
+  f, err := os.Open(path)
+  if err != nil {
+    return nil, err
+  }
+  defer f.Close()
+  r := csv.NewReader(f)
+  // rows of the wrong length are reported with the others
+  r.FieldsPerRecord = -1
+  header, err := r.Read()
+  if err != nil {
+    return nil, fmt.Errorf("could not read the header: %v", err)
+  }
+  fields := make([]string, len(header))
+  for i, name := range header {
+    if fields[i] = fieldOf(mapping, strings.TrimSpace(name)); fields[i] == "" {
+      report("column %q is not imported", name)
+    }
+  }
+
+  var records []*importRecord
+  for {
+    row, err := r.Read()
+    if err == io.EOF {
+      return records, nil
+    }
+    if err != nil {
+      return nil, err
+    }
+    line, _ := r.FieldPos(0)
+    rec := &importRecord{
+      source: fmt.Sprintf("line %v", line),
+      blog:   &blogpb.Blog{},
+    }
+    if len(row) != len(header) {
+      rec.errorf("%v columns instead of %v", len(row), len(header))
+    }
+    for i, value := range row {
+      if i >= len(fields) {
+        break
+      }
+      if fields[i] != "" && (value != "" || fields[i] == "content") {
+        rec.set(fields[i], value)
+      }
+    }
+    records = append(records, rec)
+  }
+}
+
+// readMarkdownDir reads one blog per Markdown file under dir. The YAML front
+// matter, between two --- lines at the top, sets the fields and the rest of
+// the file is the content. The title falls back to the first level 1
+// heading.
+func readMarkdownDir(dir string, mapping map[string][]string) ([]*importRecord, error) {
+  var records []*importRecord
+  err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+    if err != nil {
+      return err
+    }
+    ext := strings.ToLower(filepath.Ext(path))
+    if info.IsDir() || (ext != ".md" && ext != ".markdown") {
+      return nil
+    }
+    data, err := ioutil.ReadFile(path)
+    if err != nil {
+      return err
+    }
+    rel, _ := filepath.Rel(dir, path)
+    rec := &importRecord{
+      source: filepath.ToSlash(rel),
+      blog:   &blogpb.Blog{},
+    }
+    records = append(records, rec)
+
+    front, body, err := splitFrontMatter(data)
+    if err != nil {
+      rec.errorf("%v", err)
+      return nil
+    }
+    rec.blog.Content = string(body)
+    keys := make([]string, 0, len(front))
+    for key := range front {
+      keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    for _, key := range keys {
+      field := fieldOf(mapping, key)
+      if field == "" {
+        rec.warnf("front matter key %q is not imported", key)
+        continue
+      }
+      if front[key] != nil {
+        rec.set(field, front[key])
+      }
+    }
+    if rec.blog.GetTitle() == "" {
+      scanner := bufio.NewScanner(bytes.NewReader(body))
+      for scanner.Scan() {
+        if line := scanner.Text(); strings.HasPrefix(line, "# ") {
+          rec.blog.Title = strings.TrimSpace(line[2:])
+          break
+        }
+      }
+    }
+    return nil
+  })
+  return records, err
+}
+
+// splitFrontMatter returns the YAML front matter of a Markdown file, if it
+// has one, and what follows it.
+func splitFrontMatter(data []byte) (map[string]interface{}, []byte, error) {
+  data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+  text := strings.Replace(string(data), "\r\n", "\n", -1)
+  if !strings.HasPrefix(text, "---\n") {
+    return nil, []byte(text), nil
+  }
+  end := strings.Index(text[4:], "\n---")
+  if end < 0 {
+    return nil, nil, fmt.Errorf("the front matter has no closing --- line")
+  }
+  yamlText := text[4 : 4+end+1]
+  rest := text[4+end+4:]
+  // the content starts after the closing line and the blank lines under it
+  if i := strings.IndexByte(rest, '\n'); i >= 0 {
+    rest = strings.TrimLeft(rest[i+1:], "\n")
+  } else {
+    rest = ""
+  }
+  front := make(map[string]interface{})
+  if err := yaml.Unmarshal([]byte(yamlText), &front); err != nil {
+    return nil, nil, fmt.Errorf("invalid front matter: %v", err)
+  }
+  return front, []byte(rest), nil
+}
+
+// validateRecords checks the records the way the server would, so that a
+// dry run catches what the import would refuse.
+func validateRecords(records []*importRecord, defaultAuthor string) {
+  seen := make(map[string]bool)
+  for _, rec := range records {
+    blog := rec.blog
+    if seen[rec.source] {
+      rec.errorf("source %q is used by another record", rec.source)
+    }
+    seen[rec.source] = true
+    if blog.GetAuthorId() == "" {
+      blog.AuthorId = defaultAuthor
+    }
+    if blog.GetAuthorId() == "" {
+      rec.errorf("no author, set one in the record or with -author")
+    }
+    if blog.GetTitle() == "" {
+      rec.errorf("no title")
+    }
+    if blog.GetContent() == "" {
+      rec.warnf("empty content")
+    }
+    if len(blog.GetContent()) > importMaxContent {
+      rec.errorf("content of %v bytes, the server accepts up to %v", len(blog.GetContent()), importMaxContent)
+    }
+    if !utf8.ValidString(blog.GetContent()) || !utf8.ValidString(blog.GetTitle()) {
+      rec.errorf("title or content isn't valid UTF-8")
+    }
+    if l := blog.GetOriginalLocale(); l != "" && !importLocalePattern.MatchString(l) {
+      rec.errorf("invalid locale %q", l)
+    }
+  }
+}
+
+// importCheckpoint is a line of the checkpoint file, written as each
+// record is imported so that an interrupted import resumes where it stopped.
+type importCheckpoint struct {
+  Source       string `json:"source"`
+  BlogID       uint64 `json:"blog_id,omitempty"`
+  QuarantineID uint64 `json:"quarantine_id,omitempty"`
+  // unset while the large content of the blog is still being uploaded
+  Done bool `json:"done"`
+}
+
+// loadCheckpoint returns the last line of path for every source, nothing if
+// the file doesn't exist.
+func loadCheckpoint(path string) (map[string]importCheckpoint, error) {
+  done := make(map[string]importCheckpoint)
+  f, err := os.Open(path)
+  if os.IsNotExist(err) {
+    return done, nil
+  }
+  if err != nil {
+    return nil, err
+  }
+  defer f.Close()
+  scanner := bufio.NewScanner(f)
+  for scanner.Scan() {
+    var cp importCheckpoint
+    if err := json.Unmarshal(scanner.Bytes(), &cp); err != nil {
+      // the last line can be cut by a crash
+      continue
+    }
+    done[cp.Source] = cp
+  }
+  return done, scanner.Err()
+}
+
+type checkpointWriter struct {
+  f *os.File
+}
+
+func (w *checkpointWriter) record(cp importCheckpoint) error {
+  line, err := json.Marshal(cp)
+  if err != nil {
+    return err
+  }
+  if _, err := w.f.Write(append(line, '\n')); err != nil {
+    return err
+  }
+  return w.f.Sync()
+}
+
+// runImport implements the "import" command, which creates blogs from a CSV
+// file or a folder of Markdown files through the ImportBlogs stream:
+//
+//   blog_client import -csv posts.csv -columns title=Headline,content=Body -dry-run
+//   blog_client import -dir posts/ -author legacy -token secret
+func runImport(args []string) {
+  fs := flag.NewFlagSet("import", flag.ExitOnError)
+  addr := fs.String("addr", "localhost:50051", "address of the blog server")
+  csvPath := fs.String("csv", "", "CSV file to import, its first row naming the columns")
+  dir := fs.String("dir", "", "folder of Markdown files with YAML front matter to import")
+  columns := fs.String("columns", "", "comma separated field=column pairs for the CSV columns or front matter keys that don't have the name of their field")
+  author := fs.String("author", "", "author_id of the records without one")
+  token := fs.String("token", "", "bearer token of the calls, for servers started with -auth-tokens")
+  dryRun := fs.Bool("dry-run", false, "only read and validate the records, and report the problems")
+  skipInvalid := fs.Bool("skip-invalid", false, "import the valid records even if others have errors")
+  checkpointPath := fs.String("checkpoint", "", "file recording the imported records, to resume an interrupted import; defaults to the input path with .checkpoint appended")
+  fs.Parse(args)
+
+  if (*csvPath == "") == (*dir == "") {
+    log.Fatalf("Give either -csv or -dir")
+  }
+  mapping, err := parseMapping(*columns)
+  if err != nil {
+    log.Fatalf("%v", err)
+  }
+  input := *csvPath
+  var records []*importRecord
+  if *csvPath != "" {
+    records, err = readCSV(*csvPath, mapping, func(format string, args ...interface{}) {
+      fmt.Printf("warning: "+format+"\n", args...)
+    })
+  } else {
+    input = filepath.Clean(*dir)
+    records, err = readMarkdownDir(*dir, mapping)
+  }
+  if err != nil {
+    log.Fatalf("Could not read %v: %v", input, err)
+  }
+  validateRecords(records, *author)
+
+  invalid := 0
+  for _, rec := range records {
+    for _, w := range rec.warnings {
+      fmt.Printf("%v: warning: %v\n", rec.source, w)
+    }
+    for _, e := range rec.errors {
+      fmt.Printf("%v: error: %v\n", rec.source, e)
+    }
+    if len(rec.errors) > 0 {
+      invalid++
+    }
+  }
+  fmt.Printf("%v records read from %v, %v valid, %v with errors\n", len(records), input, len(records)-invalid, invalid)
+  if *dryRun {
+    if invalid > 0 {
+      os.Exit(1)
+    }
+    return
+  }
+  if invalid > 0 && !*skipInvalid {
+    log.Fatalf("Fix the records with errors, or import the others with -skip-invalid")
+  }
+
+  if *checkpointPath == "" {
+    *checkpointPath = input + ".checkpoint"
+  }
+  done, err := loadCheckpoint(*checkpointPath)
+  if err != nil {
+    log.Fatalf("Could not read the checkpoint: %v", err)
+  }
+  f, err := os.OpenFile(*checkpointPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+  if err != nil {
+    log.Fatalf("Could not open the checkpoint: %v", err)
+  }
+  defer f.Close()
+
+  var pending []*importRecord
+  for _, rec := range records {
+    if len(rec.errors) == 0 && !done[rec.source].Done {
+      pending = append(pending, rec)
+    }
+  }
+  if skipped := len(records) - invalid - len(pending); skipped > 0 {
+    fmt.Printf("Resuming from %v: %v records already imported\n", *checkpointPath, skipped)
+  }
+
+  cc, err := grpc.Dial(*addr, grpc.WithInsecure())
+  if err != nil {
+    log.Fatalf("Could not connect: %v", err)
+  }
+  defer cc.Close()
+  ctx := context.Background()
+  if *token != "" {
+    ctx = withToken(*token)
+  }
+  imported, failed := importRecords(ctx, blogpb.NewBlogServiceClient(cc), pending, done, &checkpointWriter{f: f})
+  fmt.Printf("Imported %v records, %v failed\n", imported, failed)
+  if failed > 0 {
+    fmt.Printf("Run the same command again to retry the failed records\n")
+    os.Exit(1)
+  }
+}
+
+// importRecords sends records through ImportBlogs and uploads the large
+// contents once their blog is created. Records whose blog was created by an
+// earlier run, but whose upload didn't finish, only get their content
+// uploaded again. It returns the number of records imported and failed.
+func importRecords(ctx context.Context, c blogpb.BlogServiceClient, records []*importRecord, done map[string]importCheckpoint, cp *checkpointWriter) (int, int) {
+  imported, failed := 0, 0
+  total := len(records)
+  progress := func(rec *importRecord, format string, args ...interface{}) {
+    fmt.Printf("[%v/%v] %v: %v\n", imported+failed, total, rec.source, fmt.Sprintf(format, args...))
+  }
+  // finish uploads the large content of rec to blog id and records it.
+  finish := func(rec *importRecord, id uint64) {
+    if err := uploadContent(ctx, c, id, []byte(rec.blog.GetContent())); err != nil {
+      failed++
+      progress(rec, "blog %v created, but the upload of its content failed: %v", id, err)
+      return
+    }
+    if err := cp.record(importCheckpoint{Source: rec.source, BlogID: id, Done: true}); err != nil {
+      log.Fatalf("Could not write the checkpoint: %v", err)
+    }
+    imported++
+    progress(rec, "blog %v, %v bytes of content uploaded", id, len(rec.blog.GetContent()))
+  }
+
+  var toSend []*importRecord
+  for _, rec := range records {
+    if id := done[rec.source].BlogID; id != 0 {
+      finish(rec, id)
+    } else {
+      toSend = append(toSend, rec)
+    }
+  }
+  if len(toSend) == 0 {
+    return imported, failed
+  }
+
+  stream, err := c.ImportBlogs(ctx)
+  if err != nil {
+    log.Fatalf("Error while calling ImportBlogs RPC: %v\n\n", err)
+  }
+  go func() {
+    for _, rec := range toSend {
+      blog := rec.blog
+      if len(blog.GetContent()) > importInlineContent {
+        // sent apart, once the blog exists
+        without := *blog
+        without.Content = ""
+        blog = &without
+      }
+      if err := stream.Send(&blogpb.ImportBlogRequest{Source: rec.source, Blog: blog}); err != nil {
+        // Recv gets the error
+        return
+      }
+    }
+    stream.CloseSend()
+  }()
+
+  for _, rec := range toSend {
+    res, err := stream.Recv()
+    if err != nil {
+      log.Fatalf("The import stopped at %v: %v\nRun the same command again to resume it", rec.source, err)
+    }
+    if res.GetCode() != 0 {
+      failed++
+      progress(rec, "failed: %v: %v", codes.Code(res.GetCode()), res.GetMessage())
+      continue
+    }
+    if id := res.GetQuarantineId(); id != 0 {
+      if err := cp.record(importCheckpoint{Source: rec.source, QuarantineID: id, Done: true}); err != nil {
+        log.Fatalf("Could not write the checkpoint: %v", err)
+      }
+      imported++
+      progress(rec, "quarantined by moderation as %v", id)
+      continue
+    }
+    id := res.GetBlog().GetId()
+    if len(rec.blog.GetContent()) > importInlineContent {
+      if err := cp.record(importCheckpoint{Source: rec.source, BlogID: id}); err != nil {
+        log.Fatalf("Could not write the checkpoint: %v", err)
+      }
+      finish(rec, id)
+      continue
+    }
+    if err := cp.record(importCheckpoint{Source: rec.source, BlogID: id, Done: true}); err != nil {
+      log.Fatalf("Could not write the checkpoint: %v", err)
+    }
+    imported++
+    progress(rec, "blog %v", id)
+  }
+  return imported, failed
+}
+
+// uploadContent uploads content as the content of blog id with
+// WriteBlogContent, resuming from the offset the server holds when an
+// attempt is cut.
+func uploadContent(ctx context.Context, c blogpb.BlogServiceClient, id uint64, content []byte) error {
+  offset := uint64(0)
+  var err error
+  for attempt := 0; attempt < 3; attempt++ {
+    var trailer metadata.MD
+    var stream blogpb.BlogService_WriteBlogContentClient
+    stream, err = c.WriteBlogContent(ctx, grpc.Trailer(&trailer))
+    if err != nil {
+      return err
+    }
+    for start := offset; ; start += importChunkSize {
+      end := start + importChunkSize
+      if end >= uint64(len(content)) {
+        end = uint64(len(content))
+      }
+      req := &blogpb.WriteBlogContentRequest{
+        Data:     content[start:end],
+        Complete: end == uint64(len(content)),
+      }
+      if start == offset {
+        req.BlogId = id
+        req.Offset = offset
+      }
+      if stream.Send(req) != nil || req.GetComplete() {
+        break
+      }
+    }
+    if _, err = stream.CloseAndRecv(); err == nil {
+      return nil
+    }
+    v := trailer.Get("x-upload-offset")
+    if status.Code(err) != codes.FailedPrecondition || len(v) == 0 {
+      return err
+    }
+    if offset, err = strconv.ParseUint(v[0], 10, 64); err != nil || offset > uint64(len(content)) {
+      return fmt.Errorf("invalid upload offset %q", v[0])
+    }
+  }
+  return err
+}
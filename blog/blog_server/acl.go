@@ -0,0 +1,229 @@
+package main
+
+import(
+  "context"
+  "encoding/json"
+  "fmt"
+  "io/ioutil"
+  "strings"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+// loadAuthTokens reads the bearer tokens accepted by the server, a JSON object
+// mapping each token to the identity of its caller:
+//
+//   {"s3cr3t": "luis", "t0k3n": "maria"}
+func loadAuthTokens(path string) (map[string]string, error) {
+  data, err := ioutil.ReadFile(path)
+  if err != nil {
+    return nil, err
+  }
+  tokens := make(map[string]string)
+  if err := json.Unmarshal(data, &tokens); err != nil {
+    return nil, fmt.Errorf("%v: %v", path, err)
+  }
+  return tokens, nil
+}
+
+// caller returns the identity of the caller from the bearer token in the
+// authorization metadata, or "" for anonymous callers. Without tokens
+// configured every caller is anonymous and ACLs aren't enforced.
+func (s *server) caller(ctx context.Context) (string, error) {
+  if s.tokens == nil {
+    return "", nil
+  }
+  md, _ := metadata.FromIncomingContext(ctx)
+  values := md.Get("authorization")
+  if len(values) == 0 {
+    return "", nil
+  }
+  token := strings.TrimSpace(values[0])
+  if !strings.HasPrefix(strings.ToLower(token), "bearer ") {
+    return "", status.Error(codes.Unauthenticated, "The authorization metadata must be a bearer token")
+  }
+  caller, ok := s.tokens[strings.TrimSpace(token[len("bearer "):])]
+  if !ok {
+    return "", status.Error(codes.Unauthenticated, "Invalid token")
+  }
+  return caller, nil
+}
+
+// authenticated is caller for the RPCs anonymous callers can't use.
+func (s *server) authenticated(ctx context.Context) (string, error) {
+  caller, err := s.caller(ctx)
+  if err != nil {
+    return "", err
+  }
+  if caller == "" && s.tokens != nil {
+    return "", status.Error(codes.Unauthenticated, "This call needs a bearer token in the authorization metadata")
+  }
+  return caller, nil
+}
+
+// addAdmins accepts the admin tokens, a map like the one of
+// loadAuthTokens. Admins are callers like the others for BlogService.
+func (s *server) addAdmins(tokens map[string]string) {
+  if s.tokens == nil {
+    s.tokens = make(map[string]string)
+  }
+  if s.admins == nil {
+    s.admins = make(map[string]bool)
+  }
+  for token, admin := range tokens {
+    s.tokens[token] = admin
+    s.admins[admin] = true
+  }
+}
+
+// admin is authenticated for the maintenance RPCs, which only admins can
+// call once authentication is on.
+func (s *server) admin(ctx context.Context) (string, error) {
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return "", err
+  }
+  if s.tokens != nil && !s.admins[caller] {
+    return "", status.Error(codes.PermissionDenied, fmt.Sprintf("%v is not an admin", caller))
+  }
+  return caller, nil
+}
+
+// Blogs without an ACL, which could only come from an older leader, can be
+// read by anyone and changed by no one but maintenance.
+
+func isOwner(acl *blogpb.BlogAcl, caller string) bool {
+  return acl != nil && caller != "" && acl.GetOwner() == caller
+}
+
+func canEdit(acl *blogpb.BlogAcl, caller string) bool {
+  return isOwner(acl, caller) || (caller != "" && contains(acl.GetEditors(), caller))
+}
+
+func canRead(acl *blogpb.BlogAcl, caller string) bool {
+  return acl == nil || acl.GetPublic() || canEdit(acl, caller) || (caller != "" && contains(acl.GetViewers(), caller))
+}
+
+func contains(list []string, s string) bool {
+  for _, v := range list {
+    if v == s {
+      return true
+    }
+  }
+  return false
+}
+
+// checkAccess fails when caller can't read blog, or can't edit it if edit is
+// set. An empty caller means ACLs aren't enforced.
+func checkAccess(blog *blogpb.Blog, caller string, edit bool) error {
+  if caller == "" {
+    return nil
+  }
+  if edit && !canEdit(blog.GetAcl(), caller) {
+    return status.Error(codes.PermissionDenied, fmt.Sprintf("%v can't change blog %v", caller, blog.GetId()))
+  }
+  if !canRead(blog.GetAcl(), caller) {
+    return status.Error(codes.PermissionDenied, fmt.Sprintf("%v can't read blog %v", caller, blog.GetId()))
+  }
+  return nil
+}
+
+// checkRead fails when caller can't read blog. Unlike checkAccess it also
+// applies to anonymous callers, which only get to see public blogs.
+func (s *server) checkRead(blog *blogpb.Blog, caller string) error {
+  if s.tokens == nil || canRead(blog.GetAcl(), caller) {
+    return nil
+  }
+  if caller == "" {
+    return status.Error(codes.PermissionDenied, fmt.Sprintf("Blog %v isn't public", blog.GetId()))
+  }
+  return status.Error(codes.PermissionDenied, fmt.Sprintf("%v can't read blog %v", caller, blog.GetId()))
+}
+
+// visibleBlog returns blog the way caller may see it, without the ACL unless
+// caller can edit the blog.
+func (s *server) visibleBlog(blog *blogpb.Blog, caller string) *blogpb.Blog {
+  if s.tokens == nil || canEdit(blog.GetAcl(), caller) {
+    return blog
+  }
+  visible := *blog
+  visible.Acl = nil
+  return &visible
+}
+
+// newBlogACL returns the ACL of a new blog written by caller, based on the
+// one sent by the client. Without authentication the author owns the blog
+// and it is public unless the client says otherwise.
+func (s *server) newBlogACL(blog *blogpb.Blog, caller string) *blogpb.BlogAcl {
+  acl := blog.GetAcl()
+  if acl == nil {
+    acl = &blogpb.BlogAcl{
+      Public: s.tokens == nil,
+    }
+  }
+  if caller != "" {
+    acl.Owner = caller
+  } else if acl.GetOwner() == "" {
+    acl.Owner = blog.GetAuthorId()
+  }
+  return acl
+}
+
+func (s *server) GetBlogAcl(ctx context.Context, req *blogpb.GetBlogAclRequest) (*blogpb.GetBlogAclResponse, error) {
+  fmt.Printf("GetBlogAcl was invoked with: %v\n\n", req)
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  id := req.GetBlogId()
+  var blog *blogpb.Blog
+  err = s.viewBlog(id, func(tx *bolt.Tx) error {
+    var err error
+    blog, err = getBlog(tx, id)
+    if err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+    }
+    if blog == nil {
+      return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", id))
+    }
+    return checkAccess(blog, caller, true)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.GetBlogAclResponse{
+    Acl: blog.GetAcl(),
+  }, nil
+}
+
+func (s *server) SetBlogAcl(ctx context.Context, req *blogpb.SetBlogAclRequest) (*blogpb.SetBlogAclResponse, error) {
+  fmt.Printf("SetBlogAcl was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if req.GetAcl() == nil {
+    return nil, status.Error(codes.InvalidArgument, "The ACL is required")
+  }
+
+  blog, err := s.execute(ctx, &blogpb.BlogCommand{
+    Op:     blogpb.BlogCommand_SET_ACL,
+    BlogId: req.GetBlogId(),
+    Acl:    req.GetAcl(),
+    Caller: caller,
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.SetBlogAclResponse{
+    Acl: blog.GetAcl(),
+  }, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import(
+  "context"
+  "flag"
+  "fmt"
+  "io/ioutil"
+  "log"
+  "os"
+  "path/filepath"
+  "sync"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+)
+
+// setBatching enables group commit of creates: concurrent CreateBlog calls
+// are merged by Bolt into a single transaction of up to size creates, waiting
+// at most delay for others to join, so they share one fsync. A size of 0
+// keeps one transaction per create.
+func (s *server) setBatching(size int, delay time.Duration) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  s.batchSize = size
+  s.batchDelay = delay
+  s.applyBatching(s.db)
+  if s.shards != nil {
+    for _, db := range s.shards.dbs {
+      s.applyBatching(db)
+    }
+  }
+}
+
+// applyBatching sets the batch limits of the server on db, which must be
+// done again whenever a database is reopened.
+func (s *server) applyBatching(db *bolt.DB) {
+  if s.batchSize > 0 {
+    db.MaxBatchSize = s.batchSize
+    db.MaxBatchDelay = s.batchDelay
+  }
+}
+
+// batchBlog is updateBlog for creates. With batching enabled fn may be merged
+// with concurrent calls in one transaction, and be run again on its own if
+// another call of the batch fails, so it must only depend on tx.
+func (s *server) batchBlog(id uint64, fn func(*bolt.Tx) error) error {
+  if s.batchSize == 0 {
+    return s.updateBlog(id, fn)
+  }
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  db := s.db
+  if s.shards != nil {
+    db = s.shards.dbs[shardIndex(id, len(s.shards.dbs))]
+  }
+  err := db.Batch(fn)
+  if err == nil {
+    s.changes.notify()
+  }
+  return err
+}
+
+// runBench implements the "bench" subcommand, which measures how many blogs
+// per second can be created on a scratch database with one transaction per
+// create and with batching:
+//
+//   blog_server bench -n 10000 -concurrency 64
+func runBench(args []string) {
+  fs := flag.NewFlagSet("bench", flag.ExitOnError)
+  n := fs.Int("n", 10000, "number of blogs to create in each run")
+  concurrency := fs.Int("concurrency", 64, "number of concurrent writers")
+  batchSize := fs.Int("batch-size", 1000, "largest batch of the batched run")
+  batchDelay := fs.Duration("batch-delay", 10*time.Millisecond, "longest wait for a batch to fill up in the batched run")
+  fs.Parse(args)
+
+  dir, err := ioutil.TempDir("", "blog-bench")
+  if err != nil {
+    log.Fatalf("Could not create a scratch directory: %v", err)
+  }
+  defer os.RemoveAll(dir)
+
+  single := benchCreates(filepath.Join(dir, "single.db"), *n, *concurrency, 0, 0)
+  batched := benchCreates(filepath.Join(dir, "batched.db"), *n, *concurrency, *batchSize, *batchDelay)
+  fmt.Printf("one transaction per create: %v blogs in %v, %.0f blogs/s\n", *n, single, float64(*n)/single.Seconds())
+  fmt.Printf("batched (size %v, delay %v): %v blogs in %v, %.0f blogs/s\n", *batchSize, *batchDelay, *n, batched, float64(*n)/batched.Seconds())
+  fmt.Printf("speedup: %.1fx\n", single.Seconds()/batched.Seconds())
+}
+
+// benchCreates creates n blogs from concurrency goroutines on a new database
+// at path, the same way CreateBlog does, and returns how long it took.
+func benchCreates(path string, n, concurrency, batchSize int, batchDelay time.Duration) time.Duration {
+  db, err := bolt.Open(path, 0600, nil)
+  if err != nil {
+    log.Fatalf("Could not open database: %v", err)
+  }
+  defer db.Close()
+  if _, err := migrateDB(db, false, func(string, ...interface{}) {}); err != nil {
+    log.Fatalf("Could not migrate database: %v", err)
+  }
+  s := &server{db: db, path: path}
+  s.setBatching(batchSize, batchDelay)
+
+  work := make(chan int)
+  var wg sync.WaitGroup
+  start := time.Now()
+  for i := 0; i < concurrency; i++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      for i := range work {
+        _, err := s.execute(context.Background(), &blogpb.BlogCommand{
+          Op: blogpb.BlogCommand_CREATE,
+          Blog: &blogpb.Blog{
+            AuthorId: "bench",
+            Title:    fmt.Sprintf("Blog %v", i),
+            Content:  "Lorem ipsum dolor sit amet, consectetur adipiscing elit.",
+          },
+        })
+        if err != nil {
+          log.Fatalf("Could not create blog: %v", err)
+        }
+      }
+    }()
+  }
+  for i := 0; i < n; i++ {
+    work <- i
+  }
+  close(work)
+  wg.Wait()
+  return time.Since(start)
+}
@@ -0,0 +1,147 @@
+package main
+
+import(
+  "container/list"
+  "context"
+  "fmt"
+  "sync"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/golang/protobuf/proto"
+)
+
+// blogCache keeps the most recently read blogs decoded, bounded both by
+// number of blogs and by their encoded size. A nil cache caches nothing.
+type blogCache struct {
+  maxEntries int
+  maxBytes   int64
+
+  mu    sync.Mutex
+  ll    *list.List // front is the most recently used
+  items map[uint64]*list.Element
+  bytes int64
+  // gen changes on every invalidation, so that a blog read from the
+  // database before a write can't be cached after it
+  gen uint64
+
+  hits      uint64
+  misses    uint64
+  evictions uint64
+}
+
+type cacheEntry struct {
+  blog *blogpb.Blog
+  size int64
+}
+
+func newBlogCache(maxEntries int, maxBytes int64) *blogCache {
+  if maxEntries <= 0 || maxBytes <= 0 {
+    return nil
+  }
+  return &blogCache{
+    maxEntries: maxEntries,
+    maxBytes:   maxBytes,
+    ll:         list.New(),
+    items:      make(map[uint64]*list.Element),
+  }
+}
+
+// get returns a copy of the cached blog id, or nil and the generation to
+// pass to add once the blog is read from the database.
+func (c *blogCache) get(id uint64) (*blogpb.Blog, uint64) {
+  if c == nil {
+    return nil, 0
+  }
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  if e, ok := c.items[id]; ok {
+    c.hits++
+    c.ll.MoveToFront(e)
+    // callers may change the blog they get
+    blog := *e.Value.(*cacheEntry).blog
+    return &blog, 0
+  }
+  c.misses++
+  return nil, c.gen
+}
+
+// add caches blog unless something was invalidated since gen was returned by
+// get.
+func (c *blogCache) add(blog *blogpb.Blog, gen uint64) {
+  if c == nil {
+    return
+  }
+  size := int64(proto.Size(blog))
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  if gen != c.gen || size > c.maxBytes {
+    return
+  }
+  if e, ok := c.items[blog.GetId()]; ok {
+    c.removeElement(e)
+  }
+  // keep a copy of our own, blog goes back to the caller
+  cached := *blog
+  entry := &cacheEntry{blog: &cached, size: size}
+  c.items[blog.GetId()] = c.ll.PushFront(entry)
+  c.bytes += size
+  for c.ll.Len() > c.maxEntries || c.bytes > c.maxBytes {
+    c.removeElement(c.ll.Back())
+    c.evictions++
+  }
+}
+
+// invalidate drops blog id after it was written.
+func (c *blogCache) invalidate(id uint64) {
+  if c == nil {
+    return
+  }
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  c.gen++
+  if e, ok := c.items[id]; ok {
+    c.removeElement(e)
+  }
+}
+
+// clear drops every blog, when the whole database is replaced.
+func (c *blogCache) clear() {
+  if c == nil {
+    return
+  }
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  c.gen++
+  c.ll.Init()
+  c.items = make(map[uint64]*list.Element)
+  c.bytes = 0
+}
+
+func (c *blogCache) removeElement(e *list.Element) {
+  entry := c.ll.Remove(e).(*cacheEntry)
+  delete(c.items, entry.blog.GetId())
+  c.bytes -= entry.size
+}
+
+func (s *server) CacheStats(ctx context.Context, req *blogpb.CacheStatsRequest) (*blogpb.CacheStatsResponse, error) {
+  fmt.Printf("CacheStats was invoked\n\n")
+  if _, err := s.admin(ctx); err != nil {
+    return nil, err
+  }
+
+  c := s.cache
+  if c == nil {
+    return &blogpb.CacheStatsResponse{}, nil
+  }
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  return &blogpb.CacheStatsResponse{
+    Entries:    int64(c.ll.Len()),
+    Bytes:      c.bytes,
+    MaxEntries: int64(c.maxEntries),
+    MaxBytes:   c.maxBytes,
+    Hits:       c.hits,
+    Misses:     c.misses,
+    Evictions:  c.evictions,
+  }, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import(
+  "context"
+  "testing"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+func TestCacheStatsNeedsAnAdmin(t *testing.T) {
+  s := newTestServer(t, t.TempDir())
+  defer s.Close()
+  s.cache = newBlogCache(100, 1<<20)
+  s.tokens = map[string]string{"user-token": "luis"}
+  s.addAdmins(map[string]string{"admin-token": "root"})
+  ctx := context.Background()
+
+  for token, want := range map[string]codes.Code{
+    "":            codes.Unauthenticated,
+    "user-token":  codes.PermissionDenied,
+    "admin-token": codes.OK,
+  } {
+    callCtx := ctx
+    if token != "" {
+      callCtx = withToken(ctx, token)
+    }
+    if _, err := s.CacheStats(callCtx, &blogpb.CacheStatsRequest{}); status.Code(err) != want {
+      t.Errorf("CacheStats with %q: got %v, want %v", token, err, want)
+    }
+  }
+}
@@ -0,0 +1,403 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "io"
+  "os"
+  "path/filepath"
+  "strconv"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "github.com/golang/protobuf/proto"
+  "github.com/hashicorp/raft"
+  raftboltdb "github.com/hashicorp/raft-boltdb"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+const (
+  raftApplyTimeout  = 10 * time.Second
+  raftSnapshotsKept = 2
+)
+
+// raftAppliedIndexKey, in the metadata bucket, is the index of the last Raft
+// log entry applied to the database. It is written in the same transaction
+// as the entry, so entries replayed after a restart are applied only once.
+var raftAppliedIndexKey = []byte("raft_applied_index")
+
+// cluster is the Raft state of a server running in cluster mode. Writes are
+// committed through the Raft log before being applied to the database of
+// every node by blogFSM.
+type cluster struct {
+  id        raft.ServerID
+  raft      *raft.Raft
+  transport raft.Transport
+  logStore  *raftboltdb.BoltStore
+}
+
+type clusterConfig struct {
+  // id identifies the node, it must stay the same across restarts
+  id string
+  // dir holds the Raft log and the snapshots
+  dir string
+  // bootstrap starts a new cluster made of this node only, other nodes are
+  // then added with AddVoter. It is ignored if the node already has state.
+  bootstrap bool
+  // transport carries the Raft RPCs, a grpcTransport in real deployments.
+  // Tests can run a whole cluster in one process with raft.NewInmemTransport.
+  transport raft.Transport
+}
+
+func newCluster(s *server, cfg clusterConfig) (*cluster, error) {
+  if err := os.MkdirAll(cfg.dir, 0700); err != nil {
+    return nil, err
+  }
+  logStore, err := raftboltdb.NewBoltStore(filepath.Join(cfg.dir, "raft.db"))
+  if err != nil {
+    return nil, fmt.Errorf("could not open the raft log: %v", err)
+  }
+  snapshots, err := raft.NewFileSnapshotStore(cfg.dir, raftSnapshotsKept, os.Stderr)
+  if err != nil {
+    logStore.Close()
+    return nil, fmt.Errorf("could not open the snapshot store: %v", err)
+  }
+
+  conf := raft.DefaultConfig()
+  conf.LocalID = raft.ServerID(cfg.id)
+  // an APPEND_CONTENT command carries up to contentAppendSize bytes, keep
+  // the batches of entries under the 4MB a gRPC message can carry
+  conf.MaxAppendEntries = 12
+
+  if cfg.bootstrap {
+    hasState, err := raft.HasExistingState(logStore, logStore, snapshots)
+    if err != nil {
+      logStore.Close()
+      return nil, err
+    }
+    if !hasState {
+      fmt.Printf("Bootstrapping cluster with node %v at %v\n\n", cfg.id, cfg.transport.LocalAddr())
+      err := raft.BootstrapCluster(conf, logStore, logStore, snapshots, cfg.transport, raft.Configuration{
+        Servers: []raft.Server{{
+          ID:      conf.LocalID,
+          Address: cfg.transport.LocalAddr(),
+        }},
+      })
+      if err != nil {
+        logStore.Close()
+        return nil, fmt.Errorf("could not bootstrap the cluster: %v", err)
+      }
+    }
+  }
+
+  r, err := raft.NewRaft(conf, &blogFSM{s: s}, logStore, logStore, snapshots, cfg.transport)
+  if err != nil {
+    logStore.Close()
+    return nil, err
+  }
+  return &cluster{
+    id:        conf.LocalID,
+    raft:      r,
+    transport: cfg.transport,
+    logStore:  logStore,
+  }, nil
+}
+
+func (c *cluster) Close() {
+  fmt.Println("Stopping Raft")
+  if err := c.raft.Shutdown().Error(); err != nil {
+    fmt.Printf("Error while stopping Raft: %v\n", err)
+  }
+  if closer, ok := c.transport.(raft.WithClose); ok {
+    closer.Close()
+  }
+  c.logStore.Close()
+}
+
+// checkLeader rejects writes on nodes that aren't the leader, sending its
+// address back in the x-blog-leader trailer like read-only followers do.
+func (c *cluster) checkLeader(ctx context.Context) error {
+  if c.raft.State() == raft.Leader {
+    return nil
+  }
+  leader := string(c.raft.Leader())
+  if leader == "" {
+    return status.Error(codes.Unavailable, "The cluster has no leader at the moment, try again later")
+  }
+  grpc.SetTrailer(ctx, metadata.Pairs("x-blog-leader", leader))
+  return status.Error(codes.FailedPrecondition, fmt.Sprintf("This node is not the leader of the cluster, send writes to %v", leader))
+}
+
+// commandResult is what blogFSM.Apply returns for a command.
+type commandResult struct {
+  blog   *blogpb.Blog
+  series *blogpb.Series // for the series commands
+  err    error
+}
+
+// apply commits cmd through the Raft log and waits for this node to apply it.
+func (c *cluster) apply(ctx context.Context, cmd *blogpb.BlogCommand) (*blogpb.Blog, error) {
+  res := c.commit(ctx, cmd)
+  return res.blog, res.err
+}
+
+// commit is apply returning the whole result of the command.
+func (c *cluster) commit(ctx context.Context, cmd *blogpb.BlogCommand) commandResult {
+  // the nodes apply the command at different times, the changelog records
+  // the time of the leader
+  if cmd.GetTimestamp() == 0 {
+    cmd.Timestamp = time.Now().UnixNano()
+  }
+  data, err := proto.Marshal(cmd)
+  if err == nil {
+    // the Raft log holds the contents of the blogs too
+    data, err = sealCommand(data)
+  }
+  if err != nil {
+    return commandResult{err: status.Error(codes.Internal, fmt.Sprintf("Could not marshal command: %v", err))}
+  }
+  timeout := raftApplyTimeout
+  if deadline, ok := ctx.Deadline(); ok {
+    // Apply takes a timeout of 0 as no timeout at all
+    if timeout = time.Until(deadline); timeout <= 0 {
+      return commandResult{err: status.Error(codes.DeadlineExceeded, "The deadline passed before the write was committed")}
+    }
+  }
+  f := c.raft.Apply(data, timeout)
+  if err := f.Error(); err != nil {
+    switch err {
+    case raft.ErrNotLeader, raft.ErrLeadershipLost, raft.ErrLeadershipTransferInProgress:
+      return commandResult{err: c.checkLeader(ctx)}
+    case raft.ErrEnqueueTimeout:
+      return commandResult{err: status.Error(codes.DeadlineExceeded, err.Error())}
+    }
+    return commandResult{err: status.Error(codes.Unavailable, fmt.Sprintf("Could not commit the write: %v", err))}
+  }
+  return f.Response().(commandResult)
+}
+
+func (s *server) AddVoter(ctx context.Context, req *blogpb.AddVoterRequest) (*blogpb.AddVoterResponse, error) {
+  fmt.Printf("AddVoter was invoked with: %v\n\n", req)
+  if _, err := s.admin(ctx); err != nil {
+    return nil, err
+  }
+  c, err := s.clusterLeader(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if req.GetId() == "" || req.GetAddress() == "" {
+    return nil, status.Error(codes.InvalidArgument, "Both the id and the address of the node are required")
+  }
+  f := c.raft.AddVoter(raft.ServerID(req.GetId()), raft.ServerAddress(req.GetAddress()), 0, raftApplyTimeout)
+  if err := f.Error(); err != nil {
+    return nil, status.Error(codes.Unavailable, fmt.Sprintf("Could not add node %v: %v", req.GetId(), err))
+  }
+  return &blogpb.AddVoterResponse{}, nil
+}
+
+func (s *server) RemoveServer(ctx context.Context, req *blogpb.RemoveServerRequest) (*blogpb.RemoveServerResponse, error) {
+  fmt.Printf("RemoveServer was invoked with: %v\n\n", req)
+  if _, err := s.admin(ctx); err != nil {
+    return nil, err
+  }
+  c, err := s.clusterLeader(ctx)
+  if err != nil {
+    return nil, err
+  }
+  f := c.raft.RemoveServer(raft.ServerID(req.GetId()), 0, raftApplyTimeout)
+  if err := f.Error(); err != nil {
+    return nil, status.Error(codes.Unavailable, fmt.Sprintf("Could not remove node %v: %v", req.GetId(), err))
+  }
+  return &blogpb.RemoveServerResponse{}, nil
+}
+
+// addRaftNodes accepts the tokens of the nodes of the cluster, a map like
+// the one of loadAuthTokens.
+func (s *server) addRaftNodes(tokens map[string]string) {
+  if s.tokens == nil {
+    s.tokens = make(map[string]string)
+  }
+  if s.raftNodes == nil {
+    s.raftNodes = make(map[string]bool)
+  }
+  for token, node := range tokens {
+    s.tokens[token] = node
+    s.raftNodes[node] = true
+  }
+}
+
+// raftNode is authenticated for RaftTransport, which can rewrite the log
+// and replace the database, so only the nodes of the cluster can call it,
+// whether or not authentication is on for the other services.
+func (s *server) raftNode(ctx context.Context) error {
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return err
+  }
+  if !s.raftNodes[caller] {
+    if caller == "" {
+      return status.Error(codes.Unauthenticated, "The Raft RPCs need the bearer token of a node")
+    }
+    return status.Error(codes.PermissionDenied, fmt.Sprintf("%v is not a node of the cluster", caller))
+  }
+  return nil
+}
+
+func (s *server) ClusterStatus(ctx context.Context, req *blogpb.ClusterStatusRequest) (*blogpb.ClusterStatusResponse, error) {
+  if _, err := s.admin(ctx); err != nil {
+    return nil, err
+  }
+  c := s.cluster
+  if c == nil {
+    return nil, status.Error(codes.FailedPrecondition, "This server is not running in cluster mode")
+  }
+  stats := c.raft.Stats()
+  term, _ := strconv.ParseUint(stats["term"], 10, 64)
+  leader := c.raft.Leader()
+  res := &blogpb.ClusterStatusResponse{
+    Id:            string(c.id),
+    State:         c.raft.State().String(),
+    LeaderAddress: string(leader),
+    Term:          term,
+    LastIndex:     c.raft.LastIndex(),
+    AppliedIndex:  c.raft.AppliedIndex(),
+  }
+  f := c.raft.GetConfiguration()
+  if err := f.Error(); err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read the configuration: %v", err))
+  }
+  for _, srv := range f.Configuration().Servers {
+    res.Servers = append(res.Servers, &blogpb.ClusterServer{
+      Id:      string(srv.ID),
+      Address: string(srv.Address),
+      Voter:   srv.Suffrage == raft.Voter,
+      Leader:  srv.Address == leader,
+    })
+  }
+  return res, nil
+}
+
+// clusterLeader returns the cluster of s if this node is its leader, since
+// only the leader can change the membership.
+func (s *server) clusterLeader(ctx context.Context) (*cluster, error) {
+  if s.cluster == nil {
+    return nil, status.Error(codes.FailedPrecondition, "This server is not running in cluster mode")
+  }
+  if err := s.cluster.checkLeader(ctx); err != nil {
+    return nil, err
+  }
+  return s.cluster, nil
+}
+
+// blogFSM is the Raft state machine, the Bolt database of the server.
+type blogFSM struct {
+  s *server
+}
+
+func (f *blogFSM) Apply(l *raft.Log) interface{} {
+  cmd := &blogpb.BlogCommand{}
+  data, err := openCommand(l.Data)
+  if err == nil {
+    err = proto.Unmarshal(data, cmd)
+  }
+  if err != nil {
+    return commandResult{err: status.Error(codes.Internal, fmt.Sprintf("Could not unmarshal command: %v", err))}
+  }
+  var res commandResult
+  err = f.s.update(func(tx *bolt.Tx) error {
+    meta, err := tx.CreateBucketIfNotExists(metaBucket)
+    if err != nil {
+      return err
+    }
+    if v := meta.Get(raftAppliedIndexKey); v != nil && btoui(v) >= l.Index {
+      // applied before a restart
+      return nil
+    }
+    if err := meta.Put(raftAppliedIndexKey, uitob(l.Index)); err != nil {
+      return err
+    }
+    // a command that fails, such as an update of a missing blog, fails the
+    // same way on every node, but its index must still be recorded
+    if isSeriesCommand(cmd) {
+      res.series, res.err = applySeriesCommand(tx, cmd)
+    } else {
+      res.blog, res.err = applyCommand(tx, cmd)
+    }
+    return nil
+  })
+  if err != nil {
+    res.err = status.Error(codes.Internal, fmt.Sprintf("Could not apply command: %v", err))
+  }
+  f.s.written(cmd, res.blog)
+  return res
+}
+
+// Snapshot opens a read transaction, which Persist copies to the snapshot
+// while later commands keep being applied.
+func (f *blogFSM) Snapshot() (raft.FSMSnapshot, error) {
+  f.s.mu.RLock()
+  defer f.s.mu.RUnlock()
+  tx, err := f.s.db.Begin(false)
+  if err != nil {
+    return nil, err
+  }
+  return &blogSnapshot{tx: tx}, nil
+}
+
+// Restore replaces the database by the snapshot.
+func (f *blogFSM) Restore(rc io.ReadCloser) error {
+  defer rc.Close()
+  fmt.Printf("Restoring database from a snapshot\n\n")
+
+  tmpPath := f.s.path + ".restore"
+  tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+  if err != nil {
+    return err
+  }
+  _, err = io.Copy(tmp, rc)
+  if err == nil {
+    err = tmp.Sync()
+  }
+  if closeErr := tmp.Close(); err == nil {
+    err = closeErr
+  }
+  if err != nil {
+    os.Remove(tmpPath)
+    return err
+  }
+
+  f.s.mu.Lock()
+  defer f.s.mu.Unlock()
+  db, err := swapDB(f.s.db, tmpPath, f.s.path)
+  if db != nil {
+    f.s.applyBatching(db)
+    f.s.db = db
+  }
+  f.s.cache.clear()
+  f.s.related.reset()
+  if err != nil {
+    return err
+  }
+  f.s.changes.notify()
+  return nil
+}
+
+type blogSnapshot struct {
+  tx *bolt.Tx
+}
+
+func (s *blogSnapshot) Persist(sink raft.SnapshotSink) error {
+  if _, err := s.tx.WriteTo(sink); err != nil {
+    sink.Cancel()
+    return err
+  }
+  return sink.Close()
+}
+
+func (s *blogSnapshot) Release() {
+  s.tx.Rollback()
+}
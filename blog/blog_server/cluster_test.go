@@ -0,0 +1,282 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "os"
+  "path/filepath"
+  "testing"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "github.com/hashicorp/raft"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// clusterTimeout bounds the waits for elections and replication, Raft
+// running with its default timeouts of a second
+const clusterTimeout = 15 * time.Second
+
+// testNode is a node of a cluster running in the test process, its Raft
+// RPCs going through an in-memory transport.
+type testNode struct {
+  id        string
+  dir       string
+  s         *server
+  transport *raft.InmemTransport
+  stopped   bool
+}
+
+func startTestNode(t *testing.T, id, dir string, bootstrap bool) *testNode {
+  t.Helper()
+  n := &testNode{id: id, dir: dir}
+  n.s = newTestServer(t, dir)
+  _, n.transport = raft.NewInmemTransport(raft.ServerAddress(id))
+  var err error
+  n.s.cluster, err = newCluster(n.s, clusterConfig{
+    id:        id,
+    dir:       filepath.Join(dir, "raft"),
+    bootstrap: bootstrap,
+    transport: n.transport,
+  })
+  if err != nil {
+    t.Fatalf("newCluster(%v): %v", id, err)
+  }
+  t.Cleanup(n.stop)
+  return n
+}
+
+func (n *testNode) stop() {
+  if n.stopped {
+    return
+  }
+  n.stopped = true
+  n.s.cluster.Close()
+  n.s.Close()
+}
+
+// connect lets every node reach the others.
+func connect(nodes []*testNode) {
+  for _, a := range nodes {
+    for _, b := range nodes {
+      if a != b {
+        a.transport.Connect(b.transport.LocalAddr(), b.transport)
+      }
+    }
+  }
+}
+
+// waitLeader returns the node elected leader among the running nodes.
+func waitLeader(t *testing.T, nodes []*testNode) *testNode {
+  t.Helper()
+  var leader *testNode
+  eventually(t, clusterTimeout, func() error {
+    for _, n := range nodes {
+      if !n.stopped && n.s.cluster.raft.State() == raft.Leader {
+        leader = n
+        return nil
+      }
+    }
+    return fmt.Errorf("no leader elected")
+  })
+  return leader
+}
+
+// newTestCluster starts a cluster of size nodes, the first one bootstrapping
+// it and adding the others.
+func newTestCluster(t *testing.T, size int) []*testNode {
+  t.Helper()
+  var nodes []*testNode
+  for i := 0; i < size; i++ {
+    nodes = append(nodes, startTestNode(t, fmt.Sprintf("node%v", i+1), t.TempDir(), i == 0))
+  }
+  connect(nodes)
+  leader := waitLeader(t, nodes[:1])
+  for _, n := range nodes[1:] {
+    f := leader.s.cluster.raft.AddVoter(raft.ServerID(n.id), n.transport.LocalAddr(), 0, clusterTimeout)
+    if err := f.Error(); err != nil {
+      t.Fatalf("AddVoter(%v): %v", n.id, err)
+    }
+  }
+  return nodes
+}
+
+// waitBlog waits for blog to be readable on every running node as written.
+func waitBlog(t *testing.T, nodes []*testNode, blog *blogpb.Blog) {
+  t.Helper()
+  for _, n := range nodes {
+    if n.stopped {
+      continue
+    }
+    eventually(t, clusterTimeout, func() error {
+      res, err := n.s.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: blog.GetId()})
+      if err != nil {
+        return fmt.Errorf("%v: %v", n.id, err)
+      }
+      if res.GetBlog().GetTitle() != blog.GetTitle() {
+        return fmt.Errorf("%v: got title %q, want %q", n.id, res.GetBlog().GetTitle(), blog.GetTitle())
+      }
+      return nil
+    })
+  }
+}
+
+// changeTimes returns the timestamps of the changelog of s.
+func changeTimes(t *testing.T, s *server) []int64 {
+  t.Helper()
+  var times []int64
+  err := s.view(func(tx *bolt.Tx) error {
+    changes, err := readChanges(tx, 1, 100)
+    for _, entry := range changes {
+      times = append(times, entry.GetTimestamp())
+    }
+    return err
+  })
+  if err != nil {
+    t.Fatal(err)
+  }
+  return times
+}
+
+func TestClusterCommit(t *testing.T) {
+  nodes := newTestCluster(t, 3)
+  leader := waitLeader(t, nodes)
+  ctx := context.Background()
+
+  blog := createTestBlog(t, ctx, leader.s, "First")
+  waitBlog(t, nodes, blog)
+
+  blog.Title = "First, edited"
+  if _, err := leader.s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: blog}); err != nil {
+    t.Fatalf("UpdateBlog: %v", err)
+  }
+  waitBlog(t, nodes, blog)
+
+  // every node records the time the leader took the writes
+  want := changeTimes(t, leader.s)
+  if len(want) != 2 || want[0] == 0 || want[1] == 0 {
+    t.Fatalf("got changelog timestamps %v on the leader, want 2", want)
+  }
+  for _, n := range nodes {
+    if got := changeTimes(t, n.s); fmt.Sprint(got) != fmt.Sprint(want) {
+      t.Errorf("%v: got changelog timestamps %v, want %v", n.id, got, want)
+    }
+  }
+
+  for _, n := range nodes {
+    if n == leader {
+      continue
+    }
+    _, err := n.s.CreateBlog(ctx, &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{Title: "Lost"}})
+    if status.Code(err) != codes.FailedPrecondition {
+      t.Errorf("CreateBlog on follower %v: got %v, want FailedPrecondition", n.id, err)
+    }
+  }
+}
+
+func TestClusterLeaderFailover(t *testing.T) {
+  nodes := newTestCluster(t, 3)
+  leader := waitLeader(t, nodes)
+  ctx := context.Background()
+  before := createTestBlog(t, ctx, leader.s, "Before")
+  waitBlog(t, nodes, before)
+
+  leader.stop()
+  next := waitLeader(t, nodes)
+  if next == leader {
+    t.Fatal("the stopped leader is still the leader")
+  }
+  after := createTestBlog(t, ctx, next.s, "After")
+  waitBlog(t, nodes, before)
+  waitBlog(t, nodes, after)
+}
+
+func TestClusterSnapshotRestore(t *testing.T) {
+  nodes := newTestCluster(t, 3)
+  leader := waitLeader(t, nodes)
+  ctx := context.Background()
+  var follower *testNode
+  for _, n := range nodes {
+    if n != leader {
+      follower = n
+      break
+    }
+  }
+
+  snapshotted := createTestBlog(t, ctx, leader.s, "In the snapshot")
+  waitBlog(t, nodes, snapshotted)
+  if err := follower.s.cluster.raft.Snapshot().Error(); err != nil {
+    t.Fatalf("Snapshot: %v", err)
+  }
+  replayed := createTestBlog(t, ctx, leader.s, "In the log")
+  waitBlog(t, nodes, replayed)
+
+  // start the follower again on an empty database: the first blog can only
+  // come back from the snapshot, Raft replaying the log after it only
+  follower.stop()
+  if err := os.Remove(filepath.Join(follower.dir, "blog.db")); err != nil {
+    t.Fatal(err)
+  }
+  restarted := startTestNode(t, follower.id, follower.dir, false)
+  for i, n := range nodes {
+    if n == follower {
+      nodes[i] = restarted
+    }
+  }
+  connect(nodes)
+  waitBlog(t, []*testNode{restarted}, snapshotted)
+  waitBlog(t, []*testNode{restarted}, replayed)
+}
+
+func TestClusterMembership(t *testing.T) {
+  var nodes []*testNode
+  for i := 0; i < 3; i++ {
+    nodes = append(nodes, startTestNode(t, fmt.Sprintf("node%v", i+1), t.TempDir(), i == 0))
+  }
+  connect(nodes)
+  leader := waitLeader(t, nodes[:1])
+  leader.s.tokens = map[string]string{"user-token": "luis"}
+  leader.s.addAdmins(map[string]string{"admin-token": "root"})
+  ctx := context.Background()
+  admin := withToken(ctx, "admin-token")
+
+  add := &blogpb.AddVoterRequest{Id: "node2", Address: "node2"}
+  if _, err := leader.s.AddVoter(ctx, add); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("AddVoter without a token: got %v, want Unauthenticated", err)
+  }
+  if _, err := leader.s.AddVoter(withToken(ctx, "user-token"), add); status.Code(err) != codes.PermissionDenied {
+    t.Errorf("AddVoter by a user: got %v, want PermissionDenied", err)
+  }
+  for _, n := range nodes[1:] {
+    if _, err := leader.s.AddVoter(admin, &blogpb.AddVoterRequest{Id: n.id, Address: n.id}); err != nil {
+      t.Fatalf("AddVoter(%v): %v", n.id, err)
+    }
+  }
+  // the leader alone checks tokens, the blog isn't public
+  blog := createTestBlog(t, withToken(ctx, "user-token"), leader.s, "Members")
+  waitBlog(t, nodes[1:], blog)
+
+  remove := &blogpb.RemoveServerRequest{Id: "node3"}
+  if _, err := leader.s.RemoveServer(withToken(ctx, "user-token"), remove); status.Code(err) != codes.PermissionDenied {
+    t.Errorf("RemoveServer by a user: got %v, want PermissionDenied", err)
+  }
+  if _, err := leader.s.RemoveServer(admin, remove); err != nil {
+    t.Fatalf("RemoveServer: %v", err)
+  }
+  if _, err := leader.s.ClusterStatus(withToken(ctx, "user-token"), &blogpb.ClusterStatusRequest{}); status.Code(err) != codes.PermissionDenied {
+    t.Errorf("ClusterStatus by a user: got %v, want PermissionDenied", err)
+  }
+  res, err := leader.s.ClusterStatus(admin, &blogpb.ClusterStatusRequest{})
+  if err != nil {
+    t.Fatalf("ClusterStatus: %v", err)
+  }
+  var ids []string
+  for _, srv := range res.GetServers() {
+    ids = append(ids, srv.GetId())
+  }
+  if fmt.Sprint(ids) != "[node1 node2]" {
+    t.Errorf("got servers %v after removing node3, want [node1 node2]", ids)
+  }
+}
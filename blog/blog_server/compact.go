@@ -0,0 +1,300 @@
+package main
+
+import(
+  "context"
+  "flag"
+  "fmt"
+  "log"
+  "os"
+  "path/filepath"
+  "sort"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// compactTxMaxSize is the amount of data copied by a single transaction of
+// the destination database before it is committed.
+const compactTxMaxSize = 64 << 20
+
+func (s *server) CompactDatabase(ctx context.Context, req *blogpb.CompactDatabaseRequest) (*blogpb.CompactDatabaseResponse, error) {
+  fmt.Printf("CompactDatabase was invoked\n\n")
+  if _, err := s.admin(ctx); err != nil {
+    return nil, err
+  }
+
+  // no request can use the database while it is being replaced
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  // the shards are compacted one after the other, after the main file
+  dbs := []**bolt.DB{&s.db}
+  paths := []string{s.path}
+  if s.shards != nil {
+    for i := range s.shards.dbs {
+      dbs = append(dbs, &s.shards.dbs[i])
+      paths = append(paths, s.shards.paths[i])
+    }
+  }
+
+  var sizeBefore, sizeAfter int64
+  for i, path := range paths {
+    before, err := fileSize(path)
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not stat database: %v", err))
+    }
+
+    tmpPath := path + ".compact"
+    if err := compactDB(*dbs[i], tmpPath); err != nil {
+      os.Remove(tmpPath)
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not compact database: %v", err))
+    }
+
+    db, err := swapDB(*dbs[i], tmpPath, path)
+    if db != nil {
+      s.applyBatching(db)
+      *dbs[i] = db
+    }
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not swap database: %v", err))
+    }
+
+    after, err := fileSize(path)
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not stat database: %v", err))
+    }
+    sizeBefore += before
+    sizeAfter += after
+  }
+  fmt.Printf("Database compacted from %v to %v bytes\n\n", sizeBefore, sizeAfter)
+  return &blogpb.CompactDatabaseResponse{
+    SizeBefore: sizeBefore,
+    SizeAfter:  sizeAfter,
+  }, nil
+}
+
+func (s *server) DatabaseStats(ctx context.Context, req *blogpb.DatabaseStatsRequest) (*blogpb.DatabaseStatsResponse, error) {
+  fmt.Printf("DatabaseStats was invoked\n\n")
+  if _, err := s.admin(ctx); err != nil {
+    return nil, err
+  }
+
+  // read first since it takes the lock itself, for every file in turn
+  compression, err := s.compressionStats()
+  if err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read stats: %v", err))
+  }
+
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+
+  size, err := fileSize(s.path)
+  if err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not stat database: %v", err))
+  }
+  dbStats := s.db.Stats()
+  res := &blogpb.DatabaseStatsResponse{
+    FileSize:      size,
+    PageSize:      int64(s.db.Info().PageSize),
+    FreePageN:     int64(dbStats.FreePageN),
+    PendingPageN:  int64(dbStats.PendingPageN),
+    FreeAlloc:     int64(dbStats.FreeAlloc),
+    FreelistInuse: int64(dbStats.FreelistInuse),
+    TxN:           int64(dbStats.TxN),
+    OpenTxN:       int64(dbStats.OpenTxN),
+    Compression:   compression,
+  }
+  err = s.db.View(func(tx *bolt.Tx) error {
+    res.DataSize = tx.Size()
+    return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+      res.Buckets = append(res.Buckets, bucketStats(string(name), b.Stats()))
+      return nil
+    })
+  })
+  if err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read stats: %v", err))
+  }
+  sort.Slice(res.Buckets, func(i, j int) bool {
+    return res.Buckets[i].GetName() < res.Buckets[j].GetName()
+  })
+  return res, nil
+}
+
+func bucketStats(name string, st bolt.BucketStats) *blogpb.BucketStats {
+  return &blogpb.BucketStats{
+    Name:            name,
+    KeyN:            int64(st.KeyN),
+    Depth:           int64(st.Depth),
+    BranchPageN:     int64(st.BranchPageN),
+    BranchOverflowN: int64(st.BranchOverflowN),
+    LeafPageN:       int64(st.LeafPageN),
+    LeafOverflowN:   int64(st.LeafOverflowN),
+    BranchAlloc:     int64(st.BranchAlloc),
+    BranchInuse:     int64(st.BranchInuse),
+    LeafAlloc:       int64(st.LeafAlloc),
+    LeafInuse:       int64(st.LeafInuse),
+    BucketN:         int64(st.BucketN),
+    InlineBucketN:   int64(st.InlineBucketN),
+  }
+}
+
+// compactDB copies every bucket of src, including nested buckets and their
+// sequences, into a new Bolt file at dstPath. Only live data is copied so
+// the new file doesn't carry the pages freed by deletes.
+func compactDB(src *bolt.DB, dstPath string) error {
+  os.Remove(dstPath)
+  dst, err := bolt.Open(dstPath, 0600, nil)
+  if err != nil {
+    return err
+  }
+
+  err = src.View(func(srcTx *bolt.Tx) error {
+    dstTx, err := dst.Begin(true)
+    if err != nil {
+      return err
+    }
+    defer func() {
+      dstTx.Rollback()
+    }()
+    size := 0
+
+    err = srcTx.ForEach(func(name []byte, b *bolt.Bucket) error {
+      return walkBucket(b, [][]byte{name}, func(path [][]byte, k, v []byte, seq uint64) error {
+        // commit regularly so a big database doesn't sit in memory
+        if size += len(k) + len(v); size > compactTxMaxSize {
+          if err := dstTx.Commit(); err != nil {
+            return err
+          }
+          if dstTx, err = dst.Begin(true); err != nil {
+            return err
+          }
+          size = 0
+        }
+        return copyEntry(dstTx, path, k, v, seq)
+      })
+    })
+    if err != nil {
+      return err
+    }
+    return dstTx.Commit()
+  })
+  if err != nil {
+    dst.Close()
+    return err
+  }
+  return dst.Close()
+}
+
+// walkBucket calls fn for the bucket at path itself (k == nil) and then for
+// every key of it, descending into nested buckets (v == nil).
+func walkBucket(b *bolt.Bucket, path [][]byte, fn func(path [][]byte, k, v []byte, seq uint64) error) error {
+  if err := fn(path, nil, nil, b.Sequence()); err != nil {
+    return err
+  }
+  return b.ForEach(func(k, v []byte) error {
+    if v != nil {
+      return fn(path, k, v, 0)
+    }
+    return walkBucket(b.Bucket(k), append(path[:len(path):len(path)], k), fn)
+  })
+}
+
+// copyEntry writes an entry produced by walkBucket into tx, creating the
+// bucket at path on its first visit.
+func copyEntry(tx *bolt.Tx, path [][]byte, k, v []byte, seq uint64) error {
+  b, err := tx.CreateBucketIfNotExists(path[0])
+  if err != nil {
+    return err
+  }
+  for _, name := range path[1:] {
+    if b, err = b.CreateBucketIfNotExists(name); err != nil {
+      return err
+    }
+  }
+  if k == nil {
+    return b.SetSequence(seq)
+  }
+  // keys come in order, so pages can be filled completely
+  b.FillPercent = 1.0
+  return b.Put(k, v)
+}
+
+// swapDB closes db, atomically replaces the file at path by the one at
+// newPath and opens it. If anything goes wrong after db was closed, the
+// original file is opened again so the server can keep going.
+func swapDB(db *bolt.DB, newPath, path string) (*bolt.DB, error) {
+  if err := db.Close(); err != nil {
+    return nil, err
+  }
+  if err := os.Rename(newPath, path); err != nil {
+    os.Remove(newPath)
+    reopened, openErr := bolt.Open(path, 0600, nil)
+    if openErr != nil {
+      log.Fatalf("Could not reopen database: %v", openErr)
+    }
+    return reopened, err
+  }
+  syncDir(filepath.Dir(path))
+  reopened, err := bolt.Open(path, 0600, nil)
+  if err != nil {
+    log.Fatalf("Could not open compacted database: %v", err)
+  }
+  return reopened, nil
+}
+
+// syncDir flushes a directory so that a rename inside it is durable.
+func syncDir(dir string) {
+  d, err := os.Open(dir)
+  if err != nil {
+    return
+  }
+  d.Sync()
+  d.Close()
+}
+
+func fileSize(path string) (int64, error) {
+  info, err := os.Stat(path)
+  if err != nil {
+    return 0, err
+  }
+  return info.Size(), nil
+}
+
+// runCompact implements the "compact" maintenance subcommand, which compacts
+// the database while the server is stopped:
+//
+//   blog_server compact -db database/blog.db
+func runCompact(args []string) {
+  fs := flag.NewFlagSet("compact", flag.ExitOnError)
+  path := fs.String("db", "database/blog.db", "path of the Bolt database")
+  fs.Parse(args)
+
+  sizeBefore, err := fileSize(*path)
+  if err != nil {
+    log.Fatalf("Could not stat database: %v", err)
+  }
+  // fail right away if a server is using the database
+  db, err := bolt.Open(*path, 0600, &bolt.Options{Timeout: time.Second})
+  if err != nil {
+    log.Fatalf("Could not open database: %v", err)
+  }
+  tmpPath := *path + ".compact"
+  if err := compactDB(db, tmpPath); err != nil {
+    os.Remove(tmpPath)
+    log.Fatalf("Could not compact database: %v", err)
+  }
+  db, err = swapDB(db, tmpPath, *path)
+  if err != nil {
+    log.Fatalf("Could not swap database: %v", err)
+  }
+  db.Close()
+
+  sizeAfter, err := fileSize(*path)
+  if err != nil {
+    log.Fatalf("Could not stat database: %v", err)
+  }
+  fmt.Printf("Database compacted from %v to %v bytes\n", sizeBefore, sizeAfter)
+}
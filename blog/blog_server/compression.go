@@ -0,0 +1,175 @@
+package main
+
+import(
+  "bytes"
+  "context"
+  "fmt"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "github.com/golang/snappy"
+)
+
+var (
+  // compressedBuckets hold the serialized blogs and content chunks, and the
+  // changelog entries carrying copies of them, which are compressed from a
+  // size threshold.
+  compressedBuckets = [][]byte{blogBucket, changelogBucket, chunksBucket}
+  // compressionThresholdKey, in the metadata bucket, is the threshold the
+  // values of the file were last re-encoded with.
+  compressionThresholdKey = []byte("compression_threshold")
+)
+
+const (
+  // codecSnappy starts the values compressed with snappy. Like the header
+  // of the encrypted values it can't start a protocol buffer, so the values
+  // stored uncompressed are read as they are.
+  codecSnappy                 = 1
+  defaultCompressionThreshold = 1024
+)
+
+// compressionThreshold is the size in bytes from which the values of
+// compressedBuckets are compressed, 0 to store them uncompressed. It is set
+// once at startup.
+var compressionThreshold = defaultCompressionThreshold
+
+func isCompressed(v []byte) bool {
+  return len(v) > 0 && v[0] == codecSnappy
+}
+
+// compressValue returns v, to be stored in bucket name, compressed with a
+// codec header when it is large enough and compression makes it smaller.
+func compressValue(name, v []byte) []byte {
+  if compressionThreshold <= 0 || len(v) < compressionThreshold {
+    return v
+  }
+  compressible := false
+  for _, b := range compressedBuckets {
+    compressible = compressible || bytes.Equal(b, name)
+  }
+  if !compressible {
+    return v
+  }
+  buf := make([]byte, 1+snappy.MaxEncodedLen(len(v)))
+  buf[0] = codecSnappy
+  n := len(snappy.Encode(buf[1:], v))
+  if 1+n >= len(v) {
+    return v
+  }
+  return buf[:1+n]
+}
+
+// decompressValue returns the serialized value of v, which may have been
+// compressed by compressValue.
+func decompressValue(v []byte) ([]byte, error) {
+  if !isCompressed(v) {
+    return v, nil
+  }
+  raw, err := snappy.Decode(nil, v[1:])
+  if err != nil {
+    return nil, fmt.Errorf("could not decompress the value: %v", err)
+  }
+  return raw, nil
+}
+
+// recompress re-encodes the values of compressedBuckets in every database
+// file last re-encoded with another threshold, so that a new threshold also
+// applies to the blogs already stored. It runs in the background after
+// startup, while the server takes requests.
+func (s *server) recompress(ctx context.Context) {
+  threshold := uint64(compressionThreshold)
+  for i := 0; i < s.fileCount(); i++ {
+    done := false
+    err := s.updateFile(i, func(tx *bolt.Tx) error {
+      v := tx.Bucket(metaBucket).Get(compressionThresholdKey)
+      done = v != nil && btoui(v) == threshold
+      return nil
+    })
+    if err != nil || done {
+      continue
+    }
+
+    var count uint64
+    for _, name := range compressedBuckets {
+      name := name
+      n, err := s.rewriteValues(ctx, i, name, func(tx *bolt.Tx, k, v []byte) ([]byte, error) {
+        plain, err := openValue(tx, name, k, v)
+        if err != nil {
+          return nil, err
+        }
+        raw, err := decompressValue(plain)
+        if err != nil {
+          return nil, err
+        }
+        encoded := compressValue(name, raw)
+        if isCompressed(encoded) == isCompressed(plain) {
+          return nil, nil
+        }
+        return sealValue(tx, name, k, encoded)
+      })
+      count += n
+      if err != nil {
+        if ctx.Err() == nil {
+          fmt.Printf("Could not re-encode the values of %s in database file %v: %v\n\n", name, i, err)
+        }
+        return
+      }
+    }
+    err = s.updateFile(i, func(tx *bolt.Tx) error {
+      return tx.Bucket(metaBucket).Put(compressionThresholdKey, uitob(threshold))
+    })
+    if err != nil {
+      fmt.Printf("Could not record the compression threshold of database file %v: %v\n\n", i, err)
+      return
+    }
+    if count > 0 {
+      fmt.Printf("Re-encoded %v values of database file %v for a compression threshold of %v bytes\n\n", count, i, threshold)
+    }
+  }
+}
+
+// compressionStats reports how much the values of compressedBuckets are
+// compressed, across every database file.
+func (s *server) compressionStats() ([]*blogpb.CompressionStats, error) {
+  stats := make([]*blogpb.CompressionStats, len(compressedBuckets))
+  for i, name := range compressedBuckets {
+    stats[i] = &blogpb.CompressionStats{Bucket: string(name)}
+  }
+  err := s.viewFiles(func(tx *bolt.Tx) error {
+    for i, name := range compressedBuckets {
+      st := stats[i]
+      err := tx.Bucket(name).ForEach(func(k, v []byte) error {
+        plain, err := openValue(tx, name, k, v)
+        if err != nil {
+          return fmt.Errorf("%s %v: %v", name, btoui(k), err)
+        }
+        st.Values++
+        st.StoredBytes += int64(len(v))
+        if !isCompressed(plain) {
+          st.RawBytes += int64(len(plain))
+          return nil
+        }
+        n, err := snappy.DecodedLen(plain[1:])
+        if err != nil {
+          return fmt.Errorf("%s %v: %v", name, btoui(k), err)
+        }
+        st.CompressedValues++
+        st.RawBytes += int64(n)
+        return nil
+      })
+      if err != nil {
+        return err
+      }
+    }
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+  for _, st := range stats {
+    if st.StoredBytes > 0 {
+      st.Ratio = float64(st.RawBytes) / float64(st.StoredBytes)
+    }
+  }
+  return stats, nil
+}
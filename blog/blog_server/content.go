@@ -0,0 +1,515 @@
+package main
+
+import(
+  "bytes"
+  "context"
+  "fmt"
+  "io"
+  "strconv"
+  "unicode/utf8"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "github.com/golang/protobuf/proto"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+var (
+  // chunksBucket holds the content uploaded with WriteBlogContent in
+  // pieces, keyed by blog id, content version and chunk index.
+  chunksBucket = []byte("Chunks")
+  // uploadsBucket holds the uploads in progress by blog id.
+  uploadsBucket = []byte("Uploads")
+
+  // contentBuckets are keyed by blog id first and live next to their
+  // blogs, in the same shard.
+  contentBuckets = [][]byte{chunksBucket, uploadsBucket}
+)
+
+const (
+  // contentChunkSize is the size of the stored chunks, and of the pieces
+  // StreamBlogContent sends.
+  contentChunkSize = 64 << 10
+  // contentAppendSize is the most content a single APPEND_CONTENT command
+  // carries, which keeps the entries of the Raft log small.
+  contentAppendSize = 4 * contentChunkSize
+  // contentReadBatch is the number of chunks StreamBlogContent reads per
+  // transaction.
+  contentReadBatch = 16
+  // maxInlineContent is the largest uploaded content stored in the blog
+  // itself, larger content stays in chunks and the blog only keeps its
+  // first contentPreviewSize bytes.
+  maxInlineContent   = 1 << 20
+  contentPreviewSize = contentChunkSize
+  maxContentSize     = 1 << 30
+)
+
+func chunkKey(id, version, index uint64) []byte {
+  key := append(uitob(id), uitob(version)...)
+  return append(key, uitob(index)...)
+}
+
+// getChunk returns chunk index of content version of blog id, or nil if
+// there is none.
+func getChunk(tx *bolt.Tx, id, version, index uint64) ([]byte, error) {
+  key := chunkKey(id, version, index)
+  v := tx.Bucket(chunksBucket).Get(key)
+  if v == nil {
+    return nil, nil
+  }
+  chunk := &blogpb.BlogChunk{}
+  if err := unmarshalValue(tx, chunksBucket, key, v, chunk); err != nil {
+    return nil, err
+  }
+  return chunk.GetData(), nil
+}
+
+// putChunk stores a chunk and records the write, made at at, in the
+// changelog.
+func putChunk(tx *bolt.Tx, at int64, id, version, index uint64, data []byte) error {
+  if err := storeChunk(tx, id, version, index, data); err != nil {
+    return err
+  }
+  return appendChange(tx, &blogpb.ChangeEntry{
+    Op:             blogpb.ChangeEntry_PUT_CHUNK,
+    BlogId:         id,
+    ContentVersion: version,
+    ChunkIndex:     index,
+    Chunk:          data,
+    Timestamp:      at,
+  })
+}
+
+func storeChunk(tx *bolt.Tx, id, version, index uint64, data []byte) error {
+  return putMessage(tx, chunksBucket, chunkKey(id, version, index), &blogpb.BlogChunk{
+    Data: data,
+  })
+}
+
+// removeChunks deletes content version of blog id and records the delete,
+// made at at, in the changelog.
+func removeChunks(tx *bolt.Tx, at int64, id, version uint64) error {
+  if err := dropChunks(tx, id, version); err != nil {
+    return err
+  }
+  return appendChange(tx, &blogpb.ChangeEntry{
+    Op:             blogpb.ChangeEntry_DELETE_CHUNKS,
+    BlogId:         id,
+    ContentVersion: version,
+    Timestamp:      at,
+  })
+}
+
+func dropChunks(tx *bolt.Tx, id, version uint64) error {
+  b := tx.Bucket(chunksBucket)
+  prefix := append(uitob(id), uitob(version)...)
+  // deleting while iterating makes the cursor skip keys
+  var keys [][]byte
+  c := b.Cursor()
+  for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+    keys = append(keys, k)
+  }
+  for _, k := range keys {
+    if err := b.Delete(k); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// getUpload returns the upload of content of blog id in progress, or nil if
+// there is none.
+func getUpload(tx *bolt.Tx, id uint64) (*blogpb.ContentUpload, error) {
+  v := tx.Bucket(uploadsBucket).Get(uitob(id))
+  if v == nil {
+    return nil, nil
+  }
+  up := &blogpb.ContentUpload{}
+  if err := proto.Unmarshal(v, up); err != nil {
+    return nil, err
+  }
+  return up, nil
+}
+
+func putUpload(tx *bolt.Tx, id uint64, up *blogpb.ContentUpload) error {
+  upBytes, err := proto.Marshal(up)
+  if err != nil {
+    return err
+  }
+  return tx.Bucket(uploadsBucket).Put(uitob(id), upBytes)
+}
+
+// writeContent writes data at offset of content version of blog id,
+// completing the last chunk first if it is partial.
+func writeContent(tx *bolt.Tx, at int64, id, version, offset uint64, data []byte) error {
+  for len(data) > 0 {
+    index := offset / contentChunkSize
+    chunk, err := getChunk(tx, id, version, index)
+    if err != nil {
+      return err
+    }
+    n := contentChunkSize - len(chunk)
+    if n > len(data) {
+      n = len(data)
+    }
+    if err := putChunk(tx, at, id, version, index, append(chunk, data[:n]...)); err != nil {
+      return err
+    }
+    data = data[n:]
+    offset += uint64(n)
+  }
+  return nil
+}
+
+// readContent returns the first n bytes of content version of blog id.
+func readContent(tx *bolt.Tx, id, version, n uint64) ([]byte, error) {
+  var content []byte
+  for index := uint64(0); uint64(len(content)) < n; index++ {
+    chunk, err := getChunk(tx, id, version, index)
+    if err != nil {
+      return nil, err
+    }
+    if len(chunk) == 0 {
+      return nil, fmt.Errorf("chunk %v of blog %v is missing", index, id)
+    }
+    content = append(content, chunk...)
+  }
+  return content[:n], nil
+}
+
+// truncateContent returns at most the first n bytes of content, without
+// splitting a character.
+func truncateContent(content []byte, n int) string {
+  if len(content) <= n {
+    return string(content)
+  }
+  for n > 0 && !utf8.RuneStart(content[n]) {
+    n--
+  }
+  return string(content[:n])
+}
+
+// contentSize returns the size of the whole content of blog.
+func contentSize(blog *blogpb.Blog) uint64 {
+  if blog.GetContentTruncated() {
+    return blog.GetContentSize()
+  }
+  return uint64(len(blog.GetContent()))
+}
+
+// removeContent deletes the chunks of blog and its upload in progress.
+func removeContent(tx *bolt.Tx, at int64, blog *blogpb.Blog) error {
+  id := blog.GetId()
+  if version := blog.GetContentVersion(); version != 0 {
+    if err := removeChunks(tx, at, id, version); err != nil {
+      return err
+    }
+  }
+  up, err := getUpload(tx, id)
+  if err != nil || up == nil {
+    return err
+  }
+  if err := removeChunks(tx, at, id, up.GetVersion()); err != nil {
+    return err
+  }
+  return tx.Bucket(uploadsBucket).Delete(uitob(id))
+}
+
+// applyContent runs an APPEND_CONTENT or COMMIT_CONTENT command on blog.
+// Appending at offset 0 starts a new upload, which only replaces the content
+// of the blog once it is committed.
+func applyContent(tx *bolt.Tx, blog *blogpb.Blog, cmd *blogpb.BlogCommand) (*blogpb.Blog, error) {
+  id := blog.GetId()
+  up, err := getUpload(tx, id)
+  if err != nil {
+    return nil, err
+  }
+
+  if cmd.GetOp() == blogpb.BlogCommand_APPEND_CONTENT {
+    // the upload counts for its whole size already, so it stops as soon as
+    // it gets past the quota
+    if err := checkUploadQuota(tx, cmd, blog, cmd.GetOffset()+uint64(len(cmd.GetData()))); err != nil {
+      return nil, err
+    }
+    if cmd.GetOffset() == 0 {
+      // starting over drops what an earlier upload left
+      if up != nil {
+        if err := removeChunks(tx, cmd.GetTimestamp(), id, up.GetVersion()); err != nil {
+          return nil, err
+        }
+      }
+      // the sequence is per database file, so content moved in by a
+      // reshard may already use the next version
+      var version uint64
+      for version == 0 || version == blog.GetContentVersion() {
+        version, err = tx.Bucket(uploadsBucket).NextSequence()
+        if err != nil {
+          return nil, err
+        }
+      }
+      up = &blogpb.ContentUpload{Version: version}
+    } else if up.GetOffset() != cmd.GetOffset() {
+      return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("The upload of blog %v is at offset %v, not %v", id, up.GetOffset(), cmd.GetOffset()))
+    }
+    if up.GetOffset()+uint64(len(cmd.GetData())) > maxContentSize {
+      return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("The content of a blog can't be larger than %v bytes", maxContentSize))
+    }
+    if err := writeContent(tx, cmd.GetTimestamp(), id, up.GetVersion(), up.GetOffset(), cmd.GetData()); err != nil {
+      return nil, err
+    }
+    up.Offset += uint64(len(cmd.GetData()))
+    return blog, putUpload(tx, id, up)
+  }
+
+  if up == nil {
+    return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Blog %v has no upload in progress", id))
+  }
+  if up.GetOffset() != cmd.GetOffset() {
+    return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("The upload of blog %v is at offset %v, not %v", id, up.GetOffset(), cmd.GetOffset()))
+  }
+  if err := checkUploadQuota(tx, cmd, blog, up.GetOffset()); err != nil {
+    return nil, err
+  }
+  old := blog.GetContentVersion()
+  if up.GetOffset() <= maxInlineContent {
+    content, err := readContent(tx, id, up.GetVersion(), up.GetOffset())
+    if err != nil {
+      return nil, err
+    }
+    if !utf8.Valid(content) {
+      return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("The content uploaded for blog %v isn't valid UTF-8", id))
+    }
+    if err := removeChunks(tx, cmd.GetTimestamp(), id, up.GetVersion()); err != nil {
+      return nil, err
+    }
+    blog.Content = string(content)
+    blog.ContentTruncated = false
+    blog.ContentSize = 0
+    blog.ContentVersion = 0
+  } else {
+    preview, err := readContent(tx, id, up.GetVersion(), contentPreviewSize)
+    if err != nil {
+      return nil, err
+    }
+    blog.Content = truncateContent(preview, contentPreviewSize)
+    blog.ContentTruncated = true
+    blog.ContentSize = up.GetOffset()
+    blog.ContentVersion = up.GetVersion()
+  }
+  if old != 0 {
+    if err := removeChunks(tx, cmd.GetTimestamp(), id, old); err != nil {
+      return nil, err
+    }
+  }
+  if err := tx.Bucket(uploadsBucket).Delete(uitob(id)); err != nil {
+    return nil, err
+  }
+  return blog, putBlog(tx, cmd.GetTimestamp(), blog)
+}
+
+// StreamBlogContent sends the whole content of a blog, read a batch of
+// chunks at a time. A new upload completed meanwhile aborts the stream,
+// which can resume from the offset it reached to read the new content.
+func (s *server) StreamBlogContent(req *blogpb.StreamBlogContentRequest, stream blogpb.BlogService_StreamBlogContentServer) error {
+  fmt.Printf("StreamBlogContent was invoked with: %v\n\n", req)
+  caller, err := s.caller(stream.Context())
+  if err != nil {
+    return err
+  }
+
+  id := req.GetBlogId()
+  var blog *blogpb.Blog
+  err = s.viewBlog(id, func(tx *bolt.Tx) error {
+    var err error
+    blog, err = getBlog(tx, id)
+    if err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+    }
+    if blog == nil {
+      return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", id))
+    }
+    return s.checkRead(blog, caller)
+  })
+  if err != nil {
+    return err
+  }
+  size := contentSize(blog)
+  offset := req.GetOffset()
+  if offset > size {
+    return status.Error(codes.OutOfRange, fmt.Sprintf("Offset %v is past the end of the content of blog %v, %v bytes long", offset, id, size))
+  }
+
+  send := func(data []byte) error {
+    err := stream.Send(&blogpb.StreamBlogContentResponse{
+      Offset:      offset,
+      Data:        data,
+      ContentSize: size,
+    })
+    offset += uint64(len(data))
+    return err
+  }
+  if !blog.GetContentTruncated() {
+    content := []byte(blog.GetContent())
+    for offset < size {
+      end := offset + contentChunkSize
+      if end > size {
+        end = size
+      }
+      if err := send(content[offset:end]); err != nil {
+        return err
+      }
+    }
+    return nil
+  }
+
+  version := blog.GetContentVersion()
+  for offset < size {
+    var pieces [][]byte
+    err := s.viewBlog(id, func(tx *bolt.Tx) error {
+      next := offset
+      for len(pieces) < contentReadBatch && next < size {
+        index := next / contentChunkSize
+        chunk, err := getChunk(tx, id, version, index)
+        if err != nil {
+          return status.Error(codes.Internal, fmt.Sprintf("Could not read the content: %v", err))
+        }
+        start := next - index*contentChunkSize
+        if uint64(len(chunk)) <= start {
+          return status.Error(codes.Aborted, fmt.Sprintf("The content of blog %v changed while it was read", id))
+        }
+        pieces = append(pieces, chunk[start:])
+        next += uint64(len(chunk)) - start
+      }
+      return nil
+    })
+    if err != nil {
+      return err
+    }
+    for _, data := range pieces {
+      if err := send(data); err != nil {
+        return err
+      }
+    }
+  }
+  return nil
+}
+
+// WriteBlogContent stores the content streamed by the client in pieces of
+// at most contentAppendSize, so what was received before the stream broke
+// is kept and the upload can resume from there. The content replaces the
+// one of the blog once a message marks it complete.
+func (s *server) WriteBlogContent(stream blogpb.BlogService_WriteBlogContentServer) error {
+  ctx := stream.Context()
+  if err := s.checkWritable(ctx); err != nil {
+    return err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return err
+  }
+  req, err := stream.Recv()
+  if err == io.EOF {
+    return status.Error(codes.InvalidArgument, "The upload is empty")
+  }
+  if err != nil {
+    return err
+  }
+  id := req.GetBlogId()
+  offset := req.GetOffset()
+  fmt.Printf("WriteBlogContent was invoked with: blog_id:%v offset:%v\n\n", id, offset)
+
+  // uploadError tells the client where to resume when it got the offset
+  // wrong, 0 when there is no upload to resume
+  uploadError := func(err error) error {
+    if status.Code(err) == codes.FailedPrecondition {
+      s.viewBlog(id, func(tx *bolt.Tx) error {
+        up, err := getUpload(tx, id)
+        if err == nil {
+          stream.SetTrailer(metadata.Pairs("x-upload-offset", strconv.FormatUint(up.GetOffset(), 10)))
+        }
+        return nil
+      })
+    }
+    return err
+  }
+
+  var pending []byte
+  started := false
+  // the appends outlive a stream cancelled by the client
+  appendCtx := ctx
+  // flush appends the pending data by pieces of contentAppendSize, and
+  // the last smaller piece as well when all is set
+  flush := func(all bool) error {
+    for len(pending) >= contentAppendSize || (all && (len(pending) > 0 || !started)) {
+      n := len(pending)
+      if n > contentAppendSize {
+        n = contentAppendSize
+      }
+      // pieces are screened like translations, as they can't be held
+      err := s.moderateOrRefuse(appendCtx, &blogpb.Blog{
+        Id:      id,
+        Content: string(pending[:n]),
+      }, "content")
+      if err != nil {
+        return err
+      }
+      _, err = s.execute(appendCtx, &blogpb.BlogCommand{
+        Op:     blogpb.BlogCommand_APPEND_CONTENT,
+        BlogId: id,
+        Offset: offset,
+        Data:   pending[:n],
+        Caller: caller,
+      })
+      if err != nil {
+        return uploadError(err)
+      }
+      started = true
+      offset += uint64(n)
+      pending = pending[n:]
+    }
+    return nil
+  }
+
+  complete := false
+  for {
+    pending = append(pending, req.GetData()...)
+    complete = complete || req.GetComplete()
+    if err := flush(false); err != nil {
+      return err
+    }
+    req, err = stream.Recv()
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      // keep what was received, so the upload can resume from there
+      if len(pending) > 0 {
+        appendCtx = context.Background()
+        flush(true)
+      }
+      return err
+    }
+  }
+  if err := flush(true); err != nil {
+    return err
+  }
+
+  res := &blogpb.WriteBlogContentResponse{
+    Offset: offset,
+  }
+  if complete {
+    blog, err := s.execute(ctx, &blogpb.BlogCommand{
+      Op:     blogpb.BlogCommand_COMMIT_CONTENT,
+      BlogId: id,
+      Offset: offset,
+      Caller: caller,
+    })
+    if err != nil {
+      return uploadError(err)
+    }
+    res.Blog = s.visibleBlog(localize(blog, nil), caller)
+  }
+  return stream.SendAndClose(res)
+}
@@ -0,0 +1,453 @@
+package main
+
+import(
+  "bytes"
+  "context"
+  "crypto/aes"
+  "crypto/cipher"
+  "crypto/hmac"
+  "crypto/rand"
+  "crypto/sha256"
+  "encoding/base64"
+  "errors"
+  "fmt"
+  "io"
+  "io/ioutil"
+  "os"
+  "strings"
+  "sync"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+var (
+  // keysBucket holds the data keys of a database file, keyed by key id and
+  // wrapped by the master key. It is created with the first data key.
+  keysBucket = []byte("Keys")
+  // activeKeyKey, in the metadata bucket, is the id of the data key new
+  // values are encrypted with.
+  activeKeyKey = []byte("active_key")
+
+  // sealedBuckets hold the values carrying the contents of the blogs, which
+  // are encrypted when the server has a master key.
+  sealedBuckets = [][]byte{blogBucket, changelogBucket, quarantineBucket, seriesBucket, chunksBucket}
+)
+
+const (
+  // masterKeyEnv holds the master key when no key file is given.
+  masterKeyEnv = "BLOG_MASTER_KEY"
+  // sealedMagic starts every encrypted value. A protocol buffer can't start
+  // with a zero byte, so the values written in the clear before encryption
+  // was turned on are still told apart.
+  sealedMagic   = 0
+  sealedVersion = 1
+  // sealedHeaderSize is the size of the magic, the version and the data key
+  // id in front of the nonce.
+  sealedHeaderSize = 10
+)
+
+// keyring implements envelope encryption of the stored values: every
+// database file has its own AES-GCM data keys, stored in it wrapped by the
+// master key, which is only ever kept in memory.
+type keyring struct {
+  master cipher.AEAD
+  // commands seals the commands of the Raft log, with a key derived from
+  // the master key since the log is shared by the nodes of a cluster
+  commands cipher.AEAD
+
+  mu sync.Mutex
+  // unwrapped caches the data keys by wrapped key, which is unique even
+  // across files since it carries a random nonce
+  unwrapped map[string]cipher.AEAD
+}
+
+// encryption is set at startup when a master key is given, values are
+// stored in the clear otherwise.
+var encryption *keyring
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+  block, err := aes.NewCipher(key)
+  if err != nil {
+    return nil, err
+  }
+  return cipher.NewGCM(block)
+}
+
+// loadMasterKey reads the base64 encoded 256 bit master key from path, or
+// from the BLOG_MASTER_KEY environment variable when path is empty. It
+// returns nil when there is neither.
+func loadMasterKey(path string) ([]byte, error) {
+  encoded := os.Getenv(masterKeyEnv)
+  if path != "" {
+    data, err := ioutil.ReadFile(path)
+    if err != nil {
+      return nil, err
+    }
+    encoded = string(data)
+  }
+  encoded = strings.TrimSpace(encoded)
+  if encoded == "" {
+    return nil, nil
+  }
+  key, err := base64.StdEncoding.DecodeString(encoded)
+  if err != nil {
+    return nil, fmt.Errorf("the master key must be base64 encoded: %v", err)
+  }
+  if len(key) != 32 {
+    return nil, fmt.Errorf("the master key must be 32 bytes long, not %v", len(key))
+  }
+  return key, nil
+}
+
+// useMasterKey turns encryption on with the master key of path or of the
+// environment, if any. It must be called before the databases are opened.
+func useMasterKey(path string) error {
+  key, err := loadMasterKey(path)
+  if err != nil || key == nil {
+    return err
+  }
+  master, err := newGCM(key)
+  if err != nil {
+    return err
+  }
+  mac := hmac.New(sha256.New, key)
+  mac.Write([]byte("blog raft commands"))
+  commands, err := newGCM(mac.Sum(nil))
+  if err != nil {
+    return err
+  }
+  encryption = &keyring{
+    master:    master,
+    commands:  commands,
+    unwrapped: make(map[string]cipher.AEAD),
+  }
+  return nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+  b := make([]byte, n)
+  _, err := io.ReadFull(rand.Reader, b)
+  return b, err
+}
+
+// wrap encrypts data key id with the master key.
+func (k *keyring) wrap(id uint64, key []byte) ([]byte, error) {
+  nonce, err := randomBytes(k.master.NonceSize())
+  if err != nil {
+    return nil, err
+  }
+  return k.master.Seal(nonce, nonce, key, uitob(id)), nil
+}
+
+// unwrap returns the cipher of data key id from its wrapped form.
+func (k *keyring) unwrap(id uint64, wrapped []byte) (cipher.AEAD, error) {
+  k.mu.Lock()
+  defer k.mu.Unlock()
+  if aead, ok := k.unwrapped[string(wrapped)]; ok {
+    return aead, nil
+  }
+  n := k.master.NonceSize()
+  if len(wrapped) < n {
+    return nil, fmt.Errorf("data key %v is truncated", id)
+  }
+  key, err := k.master.Open(nil, wrapped[:n], wrapped[n:], uitob(id))
+  if err != nil {
+    return nil, fmt.Errorf("could not unwrap data key %v, is it the right master key?", id)
+  }
+  aead, err := newGCM(key)
+  if err != nil {
+    return nil, err
+  }
+  k.unwrapped[string(wrapped)] = aead
+  return aead, nil
+}
+
+// newDataKey creates a data key in the database of tx and makes it the
+// active one. The buckets are created if needed, since the migrations
+// writing blogs can run before the one creating the metadata bucket.
+func newDataKey(tx *bolt.Tx) (uint64, error) {
+  b, err := tx.CreateBucketIfNotExists(keysBucket)
+  if err != nil {
+    return 0, err
+  }
+  meta, err := tx.CreateBucketIfNotExists(metaBucket)
+  if err != nil {
+    return 0, err
+  }
+  id, err := b.NextSequence()
+  if err != nil {
+    return 0, err
+  }
+  key, err := randomBytes(32)
+  if err != nil {
+    return 0, err
+  }
+  wrapped, err := encryption.wrap(id, key)
+  if err != nil {
+    return 0, err
+  }
+  if err := b.Put(uitob(id), wrapped); err != nil {
+    return 0, err
+  }
+  return id, meta.Put(activeKeyKey, uitob(id))
+}
+
+// activeKeyID returns the id of the active data key of tx, 0 if there is
+// none yet.
+func activeKeyID(tx *bolt.Tx) uint64 {
+  b := tx.Bucket(metaBucket)
+  if b == nil {
+    return 0
+  }
+  v := b.Get(activeKeyKey)
+  if v == nil {
+    return 0
+  }
+  return btoui(v)
+}
+
+// dataKey returns the cipher of data key id of tx.
+func dataKey(tx *bolt.Tx, id uint64) (cipher.AEAD, error) {
+  var wrapped []byte
+  if b := tx.Bucket(keysBucket); b != nil {
+    wrapped = b.Get(uitob(id))
+  }
+  if wrapped == nil {
+    return nil, fmt.Errorf("unknown data key %v", id)
+  }
+  return encryption.unwrap(id, wrapped)
+}
+
+// isSealed tells if v was encrypted by sealValue.
+func isSealed(v []byte) bool {
+  return len(v) > 0 && v[0] == sealedMagic
+}
+
+// sealedKeyID returns the id of the data key sealed value v was encrypted
+// with.
+func sealedKeyID(v []byte) uint64 {
+  if len(v) < sealedHeaderSize {
+    return 0
+  }
+  return btoui(v[2:sealedHeaderSize])
+}
+
+// valueData is the additional data of the value stored under key k of
+// bucket name, so that a value copied to another key fails to decrypt.
+func valueData(name, k []byte) []byte {
+  return append(append([]byte(nil), name...), k...)
+}
+
+// isSealedBucket tells whether the values of bucket name are encrypted.
+func isSealedBucket(name []byte) bool {
+  for _, b := range sealedBuckets {
+    if bytes.Equal(b, name) {
+      return true
+    }
+  }
+  return false
+}
+
+// sealValue encrypts v, to be stored under key k of bucket name, with the
+// active data key of tx, created on first use. Without a master key v is
+// returned as is.
+func sealValue(tx *bolt.Tx, name, k, v []byte) ([]byte, error) {
+  if encryption == nil {
+    return v, nil
+  }
+  id := activeKeyID(tx)
+  if id == 0 {
+    var err error
+    if id, err = newDataKey(tx); err != nil {
+      return nil, err
+    }
+  }
+  aead, err := dataKey(tx, id)
+  if err != nil {
+    return nil, err
+  }
+  nonce, err := randomBytes(aead.NonceSize())
+  if err != nil {
+    return nil, err
+  }
+  sealed := append([]byte{sealedMagic, sealedVersion}, uitob(id)...)
+  sealed = append(sealed, nonce...)
+  return aead.Seal(sealed, nonce, v, valueData(name, k)), nil
+}
+
+// openValue returns the plain value of v, stored under key k of bucket name.
+// Values written in the clear are returned as is.
+func openValue(tx *bolt.Tx, name, k, v []byte) ([]byte, error) {
+  if !isSealed(v) {
+    return v, nil
+  }
+  if encryption == nil {
+    return nil, errors.New("the value is encrypted and the server has no master key")
+  }
+  if len(v) < sealedHeaderSize || v[1] != sealedVersion {
+    return nil, errors.New("unknown encrypted value format")
+  }
+  aead, err := dataKey(tx, sealedKeyID(v))
+  if err != nil {
+    return nil, err
+  }
+  if len(v) < sealedHeaderSize+aead.NonceSize() {
+    return nil, errors.New("the encrypted value is truncated")
+  }
+  nonce := v[sealedHeaderSize : sealedHeaderSize+aead.NonceSize()]
+  plain, err := aead.Open(nil, nonce, v[sealedHeaderSize+aead.NonceSize():], valueData(name, k))
+  if err != nil {
+    return nil, fmt.Errorf("could not decrypt the value: %v", err)
+  }
+  return plain, nil
+}
+
+// sealCommand encrypts a command before it goes to the Raft log.
+func sealCommand(data []byte) ([]byte, error) {
+  if encryption == nil {
+    return data, nil
+  }
+  nonce, err := randomBytes(encryption.commands.NonceSize())
+  if err != nil {
+    return nil, err
+  }
+  sealed := append([]byte{sealedMagic, sealedVersion}, nonce...)
+  return encryption.commands.Seal(sealed, nonce, data, nil), nil
+}
+
+// openCommand decrypts a command of the Raft log sealed by sealCommand.
+func openCommand(data []byte) ([]byte, error) {
+  if !isSealed(data) {
+    return data, nil
+  }
+  if encryption == nil {
+    return nil, errors.New("the command is encrypted and the server has no master key")
+  }
+  n := encryption.commands.NonceSize()
+  if len(data) < 2+n || data[1] != sealedVersion {
+    return nil, errors.New("unknown encrypted command format")
+  }
+  return encryption.commands.Open(nil, data[2:2+n], data[2+n:], nil)
+}
+
+// checkDataKeys fails when db holds data keys the server can't unwrap,
+// before any request finds out.
+func checkDataKeys(db *bolt.DB) error {
+  return db.View(func(tx *bolt.Tx) error {
+    b := tx.Bucket(keysBucket)
+    if b == nil {
+      return nil
+    }
+    return b.ForEach(func(k, v []byte) error {
+      if encryption == nil {
+        return fmt.Errorf("the database is encrypted, give the master key with -master-key-file or %v", masterKeyEnv)
+      }
+      _, err := encryption.unwrap(btoui(k), v)
+      return err
+    })
+  })
+}
+
+// reencrypt writes again with the active data key the values of bucket name
+// of database file i encrypted with another key or stored in the clear.
+func (s *server) reencrypt(ctx context.Context, i int, name []byte) (uint64, error) {
+  return s.rewriteValues(ctx, i, name, func(tx *bolt.Tx, k, v []byte) ([]byte, error) {
+    if isSealed(v) && sealedKeyID(v) == activeKeyID(tx) {
+      return nil, nil
+    }
+    plain, err := openValue(tx, name, k, v)
+    if err != nil {
+      return nil, err
+    }
+    return sealValue(tx, name, k, plain)
+  })
+}
+
+// retireDataKeys deletes the data keys of database file i no value is
+// encrypted with anymore, except the active one.
+func (s *server) retireDataKeys(i int) (uint64, error) {
+  var retired uint64
+  err := s.updateFile(i, func(tx *bolt.Tx) error {
+    keys := tx.Bucket(keysBucket)
+    if keys == nil {
+      return nil
+    }
+    used := map[uint64]bool{activeKeyID(tx): true}
+    for _, name := range sealedBuckets {
+      b := tx.Bucket(name)
+      if b == nil {
+        continue
+      }
+      err := b.ForEach(func(k, v []byte) error {
+        if isSealed(v) {
+          used[sealedKeyID(v)] = true
+        }
+        return nil
+      })
+      if err != nil {
+        return err
+      }
+    }
+    var unused [][]byte
+    err := keys.ForEach(func(k, v []byte) error {
+      if !used[btoui(k)] {
+        unused = append(unused, append([]byte(nil), k...))
+      }
+      return nil
+    })
+    if err != nil {
+      return err
+    }
+    for _, k := range unused {
+      if err := keys.Delete(k); err != nil {
+        return err
+      }
+    }
+    retired = uint64(len(unused))
+    return nil
+  })
+  return retired, err
+}
+
+// RotateEncryptionKey gives every database file of this node a new data key
+// and re-encrypts the stored values with it, along with those still stored
+// in the clear. The old keys are deleted once nothing uses them. Each node
+// of a cluster, and each follower, has its own keys and rotates them on its
+// own.
+func (s *server) RotateEncryptionKey(ctx context.Context, req *blogpb.RotateEncryptionKeyRequest) (*blogpb.RotateEncryptionKeyResponse, error) {
+  fmt.Printf("RotateEncryptionKey was invoked\n\n")
+  if _, err := s.admin(ctx); err != nil {
+    return nil, err
+  }
+  if encryption == nil {
+    return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("This server has no master key, start it with -master-key-file or %v", masterKeyEnv))
+  }
+
+  res := &blogpb.RotateEncryptionKeyResponse{}
+  for i := 0; i < s.fileCount(); i++ {
+    err := s.updateFile(i, func(tx *bolt.Tx) error {
+      _, err := newDataKey(tx)
+      return err
+    })
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not create a data key: %v", err))
+    }
+    for _, name := range sealedBuckets {
+      n, err := s.reencrypt(ctx, i, name)
+      res.Reencrypted += n
+      if err != nil {
+        return nil, status.Error(codes.Internal, fmt.Sprintf("Could not re-encrypt the values: %v", err))
+      }
+    }
+    n, err := s.retireDataKeys(i)
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not delete the old data keys: %v", err))
+    }
+    res.RetiredKeys += n
+  }
+  fmt.Printf("Re-encrypted %v values and retired %v data keys\n\n", res.GetReencrypted(), res.GetRetiredKeys())
+  return res, nil
+}
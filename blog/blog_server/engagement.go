@@ -0,0 +1,392 @@
+package main
+
+import(
+  "bytes"
+  "context"
+  "fmt"
+  "sort"
+  "sync"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+var (
+  // likesBucket records who liked each blog, keyed by blog id and user, with
+  // the time of the like as value.
+  likesBucket = []byte("Likes")
+  // viewsBucket counts the views of each blog by the hour, keyed by blog id
+  // and unix hour.
+  viewsBucket = []byte("Views")
+  // viewTotalsBucket holds the total views of each blog, keyed by blog id.
+  // They are kept out of the stored blogs so that recording views doesn't
+  // write the whole blog again.
+  viewTotalsBucket = []byte("ViewTotals")
+
+  // engagementBuckets are keyed by blog id first and live next to their
+  // blogs, in the same shard.
+  engagementBuckets = [][]byte{likesBucket, viewsBucket, viewTotalsBucket}
+)
+
+const (
+  defaultTopBlogs = 10
+  maxTopBlogs     = 100
+)
+
+func likeKey(id uint64, user string) []byte {
+  return append(uitob(id), user...)
+}
+
+func viewKey(id uint64, hour uint64) []byte {
+  return append(uitob(id), uitob(hour)...)
+}
+
+func unixHour(nanos int64) uint64 {
+  return uint64(nanos / int64(time.Hour))
+}
+
+// applyLike runs a LIKE or UNLIKE command on blog. Liking twice, or
+// unliking a blog that wasn't liked, changes nothing.
+func applyLike(tx *bolt.Tx, blog *blogpb.Blog, cmd *blogpb.BlogCommand) (*blogpb.Blog, error) {
+  b := tx.Bucket(likesBucket)
+  key := likeKey(blog.GetId(), cmd.GetCaller())
+  liked := b.Get(key) != nil
+  switch {
+  case cmd.GetOp() == blogpb.BlogCommand_LIKE && !liked:
+    if err := b.Put(key, uitob(uint64(cmd.GetTimestamp()))); err != nil {
+      return nil, err
+    }
+    blog.Likes++
+  case cmd.GetOp() == blogpb.BlogCommand_UNLIKE && liked:
+    if err := b.Delete(key); err != nil {
+      return nil, err
+    }
+    blog.Likes--
+  default:
+    return blog, nil
+  }
+  return blog, putBlog(tx, cmd.GetTimestamp(), blog)
+}
+
+// applyViews adds the views of a VIEW command to blog and to the hour they
+// were counted in. Only the views are recorded in the changelog, not the
+// blog.
+func applyViews(tx *bolt.Tx, blog *blogpb.Blog, cmd *blogpb.BlogCommand) (*blogpb.Blog, error) {
+  b := tx.Bucket(viewsBucket)
+  key := viewKey(blog.GetId(), unixHour(cmd.GetTimestamp()))
+  n := cmd.GetViews()
+  if v := b.Get(key); v != nil {
+    n += btoui(v)
+  }
+  if err := b.Put(key, uitob(n)); err != nil {
+    return nil, err
+  }
+  blog.Views += cmd.GetViews()
+  if err := setViews(tx, blog.GetId(), blog.GetViews()); err != nil {
+    return nil, err
+  }
+  return blog, appendChange(tx, &blogpb.ChangeEntry{
+    Op:        blogpb.ChangeEntry_VIEW,
+    BlogId:    blog.GetId(),
+    Views:     cmd.GetViews(),
+    Timestamp: cmd.GetTimestamp(),
+  })
+}
+
+// blogViews returns the total views of blog id. Until the views have their
+// own bucket, during the migrations, they are those stored in the blog.
+func blogViews(tx *bolt.Tx, id uint64, stored uint64) uint64 {
+  b := tx.Bucket(viewTotalsBucket)
+  if b == nil {
+    return stored
+  }
+  if v := b.Get(uitob(id)); v != nil {
+    return btoui(v)
+  }
+  return 0
+}
+
+func setViews(tx *bolt.Tx, id uint64, views uint64) error {
+  b := tx.Bucket(viewTotalsBucket)
+  if views == 0 {
+    return b.Delete(uitob(id))
+  }
+  return b.Put(uitob(id), uitob(views))
+}
+
+// withoutViews returns blog as it is stored, its views being kept apart
+// once they have their own bucket.
+func withoutViews(tx *bolt.Tx, blog *blogpb.Blog) *blogpb.Blog {
+  if blog.GetViews() == 0 || tx.Bucket(viewTotalsBucket) == nil {
+    return blog
+  }
+  stored := *blog
+  stored.Views = 0
+  return &stored
+}
+
+// removeEngagement deletes the likes and views of blog id.
+func removeEngagement(tx *bolt.Tx, id uint64) error {
+  prefix := uitob(id)
+  for _, name := range engagementBuckets {
+    b := tx.Bucket(name)
+    // deleting while iterating makes the cursor skip keys
+    var keys [][]byte
+    c := b.Cursor()
+    for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+      keys = append(keys, k)
+    }
+    for _, k := range keys {
+      if err := b.Delete(k); err != nil {
+        return err
+      }
+    }
+  }
+  return nil
+}
+
+// viewCounter adds up the views counted by ReadBlog in memory until they are
+// flushed, so reads don't need a write transaction. A nil counter counts
+// nothing.
+type viewCounter struct {
+  mu      sync.Mutex
+  pending map[uint64]uint64
+}
+
+func newViewCounter() *viewCounter {
+  return &viewCounter{pending: make(map[uint64]uint64)}
+}
+
+func (v *viewCounter) add(id uint64) {
+  if v == nil {
+    return
+  }
+  v.mu.Lock()
+  defer v.mu.Unlock()
+  v.pending[id]++
+}
+
+// take returns the views counted since the last call.
+func (v *viewCounter) take() map[uint64]uint64 {
+  if v == nil {
+    return nil
+  }
+  v.mu.Lock()
+  defer v.mu.Unlock()
+  pending := v.pending
+  v.pending = make(map[uint64]uint64)
+  return pending
+}
+
+// flushViewsEvery writes the counted views every interval until ctx is done.
+func (s *server) flushViewsEvery(ctx context.Context, interval time.Duration) {
+  ticker := time.NewTicker(interval)
+  defer ticker.Stop()
+  for {
+    select {
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+      s.flushViews(ctx)
+    }
+  }
+}
+
+// flushViews writes the views counted since the last flush, with one write
+// per viewed blog. Only the node taking writes records them, followers and
+// cluster nodes that aren't the leader drop theirs.
+func (s *server) flushViews(ctx context.Context) {
+  pending := s.views.take()
+  if len(pending) == 0 || s.checkWritable(ctx) != nil {
+    return
+  }
+  now := time.Now().UnixNano()
+  for id, n := range pending {
+    _, err := s.execute(ctx, &blogpb.BlogCommand{
+      Op:        blogpb.BlogCommand_VIEW,
+      BlogId:    id,
+      Views:     n,
+      Timestamp: now,
+    })
+    // blogs deleted since they were read don't need their views anymore
+    if err != nil && status.Code(err) != codes.NotFound {
+      fmt.Printf("Could not record %v views of blog %v: %v\n\n", n, id, err)
+    }
+  }
+}
+
+// likeUser returns who a like is from: the caller when the server
+// authenticates callers, the user_id of the request otherwise.
+func (s *server) likeUser(ctx context.Context, userID string) (string, error) {
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return "", err
+  }
+  if caller != "" {
+    return caller, nil
+  }
+  if userID == "" {
+    return "", status.Error(codes.InvalidArgument, "The user_id is required")
+  }
+  return userID, nil
+}
+
+// like runs a LIKE or UNLIKE command for user on blog id and returns its
+// number of likes. Only those who can read a blog can like it.
+func (s *server) like(ctx context.Context, op blogpb.BlogCommand_Op, id uint64, user string) (uint64, error) {
+  if err := s.checkWritable(ctx); err != nil {
+    return 0, err
+  }
+  err := s.viewBlog(id, func(tx *bolt.Tx) error {
+    blog, err := getBlog(tx, id)
+    if err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+    }
+    if blog == nil {
+      return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", id))
+    }
+    return s.checkRead(blog, user)
+  })
+  if err != nil {
+    return 0, err
+  }
+
+  blog, err := s.execute(ctx, &blogpb.BlogCommand{
+    Op:        op,
+    BlogId:    id,
+    Caller:    user,
+    Timestamp: time.Now().UnixNano(),
+  })
+  if err != nil {
+    return 0, err
+  }
+  return blog.GetLikes(), nil
+}
+
+func (s *server) LikeBlog(ctx context.Context, req *blogpb.LikeBlogRequest) (*blogpb.LikeBlogResponse, error) {
+  fmt.Printf("LikeBlog was invoked with: %v\n\n", req)
+  user, err := s.likeUser(ctx, req.GetUserId())
+  if err != nil {
+    return nil, err
+  }
+  likes, err := s.like(ctx, blogpb.BlogCommand_LIKE, req.GetBlogId(), user)
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.LikeBlogResponse{
+    Likes: likes,
+  }, nil
+}
+
+func (s *server) UnlikeBlog(ctx context.Context, req *blogpb.UnlikeBlogRequest) (*blogpb.UnlikeBlogResponse, error) {
+  fmt.Printf("UnlikeBlog was invoked with: %v\n\n", req)
+  user, err := s.likeUser(ctx, req.GetUserId())
+  if err != nil {
+    return nil, err
+  }
+  likes, err := s.like(ctx, blogpb.BlogCommand_UNLIKE, req.GetBlogId(), user)
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.UnlikeBlogResponse{
+    Likes: likes,
+  }, nil
+}
+
+// countEngagement adds to scores the views or likes of every blog of tx
+// since the given time.
+func countEngagement(tx *bolt.Tx, metric blogpb.TopBlogsRequest_Metric, since int64, scores map[uint64]uint64) error {
+  if metric == blogpb.TopBlogsRequest_LIKES {
+    return tx.Bucket(likesBucket).ForEach(func(k, v []byte) error {
+      if int64(btoui(v)) >= since {
+        scores[btoui(k[:8])]++
+      }
+      return nil
+    })
+  }
+  sinceHour := unixHour(since)
+  return tx.Bucket(viewsBucket).ForEach(func(k, v []byte) error {
+    if btoui(k[8:]) >= sinceHour {
+      scores[btoui(k[:8])] += btoui(v)
+    }
+    return nil
+  })
+}
+
+// TopBlogs ranks the blogs the caller can read by views or likes. Rankings
+// over a window scan the counts of every blog, and only the node taking
+// writes has them, followers only know the totals.
+func (s *server) TopBlogs(ctx context.Context, req *blogpb.TopBlogsRequest) (*blogpb.TopBlogsResponse, error) {
+  fmt.Printf("TopBlogs was invoked with: %v\n\n", req)
+  caller, err := s.caller(ctx)
+  if err != nil {
+    return nil, err
+  }
+  limit := int(req.GetLimit())
+  if limit <= 0 {
+    limit = defaultTopBlogs
+  } else if limit > maxTopBlogs {
+    limit = maxTopBlogs
+  }
+
+  scores := make(map[uint64]uint64)
+  if req.GetWindowSeconds() <= 0 {
+    err = s.eachBlog(func(blog *blogpb.Blog) error {
+      n := blog.GetViews()
+      if req.GetMetric() == blogpb.TopBlogsRequest_LIKES {
+        n = blog.GetLikes()
+      }
+      if n > 0 {
+        scores[blog.GetId()] = n
+      }
+      return nil
+    })
+  } else {
+    since := time.Now().Add(-time.Duration(req.GetWindowSeconds()) * time.Second).UnixNano()
+    err = s.viewShards(func(tx *bolt.Tx) error {
+      return countEngagement(tx, req.GetMetric(), since, scores)
+    })
+  }
+  if err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not count the engagement: %v", err))
+  }
+
+  ids := make([]uint64, 0, len(scores))
+  for id := range scores {
+    ids = append(ids, id)
+  }
+  sort.Slice(ids, func(i, j int) bool {
+    if scores[ids[i]] != scores[ids[j]] {
+      return scores[ids[i]] > scores[ids[j]]
+    }
+    return ids[i] < ids[j]
+  })
+
+  res := &blogpb.TopBlogsResponse{}
+  for _, id := range ids {
+    if len(res.Blogs) == limit {
+      break
+    }
+    var blog *blogpb.Blog
+    err := s.viewBlog(id, func(tx *bolt.Tx) error {
+      var err error
+      blog, err = getBlog(tx, id)
+      return err
+    })
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+    }
+    // skip the blogs deleted since they were counted
+    if blog == nil || s.checkRead(blog, caller) != nil {
+      continue
+    }
+    res.Blogs = append(res.Blogs, &blogpb.TopBlog{
+      Blog:  s.visibleBlog(localize(blog, nil), caller),
+      Count: scores[id],
+    })
+  }
+  return res, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import(
+  "context"
+  "testing"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+)
+
+func TestFlushViewsRecordsOnlyTheViews(t *testing.T) {
+  s := newTestServer(t, t.TempDir())
+  defer s.Close()
+  s.cache = newBlogCache(100, 1<<20)
+  s.views = newViewCounter()
+  ctx := context.Background()
+  blog := createTestBlog(t, ctx, s, "Viewed")
+
+  read := func() *blogpb.Blog {
+    t.Helper()
+    res, err := s.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: blog.GetId()})
+    if err != nil {
+      t.Fatalf("ReadBlog: %v", err)
+    }
+    return res.GetBlog()
+  }
+  read()
+  read()
+  s.flushViews(ctx)
+  if got := read().GetViews(); got != 2 {
+    t.Errorf("got %v views after the flush, want 2", got)
+  }
+  res, err := s.CacheStats(ctx, &blogpb.CacheStatsRequest{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if res.GetHits() != 2 || res.GetMisses() != 1 {
+    t.Errorf("got %v hits and %v misses, want the flush to keep the blog cached", res.GetHits(), res.GetMisses())
+  }
+
+  var changes []*blogpb.ChangeEntry
+  err = s.view(func(tx *bolt.Tx) error {
+    stored := &blogpb.Blog{}
+    id := uitob(blog.GetId())
+    if err := unmarshalValue(tx, blogBucket, id, tx.Bucket(blogBucket).Get(id), stored); err != nil {
+      return err
+    }
+    if stored.GetViews() != 0 {
+      t.Errorf("the stored blog has %v views, want them in their own bucket", stored.GetViews())
+    }
+    changes, err = readChanges(tx, 1, 10)
+    return err
+  })
+  if err != nil {
+    t.Fatal(err)
+  }
+  last := changes[len(changes)-1]
+  if len(changes) != 2 || last.GetOp() != blogpb.ChangeEntry_VIEW || last.GetViews() != 2 || last.GetBlog() != nil {
+    t.Fatalf("got changelog %v, want the create and a VIEW entry of 2 views", changes)
+  }
+
+  // a follower replaying the changelog gets the total
+  follower := newTestServer(t, t.TempDir())
+  defer follower.Close()
+  for _, entry := range changes {
+    err := follower.update(func(tx *bolt.Tx) error {
+      return applyChange(tx, entry)
+    })
+    if err != nil {
+      t.Fatalf("applyChange(%v): %v", entry, err)
+    }
+  }
+  got, err := follower.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: blog.GetId()})
+  if err != nil {
+    t.Fatalf("ReadBlog on the follower: %v", err)
+  }
+  if got.GetBlog().GetViews() != 2 {
+    t.Errorf("got %v views on the follower, want 2", got.GetBlog().GetViews())
+  }
+}
@@ -0,0 +1,777 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "io"
+  "log"
+  "net/http"
+  "sort"
+  "strconv"
+  "strings"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/golang/protobuf/jsonpb"
+  "github.com/golang/protobuf/proto"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+// gateway transcodes HTTP/JSON requests into BlogService calls. It talks to
+// the gRPC server through a regular client connection, so every request goes
+// through the same code path as a native gRPC client.
+//
+//   POST   /v1/blogs                   -> CreateBlog (body: Blog)
+//   GET    /v1/blogs                   -> ListBlog (newline-delimited JSON)
+//   GET    /v1/blogs/{blog_id}         -> ReadBlog
+//   PUT    /v1/blogs/{blog_id}         -> UpdateBlog (body: Blog)
+//   DELETE /v1/blogs/{blog_id}         -> DeleteBlog
+//   GET    /v1/blogs/{blog_id}/acl     -> GetBlogAcl
+//   PUT    /v1/blogs/{blog_id}/acl     -> SetBlogAcl (body: BlogAcl)
+//   POST   /v1/blogs/{blog_id}/like    -> LikeBlog (body: LikeBlogRequest, optional)
+//   DELETE /v1/blogs/{blog_id}/like    -> UnlikeBlog (?user_id=)
+//   GET    /v1/blogs/{blog_id}/related -> RelatedBlogs (?limit=5)
+//   GET    /v1/blogs/{blog_id}/content -> StreamBlogContent (?offset=0, raw body)
+//   GET    /v1/blogs/{blog_id}/diff    -> DiffBlog (?from=&to=)
+//   PUT    /v1/blogs/{blog_id}/content -> WriteBlogContent (?offset=0, raw body)
+//   PUT    /v1/blogs/{blog_id}/translations/{locale} -> UpsertBlogTranslation (body: BlogTranslation)
+//   DELETE /v1/blogs/{blog_id}/translations/{locale} -> DeleteBlogTranslation
+//   GET    /v1/blogs/top               -> TopBlogs (?metric=views|likes&window=24h&limit=10)
+//   GET    /v1/usage                   -> GetUsage (?author_id=)
+//
+//   POST   /v1/series                  -> CreateSeries (body: Series)
+//   GET    /v1/series/{series_id}      -> GetSeries
+//   DELETE /v1/series/{series_id}      -> DeleteSeries
+//   POST   /v1/series/{series_id}/blogs -> AddToSeries (body: AddToSeriesRequest)
+//   PUT    /v1/series/{series_id}/blogs/{blog_id} -> MoveInSeries (body: MoveInSeriesRequest)
+//   DELETE /v1/series/{series_id}/blogs/{blog_id} -> RemoveFromSeries
+//
+// ReadBlog returns the navigation in the series of the blog with
+// ?navigation=true. ReadBlog and ListBlog read the blogs as they were at
+// ?as_of=, and DiffBlog takes its moments from ?from= and ?to=, either RFC
+// 3339 times or unix nanoseconds. ReadBlog and ListBlog pick the locale of the blogs from ?locale=es,en or
+// else from the Accept-Language header.
+type gateway struct {
+  client    blogpb.BlogServiceClient
+  marshaler *jsonpb.Marshaler
+}
+
+func newGateway(cc *grpc.ClientConn) *gateway {
+  return &gateway{
+    client: blogpb.NewBlogServiceClient(cc),
+    marshaler: &jsonpb.Marshaler{
+      OrigName:     true,
+      EmitDefaults: true,
+    },
+  }
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+  path := strings.TrimSuffix(r.URL.Path, "/")
+  if path == "/v1/series" || strings.HasPrefix(path, "/v1/series/") {
+    g.serveSeries(w, r, strings.TrimPrefix(path, "/v1/series"))
+    return
+  }
+  if path == "/v1/blogs" {
+    switch r.Method {
+    case http.MethodPost:
+      g.createBlog(w, r)
+    case http.MethodGet:
+      g.listBlog(w, r)
+    default:
+      g.methodNotAllowed(w, r)
+    }
+    return
+  }
+
+  if path == "/v1/usage" {
+    if r.Method != http.MethodGet {
+      g.methodNotAllowed(w, r)
+      return
+    }
+    res, err := g.client.GetUsage(outgoingContext(r), &blogpb.GetUsageRequest{
+      AuthorId: r.URL.Query().Get("author_id"),
+    })
+    g.writeResult(w, res, err)
+    return
+  }
+
+  if path == "/v1/blogs/top" {
+    if r.Method != http.MethodGet {
+      g.methodNotAllowed(w, r)
+      return
+    }
+    g.topBlogs(w, r)
+    return
+  }
+
+  if !strings.HasPrefix(path, "/v1/blogs/") {
+    g.writeError(w, status.Error(codes.NotFound, fmt.Sprintf("No route for %v", r.URL.Path)))
+    return
+  }
+  parts := strings.SplitN(strings.TrimPrefix(path, "/v1/blogs/"), "/", 2)
+  id, err := strconv.ParseUint(parts[0], 10, 64)
+  if err != nil {
+    g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid blog id: %v", err)))
+    return
+  }
+  sub := ""
+  if len(parts) == 2 {
+    sub = parts[1]
+  }
+  if strings.HasPrefix(sub, "translations/") {
+    locale := strings.TrimPrefix(sub, "translations/")
+    switch r.Method {
+    case http.MethodPut:
+      g.upsertBlogTranslation(w, r, id, locale)
+    case http.MethodDelete:
+      g.deleteBlogTranslation(w, r, id, locale)
+    default:
+      g.methodNotAllowed(w, r)
+    }
+    return
+  }
+  switch {
+  case sub == "acl" && r.Method == http.MethodGet:
+    g.getBlogAcl(w, r, id)
+  case sub == "acl" && r.Method == http.MethodPut:
+    g.setBlogAcl(w, r, id)
+  case sub == "like" && r.Method == http.MethodPost:
+    g.likeBlog(w, r, id)
+  case sub == "like" && r.Method == http.MethodDelete:
+    g.unlikeBlog(w, r, id)
+  case sub == "related" && r.Method == http.MethodGet:
+    g.relatedBlogs(w, r, id)
+  case sub == "content" && r.Method == http.MethodGet:
+    g.streamBlogContent(w, r, id)
+  case sub == "content" && r.Method == http.MethodPut:
+    g.writeBlogContent(w, r, id)
+  case sub == "diff" && r.Method == http.MethodGet:
+    g.diffBlog(w, r, id)
+  case sub == "" && r.Method == http.MethodGet:
+    g.readBlog(w, r, id)
+  case sub == "" && (r.Method == http.MethodPut || r.Method == http.MethodPatch):
+    g.updateBlog(w, r, id)
+  case sub == "" && r.Method == http.MethodDelete:
+    g.deleteBlog(w, r, id)
+  case sub == "" || sub == "acl" || sub == "like" || sub == "related" || sub == "content" || sub == "diff":
+    g.methodNotAllowed(w, r)
+  default:
+    g.writeError(w, status.Error(codes.NotFound, fmt.Sprintf("No route for %v", r.URL.Path)))
+  }
+}
+
+// serveSeries routes the requests under /v1/series, path being what follows.
+func (g *gateway) serveSeries(w http.ResponseWriter, r *http.Request, path string) {
+  if path == "" {
+    if r.Method != http.MethodPost {
+      g.methodNotAllowed(w, r)
+      return
+    }
+    series := &blogpb.Series{}
+    if err := g.readBody(r, series); err != nil {
+      g.writeError(w, err)
+      return
+    }
+    res, err := g.client.CreateSeries(outgoingContext(r), &blogpb.CreateSeriesRequest{
+      Series: series,
+    })
+    g.writeResult(w, res, err)
+    return
+  }
+
+  parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+  id, err := strconv.ParseUint(parts[0], 10, 64)
+  if err != nil {
+    g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid series id: %v", err)))
+    return
+  }
+  switch {
+  case len(parts) == 1 && r.Method == http.MethodGet:
+    res, err := g.client.GetSeries(outgoingContext(r), &blogpb.GetSeriesRequest{
+      SeriesId: id,
+      Locales:  requestLocales(r),
+    })
+    g.writeResult(w, res, err)
+  case len(parts) == 1 && r.Method == http.MethodDelete:
+    res, err := g.client.DeleteSeries(outgoingContext(r), &blogpb.DeleteSeriesRequest{
+      SeriesId: id,
+    })
+    g.writeResult(w, res, err)
+  case len(parts) == 2 && parts[1] == "blogs" && r.Method == http.MethodPost:
+    req := &blogpb.AddToSeriesRequest{}
+    if err := g.readBody(r, req); err != nil {
+      g.writeError(w, err)
+      return
+    }
+    req.SeriesId = id
+    res, err := g.client.AddToSeries(outgoingContext(r), req)
+    g.writeResult(w, res, err)
+  case len(parts) == 3 && parts[1] == "blogs":
+    blogID, err := strconv.ParseUint(parts[2], 10, 64)
+    if err != nil {
+      g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid blog id: %v", err)))
+      return
+    }
+    switch r.Method {
+    case http.MethodPut:
+      req := &blogpb.MoveInSeriesRequest{}
+      if err := g.readBody(r, req); err != nil {
+        g.writeError(w, err)
+        return
+      }
+      req.SeriesId = id
+      req.BlogId = blogID
+      res, err := g.client.MoveInSeries(outgoingContext(r), req)
+      g.writeResult(w, res, err)
+    case http.MethodDelete:
+      res, err := g.client.RemoveFromSeries(outgoingContext(r), &blogpb.RemoveFromSeriesRequest{
+        SeriesId: id,
+        BlogId:   blogID,
+      })
+      g.writeResult(w, res, err)
+    default:
+      g.methodNotAllowed(w, r)
+    }
+  case len(parts) == 1 || (len(parts) == 2 && parts[1] == "blogs"):
+    g.methodNotAllowed(w, r)
+  default:
+    g.writeError(w, status.Error(codes.NotFound, fmt.Sprintf("No route for %v", r.URL.Path)))
+  }
+}
+
+// writeResult writes the response of a unary call, or its error.
+func (g *gateway) writeResult(w http.ResponseWriter, res proto.Message, err error) {
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) createBlog(w http.ResponseWriter, r *http.Request) {
+  blog := &blogpb.Blog{}
+  if err := g.readBody(r, blog); err != nil {
+    g.writeError(w, err)
+    return
+  }
+  res, err := g.client.CreateBlog(outgoingContext(r), &blogpb.CreateBlogRequest{
+    Blog: blog,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) readBlog(w http.ResponseWriter, r *http.Request, id uint64) {
+  asOf, err := queryTimestamp(r, "as_of")
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  res, err := g.client.ReadBlog(outgoingContext(r), &blogpb.ReadBlogRequest{
+    BlogId:         id,
+    Locales:        requestLocales(r),
+    WithNavigation: r.URL.Query().Get("navigation") == "true",
+    AsOf:           asOf,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) updateBlog(w http.ResponseWriter, r *http.Request, id uint64) {
+  blog := &blogpb.Blog{}
+  if err := g.readBody(r, blog); err != nil {
+    g.writeError(w, err)
+    return
+  }
+  // the id in the path always wins over the one in the body
+  blog.Id = id
+  res, err := g.client.UpdateBlog(outgoingContext(r), &blogpb.UpdateBlogRequest{
+    Blog: blog,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) deleteBlog(w http.ResponseWriter, r *http.Request, id uint64) {
+  res, err := g.client.DeleteBlog(outgoingContext(r), &blogpb.DeleteBlogRequest{
+    BlogId: id,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) getBlogAcl(w http.ResponseWriter, r *http.Request, id uint64) {
+  res, err := g.client.GetBlogAcl(outgoingContext(r), &blogpb.GetBlogAclRequest{
+    BlogId: id,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) setBlogAcl(w http.ResponseWriter, r *http.Request, id uint64) {
+  acl := &blogpb.BlogAcl{}
+  if err := g.readBody(r, acl); err != nil {
+    g.writeError(w, err)
+    return
+  }
+  res, err := g.client.SetBlogAcl(outgoingContext(r), &blogpb.SetBlogAclRequest{
+    BlogId: id,
+    Acl:    acl,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) likeBlog(w http.ResponseWriter, r *http.Request, id uint64) {
+  req := &blogpb.LikeBlogRequest{}
+  if err := g.readBody(r, req); err != nil {
+    g.writeError(w, err)
+    return
+  }
+  req.BlogId = id
+  res, err := g.client.LikeBlog(outgoingContext(r), req)
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) unlikeBlog(w http.ResponseWriter, r *http.Request, id uint64) {
+  res, err := g.client.UnlikeBlog(outgoingContext(r), &blogpb.UnlikeBlogRequest{
+    BlogId: id,
+    UserId: r.URL.Query().Get("user_id"),
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) relatedBlogs(w http.ResponseWriter, r *http.Request, id uint64) {
+  req := &blogpb.RelatedBlogsRequest{
+    BlogId: id,
+  }
+  if limit := r.URL.Query().Get("limit"); limit != "" {
+    n, err := strconv.ParseInt(limit, 10, 32)
+    if err != nil {
+      g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid limit: %v", err)))
+      return
+    }
+    req.Limit = int32(n)
+  }
+  res, err := g.client.RelatedBlogs(outgoingContext(r), req)
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+// streamBlogContent writes the whole content of a blog as the response
+// body. Like with listBlog, errors after the first piece can't change the
+// status code anymore, and only cut the body short.
+func (g *gateway) streamBlogContent(w http.ResponseWriter, r *http.Request, id uint64) {
+  req := &blogpb.StreamBlogContentRequest{
+    BlogId: id,
+  }
+  if v := r.URL.Query().Get("offset"); v != "" {
+    offset, err := strconv.ParseUint(v, 10, 64)
+    if err != nil {
+      g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid offset: %v", err)))
+      return
+    }
+    req.Offset = offset
+  }
+  stream, err := g.client.StreamBlogContent(outgoingContext(r), req)
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  flusher, _ := w.(http.Flusher)
+  started := false
+  for {
+    res, err := stream.Recv()
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      if !started {
+        g.writeError(w, err)
+        return
+      }
+      log.Printf("Could not stream the content of blog %v: %v\n", id, err)
+      return
+    }
+    if !started {
+      w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+      w.Header().Set("Content-Length", strconv.FormatUint(res.GetContentSize()-req.GetOffset(), 10))
+      w.WriteHeader(http.StatusOK)
+      started = true
+    }
+    if _, err := w.Write(res.GetData()); err != nil {
+      return
+    }
+    if flusher != nil {
+      flusher.Flush()
+    }
+  }
+  if !started {
+    // empty content
+    w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+    w.WriteHeader(http.StatusOK)
+  }
+}
+
+// writeBlogContent uploads the request body as the content of a blog. A body
+// cut short leaves the upload in progress, to be resumed with ?offset= from
+// the offset in the X-Upload-Offset header of the error.
+func (g *gateway) writeBlogContent(w http.ResponseWriter, r *http.Request, id uint64) {
+  defer r.Body.Close()
+  var offset uint64
+  if v := r.URL.Query().Get("offset"); v != "" {
+    var err error
+    if offset, err = strconv.ParseUint(v, 10, 64); err != nil {
+      g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid offset: %v", err)))
+      return
+    }
+  }
+  var trailer metadata.MD
+  stream, err := g.client.WriteBlogContent(outgoingContext(r), grpc.Trailer(&trailer))
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  req := &blogpb.WriteBlogContentRequest{
+    BlogId: id,
+    Offset: offset,
+  }
+  buf := make([]byte, contentChunkSize)
+  var bodyErr error
+  for {
+    n, err := io.ReadFull(r.Body, buf)
+    req.Data = buf[:n]
+    if err == io.EOF || err == io.ErrUnexpectedEOF {
+      req.Complete = true
+    } else if err != nil {
+      // keep what was read, without completing the upload
+      bodyErr = err
+    }
+    // a failed send shows up as the error of CloseAndRecv
+    if stream.Send(req) != nil || err != nil {
+      break
+    }
+    req = &blogpb.WriteBlogContentRequest{}
+  }
+  res, err := stream.CloseAndRecv()
+  if err == nil && bodyErr != nil {
+    w.Header().Set("X-Upload-Offset", strconv.FormatUint(res.GetOffset(), 10))
+    err = status.Error(codes.Aborted, fmt.Sprintf("Could not read the content: %v", bodyErr))
+  } else if v := trailer.Get("x-upload-offset"); err != nil && len(v) > 0 {
+    w.Header().Set("X-Upload-Offset", v[0])
+  }
+  g.writeResult(w, res, err)
+}
+
+func (g *gateway) upsertBlogTranslation(w http.ResponseWriter, r *http.Request, id uint64, locale string) {
+  t := &blogpb.BlogTranslation{}
+  if err := g.readBody(r, t); err != nil {
+    g.writeError(w, err)
+    return
+  }
+  // the locale in the path always wins over the one in the body
+  t.Locale = locale
+  res, err := g.client.UpsertBlogTranslation(outgoingContext(r), &blogpb.UpsertBlogTranslationRequest{
+    BlogId:      id,
+    Translation: t,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) deleteBlogTranslation(w http.ResponseWriter, r *http.Request, id uint64, locale string) {
+  res, err := g.client.DeleteBlogTranslation(outgoingContext(r), &blogpb.DeleteBlogTranslationRequest{
+    BlogId: id,
+    Locale: locale,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+func (g *gateway) diffBlog(w http.ResponseWriter, r *http.Request, id uint64) {
+  from, err := queryTimestamp(r, "from")
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  to, err := queryTimestamp(r, "to")
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  res, err := g.client.DiffBlog(outgoingContext(r), &blogpb.DiffBlogRequest{
+    BlogId:   id,
+    FromTime: from,
+    ToTime:   to,
+  })
+  g.writeResult(w, res, err)
+}
+
+// queryTimestamp returns the query parameter name as unix nanoseconds, 0
+// when it is missing. It is either an RFC 3339 time or a number of
+// nanoseconds.
+func queryTimestamp(r *http.Request, name string) (int64, error) {
+  v := r.URL.Query().Get(name)
+  if v == "" {
+    return 0, nil
+  }
+  if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+    return n, nil
+  }
+  t, err := time.Parse(time.RFC3339Nano, v)
+  if err != nil {
+    return 0, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid %v: %v", name, err))
+  }
+  return t.UnixNano(), nil
+}
+
+func (g *gateway) topBlogs(w http.ResponseWriter, r *http.Request) {
+  q := r.URL.Query()
+  req := &blogpb.TopBlogsRequest{}
+  if metric := q.Get("metric"); metric != "" {
+    v, ok := blogpb.TopBlogsRequest_Metric_value[strings.ToUpper(metric)]
+    if !ok {
+      g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Unknown metric %q", metric)))
+      return
+    }
+    req.Metric = blogpb.TopBlogsRequest_Metric(v)
+  }
+  if window := q.Get("window"); window != "" {
+    d, err := time.ParseDuration(window)
+    if err != nil {
+      g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid window: %v", err)))
+      return
+    }
+    req.WindowSeconds = int64(d / time.Second)
+  }
+  if limit := q.Get("limit"); limit != "" {
+    n, err := strconv.ParseInt(limit, 10, 32)
+    if err != nil {
+      g.writeError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid limit: %v", err)))
+      return
+    }
+    req.Limit = int32(n)
+  }
+  res, err := g.client.TopBlogs(outgoingContext(r), req)
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  g.writeMessage(w, http.StatusOK, res)
+}
+
+// listBlog writes every ListBlogResponse received from the stream as one JSON
+// object per line. Errors that happen once the stream has started are sent as
+// a last line of the form {"error": {...}} since the status code is already out.
+func (g *gateway) listBlog(w http.ResponseWriter, r *http.Request) {
+  asOf, err := queryTimestamp(r, "as_of")
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  stream, err := g.client.ListBlog(outgoingContext(r), &blogpb.ListBlogRequest{
+    Locales: requestLocales(r),
+    AsOf:    asOf,
+  })
+  if err != nil {
+    g.writeError(w, err)
+    return
+  }
+  flusher, _ := w.(http.Flusher)
+  started := false
+  for {
+    res, err := stream.Recv()
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      if !started {
+        g.writeError(w, err)
+        return
+      }
+      fmt.Fprintf(w, "{\"error\":%s}\n", errorBody(status.Convert(err)))
+      return
+    }
+    if !started {
+      w.Header().Set("Content-Type", "application/x-ndjson")
+      w.WriteHeader(http.StatusOK)
+      started = true
+    }
+    if err := g.marshaler.Marshal(w, res); err != nil {
+      log.Printf("Could not write ListBlog response: %v\n", err)
+      return
+    }
+    io.WriteString(w, "\n")
+    if flusher != nil {
+      flusher.Flush()
+    }
+  }
+  if !started {
+    // empty collection
+    w.Header().Set("Content-Type", "application/x-ndjson")
+    w.WriteHeader(http.StatusOK)
+  }
+}
+
+func (g *gateway) methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+  st := status.New(codes.Unimplemented, fmt.Sprintf("Method %v not allowed on %v", r.Method, r.URL.Path))
+  w.Header().Set("Content-Type", "application/json")
+  w.WriteHeader(http.StatusMethodNotAllowed)
+  w.Write(errorBody(st))
+}
+
+func (g *gateway) readBody(r *http.Request, msg proto.Message) error {
+  defer r.Body.Close()
+  if err := jsonpb.Unmarshal(r.Body, msg); err != nil && err != io.EOF {
+    return status.Error(codes.InvalidArgument, fmt.Sprintf("Malformed request body: %v", err))
+  }
+  return nil
+}
+
+func (g *gateway) writeMessage(w http.ResponseWriter, code int, msg proto.Message) {
+  w.Header().Set("Content-Type", "application/json")
+  w.WriteHeader(code)
+  if err := g.marshaler.Marshal(w, msg); err != nil {
+    log.Printf("Could not write response: %v\n", err)
+  }
+}
+
+func (g *gateway) writeError(w http.ResponseWriter, err error) {
+  st := status.Convert(err)
+  w.Header().Set("Content-Type", "application/json")
+  w.WriteHeader(httpStatusFromCode(st.Code()))
+  w.Write(errorBody(st))
+}
+
+func errorBody(st *status.Status) []byte {
+  body, err := (&jsonpb.Marshaler{OrigName: true, EmitDefaults: true}).MarshalToString(st.Proto())
+  if err != nil {
+    return []byte(fmt.Sprintf("{\"code\":%d}", st.Code()))
+  }
+  return []byte(body)
+}
+
+// requestLocales returns the locales preferred by an HTTP client, from the
+// locale query parameter or else the Accept-Language header, best first.
+func requestLocales(r *http.Request) []string {
+  if locale := r.URL.Query().Get("locale"); locale != "" {
+    return strings.Split(locale, ",")
+  }
+  type weighted struct {
+    locale string
+    q      float64
+  }
+  var prefs []weighted
+  for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+    fields := strings.Split(strings.TrimSpace(part), ";")
+    locale := strings.TrimSpace(fields[0])
+    if locale == "" || locale == "*" {
+      continue
+    }
+    q := 1.0
+    for _, param := range fields[1:] {
+      param = strings.TrimSpace(param)
+      if strings.HasPrefix(param, "q=") {
+        if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+          q = v
+        }
+      }
+    }
+    if q > 0 {
+      prefs = append(prefs, weighted{locale, q})
+    }
+  }
+  sort.SliceStable(prefs, func(i, j int) bool {
+    return prefs[i].q > prefs[j].q
+  })
+  locales := make([]string, len(prefs))
+  for i, p := range prefs {
+    locales[i] = p.locale
+  }
+  return locales
+}
+
+// outgoingContext forwards the Authorization header and every header prefixed
+// with Grpc-Metadata- to the gRPC server as request metadata.
+func outgoingContext(r *http.Request) context.Context {
+  md := metadata.MD{}
+  for key, values := range r.Header {
+    switch {
+    case key == "Authorization":
+      md.Append("authorization", values...)
+    case strings.HasPrefix(key, "Grpc-Metadata-"):
+      md.Append(strings.TrimPrefix(key, "Grpc-Metadata-"), values...)
+    }
+  }
+  return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// httpStatusFromCode maps a gRPC status code to the closest HTTP status.
+func httpStatusFromCode(code codes.Code) int {
+  switch code {
+  case codes.OK:
+    return http.StatusOK
+  case codes.Canceled:
+    return 499 // client closed request
+  case codes.InvalidArgument, codes.OutOfRange:
+    return http.StatusBadRequest
+  case codes.DeadlineExceeded:
+    return http.StatusGatewayTimeout
+  case codes.NotFound:
+    return http.StatusNotFound
+  case codes.AlreadyExists, codes.Aborted:
+    return http.StatusConflict
+  case codes.PermissionDenied:
+    return http.StatusForbidden
+  case codes.Unauthenticated:
+    return http.StatusUnauthorized
+  case codes.ResourceExhausted:
+    return http.StatusTooManyRequests
+  case codes.FailedPrecondition:
+    return http.StatusPreconditionFailed
+  case codes.Unimplemented:
+    return http.StatusNotImplemented
+  case codes.Unavailable:
+    return http.StatusServiceUnavailable
+  }
+  return http.StatusInternalServerError
+}
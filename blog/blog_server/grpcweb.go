@@ -0,0 +1,55 @@
+package main
+
+import(
+  "net/http"
+  "strings"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/improbable-eng/grpc-web/go/grpcweb"
+  "google.golang.org/grpc"
+)
+
+// newGrpcWebHandler serves BlogService to browsers using the gRPC-Web
+// protocol over HTTP/1.1, both in binary (application/grpc-web) and base64
+// text (application/grpc-web-text) modes. Unary RPCs and server streaming
+// RPCs such as ListBlog are supported; client and bidirectional streaming
+// are not part of gRPC-Web.
+//
+// BlogService is registered alone on a gRPC server of its own, so that the
+// admin, replication and cluster services stay out of reach of browsers.
+//
+// allowedOrigins lists the origins accepted by CORS, "*" accepts any origin.
+func newGrpcWebHandler(blog blogpb.BlogServiceServer, allowedOrigins []string) http.Handler {
+  s := grpc.NewServer()
+  blogpb.RegisterBlogServiceServer(s, blog)
+  wrapped := grpcweb.WrapServer(s,
+    grpcweb.WithOriginFunc(originMatcher(allowedOrigins)),
+    grpcweb.WithCorsForRegisteredEndpointsOnly(true),
+    grpcweb.WithAllowedRequestHeaders([]string{
+      "authorization", "content-type", "grpc-timeout", "x-grpc-web", "x-user-agent",
+    }),
+  )
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if wrapped.IsGrpcWebRequest(r) || wrapped.IsAcceptableGrpcCorsRequest(r) {
+      wrapped.ServeHTTP(w, r)
+      return
+    }
+    http.Error(w, "Expected a gRPC-Web request", http.StatusUnsupportedMediaType)
+  })
+}
+
+func originMatcher(allowedOrigins []string) func(origin string) bool {
+  allowed := make(map[string]bool)
+  for _, origin := range allowedOrigins {
+    origin = strings.TrimSpace(origin)
+    if origin == "*" {
+      return func(string) bool { return true }
+    }
+    if origin != "" {
+      allowed[strings.TrimSuffix(origin, "/")] = true
+    }
+  }
+  return func(origin string) bool {
+    return allowed[origin]
+  }
+}
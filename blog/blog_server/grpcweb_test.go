@@ -0,0 +1,142 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "net/http"
+  "net/http/httptest"
+  "testing"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/villegasl/go_grpc_course/blog/grpcweb"
+  "github.com/golang/protobuf/proto"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+const allowedOrigin = "http://localhost:3000"
+
+func newGrpcWebTestServer(t *testing.T) *httptest.Server {
+  s := newTestServer(t, t.TempDir())
+  t.Cleanup(s.Close)
+  ts := httptest.NewServer(newGrpcWebHandler(s, []string{allowedOrigin}))
+  t.Cleanup(ts.Close)
+  return ts
+}
+
+// invokeUnary calls method through c and returns its single response.
+func invokeUnary(c *grpcweb.Client, method string, req, res proto.Message) error {
+  n := 0
+  err := c.Invoke(context.Background(), method, req, func() proto.Message {
+    return res
+  }, func(proto.Message) error {
+    n++
+    return nil
+  })
+  if err == nil && n != 1 {
+    return fmt.Errorf("%v sent %v messages, want 1", method, n)
+  }
+  return err
+}
+
+func TestGrpcWebCalls(t *testing.T) {
+  ts := newGrpcWebTestServer(t)
+
+  for _, text := range []bool{false, true} {
+    t.Run(fmt.Sprintf("text=%v", text), func(t *testing.T) {
+      c := grpcweb.NewClient(ts.URL, text)
+      title := fmt.Sprintf("Sent with text=%v", text)
+      created := &blogpb.CreateBlogResponse{}
+      err := invokeUnary(c, "/blog.BlogService/CreateBlog", &blogpb.CreateBlogRequest{
+        Blog: &blogpb.Blog{AuthorId: "luis", Title: title, Content: "Hello"},
+      }, created)
+      if err != nil {
+        t.Fatalf("CreateBlog: %v", err)
+      }
+
+      read := &blogpb.ReadBlogResponse{}
+      err = invokeUnary(c, "/blog.BlogService/ReadBlog", &blogpb.ReadBlogRequest{
+        BlogId: created.GetBlog().GetId(),
+      }, read)
+      if err != nil {
+        t.Fatalf("ReadBlog: %v", err)
+      }
+      if read.GetBlog().GetTitle() != title {
+        t.Errorf("ReadBlog: got title %q, want %q", read.GetBlog().GetTitle(), title)
+      }
+
+      err = invokeUnary(c, "/blog.BlogService/ReadBlog", &blogpb.ReadBlogRequest{BlogId: 404}, &blogpb.ReadBlogResponse{})
+      if status.Code(err) != codes.NotFound {
+        t.Errorf("ReadBlog of a missing blog: got %v, want NotFound", err)
+      }
+    })
+  }
+
+  // both blogs exist now, each mode lists them
+  for _, text := range []bool{false, true} {
+    var titles []string
+    err := grpcweb.NewClient(ts.URL, text).Invoke(context.Background(), "/blog.BlogService/ListBlog", &blogpb.ListBlogRequest{}, func() proto.Message {
+      return &blogpb.ListBlogResponse{}
+    }, func(msg proto.Message) error {
+      titles = append(titles, msg.(*blogpb.ListBlogResponse).GetBlog().GetTitle())
+      return nil
+    })
+    if err != nil {
+      t.Fatalf("ListBlog with text=%v: %v", text, err)
+    }
+    if want := "[Sent with text=false Sent with text=true]"; fmt.Sprint(titles) != want {
+      t.Errorf("ListBlog with text=%v: got %v, want %v", text, titles, want)
+    }
+  }
+}
+
+func TestGrpcWebOnlyServesBlogService(t *testing.T) {
+  c := grpcweb.NewClient(newGrpcWebTestServer(t).URL, false)
+  for method, req := range map[string]proto.Message{
+    "/blog.BlogAdminService/ListQuarantine":      &blogpb.ListQuarantineRequest{},
+    "/blog.ReplicationService/ReplicationStatus": &blogpb.ReplicationStatusRequest{},
+    "/blog.ClusterService/ClusterStatus":         &blogpb.ClusterStatusRequest{},
+  } {
+    err := c.Invoke(context.Background(), method, req, func() proto.Message {
+      return &blogpb.ListBlogResponse{}
+    }, func(proto.Message) error {
+      return fmt.Errorf("%v sent a message", method)
+    })
+    if status.Code(err) != codes.Unimplemented {
+      t.Errorf("%v: got %v, want Unimplemented", method, err)
+    }
+  }
+}
+
+func TestGrpcWebCORSPreflight(t *testing.T) {
+  ts := newGrpcWebTestServer(t)
+  for _, tc := range []struct {
+    origin, method string
+    allowed        bool
+  }{
+    {allowedOrigin, "/blog.BlogService/ListBlog", true},
+    {"http://evil.example", "/blog.BlogService/ListBlog", false},
+    {allowedOrigin, "/blog.BlogAdminService/ListQuarantine", false},
+  } {
+    req, err := http.NewRequest(http.MethodOptions, ts.URL+tc.method, nil)
+    if err != nil {
+      t.Fatal(err)
+    }
+    req.Header.Set("Origin", tc.origin)
+    req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+    req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,authorization")
+    res, err := ts.Client().Do(req)
+    if err != nil {
+      t.Fatal(err)
+    }
+    res.Body.Close()
+
+    got := res.Header.Get("Access-Control-Allow-Origin")
+    if tc.allowed && got != tc.origin {
+      t.Errorf("preflight of %v from %v: got Access-Control-Allow-Origin %q, want %q", tc.method, tc.origin, got, tc.origin)
+    }
+    if !tc.allowed && got != "" {
+      t.Errorf("preflight of %v from %v: got Access-Control-Allow-Origin %q, want none", tc.method, tc.origin, got)
+    }
+  }
+}
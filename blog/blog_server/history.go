@@ -0,0 +1,428 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "sort"
+  "strings"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+const (
+  // maxDiffContent is the largest content DiffBlog compares.
+  maxDiffContent = 8 << 20
+  // maxDiffEdits is the most line edits DiffBlog looks for before it gives
+  // up and reports the contents as replaced whole.
+  maxDiffEdits = 1000
+)
+
+// forEachChangeAsOf calls fn for the changelog entries written up to asOf,
+// in order. The changelog is never trimmed, so it holds every write since
+// the database file was created, or split in shards.
+func forEachChangeAsOf(tx *bolt.Tx, asOf int64, fn func(entry *blogpb.ChangeEntry) error) error {
+  c := tx.Bucket(changelogBucket).Cursor()
+  for k, v := c.First(); k != nil; k, v = c.Next() {
+    entry := &blogpb.ChangeEntry{}
+    if err := unmarshalValue(tx, changelogBucket, k, v, entry); err != nil {
+      return fmt.Errorf("changelog entry %v: %v", btoui(k), err)
+    }
+    if entry.GetTimestamp() > asOf {
+      return nil
+    }
+    if err := fn(entry); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// blogAsOf returns blog id as it was at asOf, or nil if it didn't exist.
+func blogAsOf(tx *bolt.Tx, id uint64, asOf int64) (*blogpb.Blog, error) {
+  blogs := make(map[uint64]*blogpb.Blog)
+  err := forEachChangeAsOf(tx, asOf, func(entry *blogpb.ChangeEntry) error {
+    if entry.GetBlogId() != id {
+      return nil
+    }
+    replayChange(blogs, entry)
+    return nil
+  })
+  return blogs[id], err
+}
+
+// blogsAsOf adds to blogs every blog that existed at asOf, by id.
+func blogsAsOf(tx *bolt.Tx, asOf int64, blogs map[uint64]*blogpb.Blog) error {
+  return forEachChangeAsOf(tx, asOf, func(entry *blogpb.ChangeEntry) error {
+    replayChange(blogs, entry)
+    return nil
+  })
+}
+
+// replayChange applies entry to blogs, by id. The blogs of PUT entries carry
+// their views only if they were written before VIEW entries existed, the
+// views of a blog are otherwise carried over from the previous PUT.
+func replayChange(blogs map[uint64]*blogpb.Blog, entry *blogpb.ChangeEntry) {
+  id := entry.GetBlogId()
+  switch entry.GetOp() {
+  case blogpb.ChangeEntry_PUT:
+    blog := entry.GetBlog()
+    if blog.GetViews() == 0 {
+      blog.Views = blogs[id].GetViews()
+    }
+    blogs[id] = blog
+  case blogpb.ChangeEntry_DELETE:
+    delete(blogs, id)
+  case blogpb.ChangeEntry_VIEW:
+    if blog := blogs[id]; blog != nil {
+      blog.Views += entry.GetViews()
+    }
+  }
+}
+
+// contentAsOf returns the whole content of blog as read at asOf. Uploaded
+// content is put together from the chunks recorded in the changelog, or
+// else from the ones still stored, since a reshard copies them without
+// recording them.
+func contentAsOf(tx *bolt.Tx, blog *blogpb.Blog, asOf int64) (string, error) {
+  if !blog.GetContentTruncated() {
+    return blog.GetContent(), nil
+  }
+  id, version, size := blog.GetId(), blog.GetContentVersion(), blog.GetContentSize()
+  if size > maxDiffContent {
+    return "", status.Error(codes.FailedPrecondition, fmt.Sprintf("The content of blog %v is %v bytes long, only contents up to %v bytes can be compared", id, size, maxDiffContent))
+  }
+  chunks := make(map[uint64][]byte)
+  err := forEachChangeAsOf(tx, asOf, func(entry *blogpb.ChangeEntry) error {
+    if entry.GetOp() == blogpb.ChangeEntry_PUT_CHUNK && entry.GetBlogId() == id && entry.GetContentVersion() == version {
+      chunks[entry.GetChunkIndex()] = entry.GetChunk()
+    }
+    return nil
+  })
+  if err != nil {
+    return "", err
+  }
+  var content []byte
+  for index := uint64(0); uint64(len(content)) < size; index++ {
+    chunk, ok := chunks[index]
+    if !ok {
+      if chunk, err = getChunk(tx, id, version, index); err != nil {
+        return "", err
+      }
+    }
+    if len(chunk) == 0 {
+      return "", status.Error(codes.FailedPrecondition, fmt.Sprintf("The content blog %v had then is no longer available", id))
+    }
+    content = append(content, chunk...)
+  }
+  return string(content[:size]), nil
+}
+
+// checkAsOf validates an as_of timestamp of a request.
+func checkAsOf(asOf int64) error {
+  if asOf < 0 {
+    return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid timestamp %v", asOf))
+  }
+  return nil
+}
+
+// checkReadAsOf fails when caller can't read past, a version of blog id, or
+// the blog as it is now if it still exists, so that making a blog private
+// also hides its history.
+func (s *server) checkReadAsOf(tx *bolt.Tx, past *blogpb.Blog, caller string) error {
+  if err := s.checkRead(past, caller); err != nil {
+    return err
+  }
+  current, err := getBlog(tx, past.GetId())
+  if err != nil || current == nil {
+    return err
+  }
+  return s.checkRead(current, caller)
+}
+
+// readBlogAsOf is ReadBlog for a request with as_of.
+func (s *server) readBlogAsOf(req *blogpb.ReadBlogRequest, caller string) (*blogpb.ReadBlogResponse, error) {
+  if err := checkAsOf(req.GetAsOf()); err != nil {
+    return nil, err
+  }
+  id := req.GetBlogId()
+  var blog *blogpb.Blog
+  err := s.viewBlog(id, func(tx *bolt.Tx) error {
+    var err error
+    blog, err = blogAsOf(tx, id, req.GetAsOf())
+    if err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Could not read the changelog: %v", err))
+    }
+    if blog == nil {
+      return status.Error(codes.NotFound, fmt.Sprintf("Blog %v didn't exist at %v", id, time.Unix(0, req.GetAsOf()).UTC().Format(time.RFC3339Nano)))
+    }
+    return s.checkReadAsOf(tx, blog, caller)
+  })
+  if err != nil {
+    return nil, err
+  }
+  res := &blogpb.ReadBlogResponse {
+    Blog: s.visibleBlog(localize(blog, req.GetLocales()), caller),
+  }
+  if req.GetWithNavigation() {
+    res.Navigation, err = s.seriesNavigation(id, caller, req.GetLocales())
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read the series: %v", err))
+    }
+  }
+  return res, nil
+}
+
+// listBlogAsOf is ListBlog for a request with as_of. The blogs of every file
+// are gathered before they are sent in id order.
+func (s *server) listBlogAsOf(req *blogpb.ListBlogRequest, stream blogpb.BlogService_ListBlogServer, caller string) error {
+  if err := checkAsOf(req.GetAsOf()); err != nil {
+    return err
+  }
+  var blogs []*blogpb.Blog
+  err := s.viewShards(func(tx *bolt.Tx) error {
+    found := make(map[uint64]*blogpb.Blog)
+    if err := blogsAsOf(tx, req.GetAsOf(), found); err != nil {
+      return err
+    }
+    for _, blog := range found {
+      // skip the blogs the caller can't see
+      if s.checkReadAsOf(tx, blog, caller) == nil {
+        blogs = append(blogs, blog)
+      }
+    }
+    return nil
+  })
+  if err != nil {
+    return status.Error(codes.Internal, fmt.Sprintf("Could not read the changelog: %v", err))
+  }
+  sort.Slice(blogs, func(i, j int) bool {
+    return blogs[i].GetId() < blogs[j].GetId()
+  })
+  for _, blog := range blogs {
+    err := stream.Send(&blogpb.ListBlogResponse {
+      Blog: s.visibleBlog(localize(blog, req.GetLocales()), caller),
+    })
+    if err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+func (s *server) DiffBlog(ctx context.Context, req *blogpb.DiffBlogRequest) (*blogpb.DiffBlogResponse, error) {
+  fmt.Printf("DiffBlog was invoked with: %v\n\n", req)
+  caller, err := s.caller(ctx)
+  if err != nil {
+    return nil, err
+  }
+  from, to := req.GetFromTime(), req.GetToTime()
+  if to == 0 {
+    to = time.Now().UnixNano()
+  }
+  if err := checkAsOf(from); err != nil {
+    return nil, err
+  }
+  if err := checkAsOf(to); err != nil {
+    return nil, err
+  }
+
+  id := req.GetBlogId()
+  res := &blogpb.DiffBlogResponse{}
+  var fromContent, toContent string
+  err = s.viewBlog(id, func(tx *bolt.Tx) error {
+    var err error
+    if res.From, err = blogAsOf(tx, id, from); err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Could not read the changelog: %v", err))
+    }
+    if res.To, err = blogAsOf(tx, id, to); err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Could not read the changelog: %v", err))
+    }
+    if res.From == nil && res.To == nil {
+      return status.Error(codes.NotFound, fmt.Sprintf("Blog %v existed at neither moment", id))
+    }
+    if res.From != nil {
+      if err := s.checkReadAsOf(tx, res.From, caller); err != nil {
+        return err
+      }
+      if fromContent, err = contentAsOf(tx, res.From, from); err != nil {
+        return err
+      }
+    }
+    if res.To != nil {
+      if err := s.checkReadAsOf(tx, res.To, caller); err != nil {
+        return err
+      }
+      if toContent, err = contentAsOf(tx, res.To, to); err != nil {
+        return err
+      }
+    }
+    return nil
+  })
+  if err != nil {
+    if _, ok := status.FromError(err); ok {
+      return nil, err
+    }
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read the content: %v", err))
+  }
+  if res.From != nil {
+    res.From = s.visibleBlog(res.From, caller)
+  }
+  if res.To != nil {
+    res.To = s.visibleBlog(res.To, caller)
+  }
+  res.Lines = diffLines(splitLines(fromContent), splitLines(toContent))
+  return res, nil
+}
+
+// splitLines returns the lines of content without their line breaks.
+func splitLines(content string) []string {
+  if content == "" {
+    return nil
+  }
+  return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// diffLines returns the shortest edit script turning a into b, found with
+// the Myers algorithm after the common first and last lines are set aside.
+// Past maxDiffEdits edits it reports every remaining line of a deleted and
+// every one of b inserted.
+func diffLines(a, b []string) []*blogpb.DiffLine {
+  var lines []*blogpb.DiffLine
+  equal := func(i, j int) {
+    lines = append(lines, &blogpb.DiffLine{
+      Op:       blogpb.DiffLine_EQUAL,
+      Text:     a[i],
+      FromLine: int32(i + 1),
+      ToLine:   int32(j + 1),
+    })
+  }
+  del := func(i int) {
+    lines = append(lines, &blogpb.DiffLine{
+      Op:       blogpb.DiffLine_DELETE,
+      Text:     a[i],
+      FromLine: int32(i + 1),
+    })
+  }
+  ins := func(j int) {
+    lines = append(lines, &blogpb.DiffLine{
+      Op:     blogpb.DiffLine_INSERT,
+      Text:   b[j],
+      ToLine: int32(j + 1),
+    })
+  }
+
+  prefix := 0
+  for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+    equal(prefix, prefix)
+    prefix++
+  }
+  suffix := 0
+  for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+    suffix++
+  }
+  n, m := len(a)-prefix-suffix, len(b)-prefix-suffix
+
+  if script := myers(a[prefix:prefix+n], b[prefix:prefix+m]); script != nil {
+    i, j := prefix, prefix
+    for _, op := range script {
+      switch op {
+      case blogpb.DiffLine_EQUAL:
+        equal(i, j)
+        i++
+        j++
+      case blogpb.DiffLine_DELETE:
+        del(i)
+        i++
+      case blogpb.DiffLine_INSERT:
+        ins(j)
+        j++
+      }
+    }
+  } else {
+    for i := prefix; i < prefix+n; i++ {
+      del(i)
+    }
+    for j := prefix; j < prefix+m; j++ {
+      ins(j)
+    }
+  }
+
+  for k := suffix; k > 0; k-- {
+    equal(len(a)-k, len(b)-k)
+  }
+  return lines
+}
+
+// myers returns the operations turning a into b, deletes first where the
+// order doesn't matter, or nil if it takes more than maxDiffEdits edits.
+func myers(a, b []string) []blogpb.DiffLine_Op {
+  n, m := len(a), len(b)
+  max := n + m
+  if max > maxDiffEdits {
+    max = maxDiffEdits
+  }
+  offset := max + 1
+  v := make([]int, 2*max+3)
+  // trace keeps v before each round, to walk the path back
+  var trace [][]int
+  for d := 0; d <= max; d++ {
+    trace = append(trace, append([]int(nil), v...))
+    for k := -d; k <= d; k += 2 {
+      var x int
+      if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+        x = v[offset+k+1]
+      } else {
+        x = v[offset+k-1] + 1
+      }
+      y := x - k
+      for x < n && y < m && a[x] == b[y] {
+        x++
+        y++
+      }
+      v[offset+k] = x
+      if x >= n && y >= m {
+        return myersScript(trace, offset, n, m)
+      }
+    }
+  }
+  return nil
+}
+
+// myersScript walks back the rounds of myers from the end of both inputs.
+func myersScript(trace [][]int, offset, n, m int) []blogpb.DiffLine_Op {
+  var script []blogpb.DiffLine_Op
+  x, y := n, m
+  for d := len(trace) - 1; d >= 0; d-- {
+    v := trace[d]
+    k := x - y
+    var prevK int
+    if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+      prevK = k + 1
+    } else {
+      prevK = k - 1
+    }
+    prevX := v[offset+prevK]
+    prevY := prevX - prevK
+    for x > prevX && y > prevY {
+      script = append(script, blogpb.DiffLine_EQUAL)
+      x--
+      y--
+    }
+    if d > 0 {
+      if x == prevX {
+        script = append(script, blogpb.DiffLine_INSERT)
+      } else {
+        script = append(script, blogpb.DiffLine_DELETE)
+      }
+    }
+    x, y = prevX, prevY
+  }
+  for i, j := 0, len(script)-1; i < j; i, j = i+1, j-1 {
+    script[i], script[j] = script[j], script[i]
+  }
+  return script
+}
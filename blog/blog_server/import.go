@@ -0,0 +1,52 @@
+package main
+
+import(
+  "fmt"
+  "io"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// ImportBlogs creates the blogs of the stream through CreateBlog, so they
+// are authenticated, moderated and counted against the quota like any
+// other. The failure of a blog is sent back with it and the import goes on,
+// only errors of the stream itself end it.
+func (s *server) ImportBlogs(stream blogpb.BlogService_ImportBlogsServer) error {
+  fmt.Printf("ImportBlogs was invoked\n\n")
+  imported := 0
+  for {
+    req, err := stream.Recv()
+    if err == io.EOF {
+      fmt.Printf("Imported %v blogs\n\n", imported)
+      return nil
+    }
+    if err != nil {
+      return err
+    }
+    res := &blogpb.ImportBlogResponse{
+      Source: req.GetSource(),
+    }
+    var created *blogpb.CreateBlogResponse
+    if req.GetBlog() == nil {
+      err = status.Error(codes.InvalidArgument, "The request has no blog")
+    } else {
+      created, err = s.CreateBlog(stream.Context(), &blogpb.CreateBlogRequest{
+        Blog: req.GetBlog(),
+      })
+    }
+    if err != nil {
+      st := status.Convert(err)
+      res.Code = int32(st.Code())
+      res.Message = st.Message()
+    } else {
+      res.Blog = created.GetBlog()
+      res.QuarantineId = created.GetQuarantineId()
+      imported++
+    }
+    if err := stream.Send(res); err != nil {
+      return err
+    }
+  }
+}
@@ -0,0 +1,361 @@
+package main
+
+import(
+  "errors"
+  "flag"
+  "fmt"
+  "log"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+)
+
+var (
+  // metaBucket holds data about the database itself rather than blogs.
+  metaBucket       = []byte("Meta")
+  schemaVersionKey = []byte("schema_version")
+
+  errDryRun = errors.New("dry run")
+)
+
+// migration moves the database layout from version-1 to version. apply
+// must be idempotent, running it again on data it already migrated changes
+// nothing, and must call report once for every change it makes so that dry
+// runs can tell what would happen.
+type migration struct {
+  version     uint64
+  description string
+  apply       func(tx *bolt.Tx, report func(format string, args ...interface{})) error
+}
+
+// migrations are run in order by setupDB. Append new ones at the end and
+// never change one that has been released.
+var migrations = []migration{
+  {
+    version:     1,
+    description: "create the Blog bucket",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      if tx.Bucket([]byte("Blog")) == nil {
+        report("create bucket Blog")
+      }
+      _, err := tx.CreateBucketIfNotExists([]byte("Blog"))
+      return err
+    },
+  },
+  {
+    version:     2,
+    description: "create the Changelog bucket with the existing blogs",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      if tx.Bucket(changelogBucket) != nil {
+        return nil
+      }
+      report("create bucket Changelog")
+      if _, err := tx.CreateBucket(changelogBucket); err != nil {
+        return err
+      }
+      // followers replay the changelog from the start, so it must hold
+      // everything written before it existed
+      return forEachBlog(tx, func(blog *blogpb.Blog) error {
+        report("record blog %v in the changelog", blog.GetId())
+        return appendChange(tx, &blogpb.ChangeEntry{
+          Op:     blogpb.ChangeEntry_PUT,
+          BlogId: blog.GetId(),
+          Blog:   blog,
+        })
+      })
+    },
+  },
+  {
+    version:     3,
+    description: "create the Quarantine bucket",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      if tx.Bucket(quarantineBucket) == nil {
+        report("create bucket Quarantine")
+      }
+      _, err := tx.CreateBucketIfNotExists(quarantineBucket)
+      return err
+    },
+  },
+  {
+    version:     4,
+    description: "give the existing blogs a public ACL owned by their author",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      var blogs []*blogpb.Blog
+      err := forEachBlog(tx, func(blog *blogpb.Blog) error {
+        if blog.GetAcl() == nil {
+          blogs = append(blogs, blog)
+        }
+        return nil
+      })
+      if err != nil {
+        return err
+      }
+      // every replica runs this on its own copy, so it stays out of the
+      // changelog to keep the sequence numbers in step
+      for _, blog := range blogs {
+        report("make blog %v public and owned by %q", blog.GetId(), blog.GetAuthorId())
+        blog.Acl = &blogpb.BlogAcl{
+          Owner:  blog.GetAuthorId(),
+          Public: true,
+        }
+        if err := storeBlog(tx, blog); err != nil {
+          return err
+        }
+      }
+      return nil
+    },
+  },
+  {
+    version:     5,
+    description: "create the Likes and Views buckets",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      for _, name := range [][]byte{likesBucket, viewsBucket} {
+        if tx.Bucket(name) != nil {
+          continue
+        }
+        report("create bucket %s", name)
+        if _, err := tx.CreateBucket(name); err != nil {
+          return err
+        }
+      }
+      return nil
+    },
+  },
+  {
+    version:     6,
+    description: "create the Series and SeriesMembers buckets",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      for _, name := range [][]byte{seriesBucket, seriesMembersBucket} {
+        if tx.Bucket(name) != nil {
+          continue
+        }
+        report("create bucket %s", name)
+        if _, err := tx.CreateBucket(name); err != nil {
+          return err
+        }
+      }
+      return nil
+    },
+  },
+  {
+    version:     7,
+    description: "create the Chunks and Uploads buckets",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      for _, name := range contentBuckets {
+        if tx.Bucket(name) != nil {
+          continue
+        }
+        report("create bucket %s", name)
+        if _, err := tx.CreateBucket(name); err != nil {
+          return err
+        }
+      }
+      return nil
+    },
+  },
+  {
+    version:     8,
+    description: "count the storage used by every author",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      for _, name := range usageBuckets {
+        if tx.Bucket(name) != nil {
+          continue
+        }
+        report("create bucket %s", name)
+        if _, err := tx.CreateBucket(name); err != nil {
+          return err
+        }
+      }
+      // counting again from scratch keeps it idempotent. The counts are
+      // derived from the blogs, every replica makes its own.
+      n, err := countUsage(tx)
+      if err != nil {
+        return err
+      }
+      if n > 0 {
+        report("count the storage of %v blogs", n)
+      }
+      return nil
+    },
+  },
+  {
+    version:     9,
+    description: "move the views of the blogs to the ViewTotals bucket",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      if tx.Bucket(viewTotalsBucket) != nil {
+        return nil
+      }
+      // read the blogs first, their views are stored in them until the
+      // bucket exists
+      var blogs []*blogpb.Blog
+      err := forEachBlog(tx, func(blog *blogpb.Blog) error {
+        if blog.GetViews() > 0 {
+          blogs = append(blogs, blog)
+        }
+        return nil
+      })
+      if err != nil {
+        return err
+      }
+      report("create bucket %s", viewTotalsBucket)
+      if _, err := tx.CreateBucket(viewTotalsBucket); err != nil {
+        return err
+      }
+      // like the ACLs of version 4, every replica moves its own copy
+      for _, blog := range blogs {
+        report("move the %v views of blog %v", blog.GetViews(), blog.GetId())
+        if err := setViews(tx, blog.GetId(), blog.GetViews()); err != nil {
+          return err
+        }
+        if err := storeBlog(tx, blog); err != nil {
+          return err
+        }
+      }
+      return nil
+    },
+  },
+  {
+    version:     10,
+    description: "count the storage used by the owner of every blog",
+    apply: func(tx *bolt.Tx, report func(string, ...interface{})) error {
+      // version 8 counted the blogs for the author_id they claim
+      n, err := countUsage(tx)
+      if err != nil {
+        return err
+      }
+      if n > 0 {
+        report("count the storage of %v blogs by owner", n)
+      }
+      return nil
+    },
+  },
+}
+
+func latestSchemaVersion() uint64 {
+  return migrations[len(migrations)-1].version
+}
+
+// schemaVersion returns the version recorded in the metadata bucket, 0 for
+// databases created before versioning existed.
+func schemaVersion(tx *bolt.Tx) uint64 {
+  b := tx.Bucket(metaBucket)
+  if b == nil {
+    return 0
+  }
+  v := b.Get(schemaVersionKey)
+  if v == nil {
+    return 0
+  }
+  return btoui(v)
+}
+
+func setSchemaVersion(tx *bolt.Tx, version uint64) error {
+  b, err := tx.CreateBucketIfNotExists(metaBucket)
+  if err != nil {
+    return err
+  }
+  return b.Put(schemaVersionKey, uitob(version))
+}
+
+// migrateDB runs every migration newer than the version of db. Each one is
+// committed in its own transaction together with the new version, so an
+// interrupted upgrade resumes where it stopped. With dryRun set they all run
+// in a single transaction that is rolled back, and only the report is kept.
+// It returns the number of migrations that were (or would be) applied.
+func migrateDB(db *bolt.DB, dryRun bool, report func(format string, args ...interface{})) (int, error) {
+  var current uint64
+  if err := db.View(func(tx *bolt.Tx) error {
+    current = schemaVersion(tx)
+    return nil
+  }); err != nil {
+    return 0, err
+  }
+  if current > latestSchemaVersion() {
+    return 0, fmt.Errorf("database schema version %v is newer than the supported version %v", current, latestSchemaVersion())
+  }
+
+  var pending []migration
+  for _, m := range migrations {
+    if m.version > current {
+      pending = append(pending, m)
+    }
+  }
+  if len(pending) == 0 {
+    return 0, nil
+  }
+
+  run := func(tx *bolt.Tx, m migration) error {
+    fmt.Printf("Migrating database to version %v: %v\n", m.version, m.description)
+    start := time.Now()
+    err := m.apply(tx, func(format string, args ...interface{}) {
+      report("[v%v] "+format, append([]interface{}{m.version}, args...)...)
+    })
+    if err != nil {
+      return fmt.Errorf("migration %v (%v) failed: %v", m.version, m.description, err)
+    }
+    fmt.Printf("Migration %v done in %v\n", m.version, time.Since(start))
+    return setSchemaVersion(tx, m.version)
+  }
+
+  if dryRun {
+    err := db.Update(func(tx *bolt.Tx) error {
+      for _, m := range pending {
+        if err := run(tx, m); err != nil {
+          return err
+        }
+      }
+      return errDryRun
+    })
+    if err != errDryRun {
+      return 0, err
+    }
+    return len(pending), nil
+  }
+
+  for i, m := range pending {
+    err := db.Update(func(tx *bolt.Tx) error {
+      return run(tx, m)
+    })
+    if err != nil {
+      return i, err
+    }
+  }
+  return len(pending), nil
+}
+
+// runMigrate implements the "migrate" maintenance subcommand, which upgrades
+// the database while the server is stopped, or only reports what an upgrade
+// would change with -dry-run:
+//
+//   blog_server migrate -db database/blog.db -dry-run
+func runMigrate(args []string) {
+  fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+  path := fs.String("db", "database/blog.db", "path of the Bolt database")
+  dryRun := fs.Bool("dry-run", false, "report what would change without changing anything")
+  masterKeyFile := fs.String("master-key-file", "", "file holding the master key of an encrypted database, defaults to $"+masterKeyEnv)
+  fs.Parse(args)
+  if err := useMasterKey(*masterKeyFile); err != nil {
+    log.Fatalf("Could not load the master key: %v", err)
+  }
+
+  db, err := bolt.Open(*path, 0600, &bolt.Options{Timeout: time.Second})
+  if err != nil {
+    log.Fatalf("Could not open database: %v", err)
+  }
+  defer db.Close()
+
+  changes := 0
+  n, err := migrateDB(db, *dryRun, func(format string, args ...interface{}) {
+    changes++
+    fmt.Printf("  "+format+"\n", args...)
+  })
+  if err != nil {
+    log.Fatalf("Could not migrate database: %v", err)
+  }
+  if *dryRun {
+    fmt.Printf("Dry run: %v migrations would make %v changes, nothing was written\n", n, changes)
+    return
+  }
+  fmt.Printf("Applied %v migrations with %v changes, schema version is now %v\n", n, changes, latestSchemaVersion())
+}
@@ -0,0 +1,309 @@
+package main
+
+import(
+  "context"
+  "encoding/json"
+  "fmt"
+  "io/ioutil"
+  "regexp"
+  "strings"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// quarantineBucket holds the writes moderation put aside for review, keyed by
+// their own sequence number.
+var quarantineBucket = []byte("Quarantine")
+
+// moderationAction is what moderation decides to do with a write. Higher
+// values win when several rules match.
+type moderationAction int
+
+const (
+  actionApprove moderationAction = iota
+  actionQuarantine
+  actionReject
+)
+
+func parseModerationAction(s string) (moderationAction, error) {
+  switch s {
+  case "approve":
+    return actionApprove, nil
+  case "quarantine":
+    return actionQuarantine, nil
+  case "reject":
+    return actionReject, nil
+  }
+  return 0, fmt.Errorf("unknown action %q, must be approve, quarantine or reject", s)
+}
+
+// verdict is the outcome of moderating a blog.
+type verdict struct {
+  action  moderationAction
+  reasons []string
+}
+
+// moderator screens blogs before CreateBlog and UpdateBlog store them. Any
+// implementation can be set on the server, such as one calling an external
+// classification service. An error fails the write with codes.Unavailable.
+type moderator interface {
+  moderate(ctx context.Context, blog *blogpb.Blog) (verdict, error)
+}
+
+// rulesModerator is the built-in moderator, which matches keywords and
+// regular expressions against the fields of the blog.
+type rulesModerator struct {
+  rules []moderationRule
+}
+
+type moderationRule struct {
+  name   string
+  re     *regexp.Regexp
+  fields []string
+  action moderationAction
+}
+
+// moderationConfig is the format of the rules file:
+//
+//   {
+//     "rules": [
+//       {"name": "spam", "keywords": ["casino", "free money"], "action": "reject"},
+//       {"name": "links", "pattern": "https?://", "fields": ["content"], "action": "quarantine"}
+//     ]
+//   }
+//
+// Keywords match whole words regardless of case. fields defaults to title and
+// content, author_id can be matched as well.
+type moderationConfig struct {
+  Rules []struct {
+    Name     string   `json:"name"`
+    Keywords []string `json:"keywords"`
+    Pattern  string   `json:"pattern"`
+    Fields   []string `json:"fields"`
+    Action   string   `json:"action"`
+  } `json:"rules"`
+}
+
+func loadRulesModerator(path string) (*rulesModerator, error) {
+  data, err := ioutil.ReadFile(path)
+  if err != nil {
+    return nil, err
+  }
+  var config moderationConfig
+  if err := json.Unmarshal(data, &config); err != nil {
+    return nil, fmt.Errorf("%v: %v", path, err)
+  }
+
+  m := &rulesModerator{}
+  for i, r := range config.Rules {
+    name := r.Name
+    if name == "" {
+      name = fmt.Sprintf("rule %v", i+1)
+    }
+    action, err := parseModerationAction(r.Action)
+    if err != nil {
+      return nil, fmt.Errorf("%v: %v", name, err)
+    }
+    var patterns []string
+    for _, kw := range r.Keywords {
+      patterns = append(patterns, `\b`+regexp.QuoteMeta(kw)+`\b`)
+    }
+    if len(patterns) > 0 {
+      patterns = []string{"(?i:" + strings.Join(patterns, "|") + ")"}
+    }
+    if r.Pattern != "" {
+      patterns = append(patterns, "(?:"+r.Pattern+")")
+    }
+    if len(patterns) == 0 {
+      return nil, fmt.Errorf("%v: a rule needs keywords or a pattern", name)
+    }
+    re, err := regexp.Compile(strings.Join(patterns, "|"))
+    if err != nil {
+      return nil, fmt.Errorf("%v: %v", name, err)
+    }
+    fields := r.Fields
+    if len(fields) == 0 {
+      fields = []string{"title", "content"}
+    }
+    for _, f := range fields {
+      if blogField(&blogpb.Blog{}, f) == nil {
+        return nil, fmt.Errorf("%v: unknown field %q", name, f)
+      }
+    }
+    m.rules = append(m.rules, moderationRule{
+      name:   name,
+      re:     re,
+      fields: fields,
+      action: action,
+    })
+  }
+  return m, nil
+}
+
+// blogField returns the value of a field of blog by name, nil if there is no
+// such field.
+func blogField(blog *blogpb.Blog, name string) *string {
+  switch name {
+  case "title":
+    return &blog.Title
+  case "content":
+    return &blog.Content
+  case "author_id":
+    return &blog.AuthorId
+  }
+  return nil
+}
+
+func (m *rulesModerator) moderate(ctx context.Context, blog *blogpb.Blog) (verdict, error) {
+  var v verdict
+  for _, r := range m.rules {
+    if r.action == actionApprove {
+      continue
+    }
+    for _, f := range r.fields {
+      if match := r.re.FindString(*blogField(blog, f)); match != "" {
+        v.reasons = append(v.reasons, fmt.Sprintf("%v: %q in %v", r.name, match, f))
+        if r.action > v.action {
+          v.action = r.action
+        }
+        break
+      }
+    }
+  }
+  return v, nil
+}
+
+// moderate runs the moderator of the server on blog, written by caller. It
+// returns an error for rejected blogs and, for quarantined ones, the id they
+// were stored under.
+func (s *server) moderate(ctx context.Context, blog *blogpb.Blog, caller string, update bool) (uint64, error) {
+  if s.moderator == nil {
+    return 0, nil
+  }
+  v, err := s.moderator.moderate(ctx, blog)
+  if err != nil {
+    return 0, status.Error(codes.Unavailable, fmt.Sprintf("Could not moderate blog: %v", err))
+  }
+  switch v.action {
+  case actionReject:
+    fmt.Printf("Blog rejected by moderation: %v\n\n", strings.Join(v.reasons, "; "))
+    return 0, status.Error(codes.PermissionDenied, fmt.Sprintf("Blog rejected by moderation: %v", strings.Join(v.reasons, "; ")))
+  case actionQuarantine:
+    var id uint64
+    err := s.update(func(tx *bolt.Tx) error {
+      var err error
+      id, err = putQuarantined(tx, &blogpb.QuarantinedBlog{
+        Blog:      blog,
+        Update:    update,
+        Reasons:   v.reasons,
+        Timestamp: time.Now().UnixNano(),
+        Caller:    caller,
+      })
+      return err
+    })
+    if err != nil {
+      return 0, status.Error(codes.Internal, fmt.Sprintf("Could not quarantine blog: %v", err))
+    }
+    fmt.Printf("Blog quarantined as %v: %v\n\n", id, strings.Join(v.reasons, "; "))
+    return id, nil
+  }
+  return 0, nil
+}
+
+func putQuarantined(tx *bolt.Tx, q *blogpb.QuarantinedBlog) (uint64, error) {
+  b := tx.Bucket(quarantineBucket)
+  id, err := b.NextSequence()
+  if err != nil {
+    return 0, err
+  }
+  q.Id = id
+  return id, putMessage(tx, quarantineBucket, uitob(id), q)
+}
+
+func (s *server) ListQuarantine(ctx context.Context, req *blogpb.ListQuarantineRequest) (*blogpb.ListQuarantineResponse, error) {
+  fmt.Printf("ListQuarantine was invoked\n\n")
+  if _, err := s.admin(ctx); err != nil {
+    return nil, err
+  }
+
+  res := &blogpb.ListQuarantineResponse{}
+  err := s.view(func(tx *bolt.Tx) error {
+    return tx.Bucket(quarantineBucket).ForEach(func(k, v []byte) error {
+      q := &blogpb.QuarantinedBlog{}
+      if err := unmarshalValue(tx, quarantineBucket, k, v, q); err != nil {
+        return fmt.Errorf("quarantined blog %v: %v", btoui(k), err)
+      }
+      res.Blogs = append(res.Blogs, q)
+      return nil
+    })
+  })
+  if err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read the quarantine: %v", err))
+  }
+  return res, nil
+}
+
+// ResolveQuarantine stores or discards a quarantined write. Approved writes
+// skip moderation but are otherwise handled like CreateBlog and UpdateBlog,
+// with the rights their author has now rather than when they were sent.
+func (s *server) ResolveQuarantine(ctx context.Context, req *blogpb.ResolveQuarantineRequest) (*blogpb.ResolveQuarantineResponse, error) {
+  fmt.Printf("ResolveQuarantine was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  reviewer, err := s.admin(ctx)
+  if err != nil {
+    return nil, err
+  }
+  id := req.GetQuarantineId()
+
+  // take the write out first, so it can't be approved twice
+  q := &blogpb.QuarantinedBlog{}
+  err = s.update(func(tx *bolt.Tx) error {
+    b := tx.Bucket(quarantineBucket)
+    qBytes := b.Get(uitob(id))
+    if qBytes == nil {
+      return status.Error(codes.NotFound, fmt.Sprintf("Could not find quarantined blog with id %v", id))
+    }
+    if err := unmarshalValue(tx, quarantineBucket, uitob(id), qBytes, q); err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Unmarshal error: %v", err))
+    }
+    return b.Delete(uitob(id))
+  })
+  if err != nil {
+    return nil, err
+  }
+  if !req.GetApprove() {
+    fmt.Printf("Quarantined blog %v discarded by %q\n\n", id, reviewer)
+    return &blogpb.ResolveQuarantineResponse{}, nil
+  }
+
+  cmd := &blogpb.BlogCommand{
+    Op:     blogpb.BlogCommand_CREATE,
+    Blog:   q.GetBlog(),
+    Caller: q.GetCaller(),
+  }
+  if q.GetUpdate() {
+    cmd.Op = blogpb.BlogCommand_UPDATE
+  }
+  blog, err := s.execute(ctx, cmd)
+  if err != nil {
+    if status.Code(err) == codes.NotFound {
+      // the blog it updates was deleted in the meantime
+      return nil, err
+    }
+    // put it back so it can be resolved again
+    s.update(func(tx *bolt.Tx) error {
+      return putMessage(tx, quarantineBucket, uitob(id), q)
+    })
+    return nil, err
+  }
+  fmt.Printf("Quarantined blog %v approved by %q as blog %v\n\n", id, reviewer, blog.GetId())
+  return &blogpb.ResolveQuarantineResponse{
+    Blog: localize(blog, nil),
+  }, nil
+}
@@ -0,0 +1,389 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "strings"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "github.com/golang/protobuf/proto"
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+var (
+  // usageBucket holds the StorageUsage of every author, keyed by
+  // usageKey.
+  usageBucket = []byte("Usage")
+  // blogUsageBucket holds what each blog counts for, its owner and bytes,
+  // keyed by blog id, so that its old size can be taken back when it
+  // changes.
+  blogUsageBucket = []byte("BlogUsage")
+  // usageBuckets are kept by storeBlog and dropBlog for the blogs of their
+  // own database file.
+  usageBuckets = [][]byte{usageBucket, blogUsageBucket}
+
+  // usageTotalKey, in the metadata bucket, is the StorageUsage of every
+  // blog of the database file.
+  usageTotalKey = []byte("usage_total")
+)
+
+// usageOwner returns the author blog counts for: the owner of its ACL, who
+// created it when tokens are required, and its author_id only for blogs
+// without an owner. The author_id is whatever the client sent, counting
+// blogs for it would let anyone write past their quota.
+func usageOwner(blog *blogpb.Blog) string {
+  if owner := blog.GetAcl().GetOwner(); owner != "" {
+    return owner
+  }
+  return blog.GetAuthorId()
+}
+
+// blogUsage returns what blog counts for in the usage of its owner.
+func blogUsage(blog *blogpb.Blog) *blogpb.StorageUsage {
+  return &blogpb.StorageUsage{
+    AuthorId: usageOwner(blog),
+    Posts:    1,
+    Bytes:    uint64(proto.Size(blog)-len(blog.GetContent())) + contentSize(blog),
+  }
+}
+
+func getUsage(tx *bolt.Tx, name, k []byte) (*blogpb.StorageUsage, error) {
+  usage := &blogpb.StorageUsage{}
+  b := tx.Bucket(name)
+  if b == nil {
+    return usage, nil
+  }
+  if v := b.Get(k); v != nil {
+    if err := proto.Unmarshal(v, usage); err != nil {
+      return nil, err
+    }
+  }
+  return usage, nil
+}
+
+func putUsage(tx *bolt.Tx, name, k []byte, usage *blogpb.StorageUsage) error {
+  v, err := proto.Marshal(usage)
+  if err != nil {
+    return err
+  }
+  return tx.Bucket(name).Put(k, v)
+}
+
+// usageKey is the key of author in usageBucket. Bolt keys can't be empty,
+// and blogs without an author count for the empty one.
+func usageKey(author string) []byte {
+  return []byte("author:" + author)
+}
+
+// authorUsage returns the usage of author in the database file of tx.
+func authorUsage(tx *bolt.Tx, author string) (*blogpb.StorageUsage, error) {
+  usage, err := getUsage(tx, usageBucket, usageKey(author))
+  if err != nil {
+    return nil, err
+  }
+  usage.AuthorId = author
+  return usage, nil
+}
+
+// totalUsage returns the usage of every blog of the database file of tx.
+func totalUsage(tx *bolt.Tx) (*blogpb.StorageUsage, error) {
+  return getUsage(tx, metaBucket, usageTotalKey)
+}
+
+// addUsage adds posts and bytes, negative to take them back, to the usage
+// of author and to the total.
+func addUsage(tx *bolt.Tx, author string, posts, bytes int64) error {
+  for _, k := range []struct {
+    name, key []byte
+  }{
+    {usageBucket, usageKey(author)},
+    {metaBucket, usageTotalKey},
+  } {
+    usage, err := getUsage(tx, k.name, k.key)
+    if err != nil {
+      return err
+    }
+    usage.Posts = uint64(int64(usage.GetPosts()) + posts)
+    usage.Bytes = uint64(int64(usage.GetBytes()) + bytes)
+    if err := putUsage(tx, k.name, k.key, usage); err != nil {
+      return err
+    }
+  }
+  // authors without blogs left are forgotten
+  if usage, err := authorUsage(tx, author); err != nil || usage.GetPosts() != 0 {
+    return err
+  }
+  return tx.Bucket(usageBucket).Delete(usageKey(author))
+}
+
+// trackUsage counts blog, about to be stored, in place of what it counted
+// for before. It does nothing until migration 8 created the buckets, since
+// that migration counts every blog.
+func trackUsage(tx *bolt.Tx, blog *blogpb.Blog) error {
+  if tx.Bucket(blogUsageBucket) == nil {
+    return nil
+  }
+  if err := untrackUsage(tx, blog.GetId()); err != nil {
+    return err
+  }
+  usage := blogUsage(blog)
+  if err := addUsage(tx, usage.GetAuthorId(), 1, int64(usage.GetBytes())); err != nil {
+    return err
+  }
+  return putUsage(tx, blogUsageBucket, uitob(blog.GetId()), usage)
+}
+
+// untrackUsage takes back what blog id counted for.
+func untrackUsage(tx *bolt.Tx, id uint64) error {
+  if tx.Bucket(blogUsageBucket) == nil {
+    return nil
+  }
+  old, err := getUsage(tx, blogUsageBucket, uitob(id))
+  if err != nil || old.GetPosts() == 0 {
+    return err
+  }
+  if err := addUsage(tx, old.GetAuthorId(), -1, -int64(old.GetBytes())); err != nil {
+    return err
+  }
+  return tx.Bucket(blogUsageBucket).Delete(uitob(id))
+}
+
+// resetUsage forgets every count of the database file.
+func resetUsage(tx *bolt.Tx) error {
+  for _, name := range usageBuckets {
+    if err := emptyBucket(tx, name); err != nil {
+      return err
+    }
+  }
+  return tx.Bucket(metaBucket).Delete(usageTotalKey)
+}
+
+// countUsage counts every blog of the database file of tx from scratch and
+// returns how many there are.
+func countUsage(tx *bolt.Tx) (int, error) {
+  if err := resetUsage(tx); err != nil {
+    return 0, err
+  }
+  n := 0
+  err := forEachBlog(tx, func(blog *blogpb.Blog) error {
+    n++
+    return trackUsage(tx, blog)
+  })
+  return n, err
+}
+
+// checkQuota returns a RESOURCE_EXHAUSTED error if storing blog in place of
+// current, nil for creates, takes the usage past the quota of cmd. Only the
+// limits the write gets closer to are checked, so that authors over a
+// lowered quota can still shrink or delete their blogs.
+func checkQuota(tx *bolt.Tx, cmd *blogpb.BlogCommand, current, blog *blogpb.Blog) error {
+  quota := cmd.GetQuota()
+  if quota == nil {
+    return nil
+  }
+  before := &blogpb.StorageUsage{}
+  if current != nil {
+    before = blogUsage(current)
+  }
+  after := blogUsage(blog)
+  author := after.GetAuthorId()
+  moved := current == nil || before.GetAuthorId() != author
+
+  used, err := authorUsage(tx, author)
+  if err != nil {
+    return err
+  }
+  total, err := totalUsage(tx)
+  if err != nil {
+    return err
+  }
+  if elsewhere := cmd.GetAuthorUsageElsewhere(); elsewhere.GetAuthorId() == author {
+    used.Posts += elsewhere.GetPosts()
+    used.Bytes += elsewhere.GetBytes()
+  }
+  total.Bytes += cmd.GetTotalUsageElsewhere().GetBytes()
+  posts, bytes := used.GetPosts()+1, used.GetBytes()+after.GetBytes()
+  if !moved {
+    posts--
+    bytes -= before.GetBytes()
+  }
+  totalBytes := total.GetBytes() + after.GetBytes() - before.GetBytes()
+
+  var violations []*errdetails.QuotaFailure_Violation
+  if max := quota.GetMaxPostsPerAuthor(); max > 0 && moved && posts > max {
+    violations = append(violations, &errdetails.QuotaFailure_Violation{
+      Subject:     "author:" + author,
+      Description: fmt.Sprintf("Author %q can't have more than %v blogs", author, max),
+    })
+  }
+  grows := moved || after.GetBytes() > before.GetBytes()
+  if max := quota.GetMaxBytesPerAuthor(); max > 0 && grows && bytes > max {
+    violations = append(violations, &errdetails.QuotaFailure_Violation{
+      Subject:     "author:" + author,
+      Description: fmt.Sprintf("The blogs of author %q would take %v bytes, more than the %v bytes allowed", author, bytes, max),
+    })
+  }
+  if max := quota.GetMaxTotalBytes(); max > 0 && after.GetBytes() > before.GetBytes() && totalBytes > max {
+    violations = append(violations, &errdetails.QuotaFailure_Violation{
+      Subject:     "global",
+      Description: fmt.Sprintf("The blogs would take %v bytes, more than the %v bytes the server allows", totalBytes, max),
+    })
+  }
+  if len(violations) == 0 {
+    return nil
+  }
+  return quotaError(violations)
+}
+
+// checkUploadQuota is checkQuota for an upload of content to blog that
+// reached size bytes, counted as if it replaced the current content.
+func checkUploadQuota(tx *bolt.Tx, cmd *blogpb.BlogCommand, blog *blogpb.Blog, size uint64) error {
+  if cmd.GetQuota() == nil {
+    return nil
+  }
+  uploaded := proto.Clone(blog).(*blogpb.Blog)
+  uploaded.Content = ""
+  uploaded.ContentTruncated = true
+  uploaded.ContentSize = size
+  return checkQuota(tx, cmd, blog, uploaded)
+}
+
+func quotaError(violations []*errdetails.QuotaFailure_Violation) error {
+  var msgs []string
+  for _, v := range violations {
+    msgs = append(msgs, v.GetDescription())
+  }
+  fmt.Printf("Quota exceeded: %v\n\n", strings.Join(msgs, "; "))
+  st := status.New(codes.ResourceExhausted, "Quota exceeded: "+strings.Join(msgs, "; "))
+  withDetails, err := st.WithDetails(&errdetails.QuotaFailure{Violations: violations})
+  if err != nil {
+    return st.Err()
+  }
+  return withDetails.Err()
+}
+
+// isQuotaCommand tells whether cmd is one of the writes checked against the
+// quota, the ones an author makes to the content of their blogs and the
+// changes of owner.
+func isQuotaCommand(cmd *blogpb.BlogCommand) bool {
+  switch cmd.GetOp() {
+  case blogpb.BlogCommand_CREATE, blogpb.BlogCommand_UPDATE, blogpb.BlogCommand_UPSERT_TRANSLATION, blogpb.BlogCommand_APPEND_CONTENT, blogpb.BlogCommand_COMMIT_CONTENT, blogpb.BlogCommand_SET_ACL:
+    return true
+  }
+  return false
+}
+
+// limit attaches the quota of the server to cmd, so that every node of a
+// cluster checks the write against the same limits.
+func (s *server) limit(cmd *blogpb.BlogCommand) {
+  if s.quota != nil && isQuotaCommand(cmd) {
+    cmd.Quota = s.quota
+  }
+}
+
+// usageElsewhere sets on cmd, run on the shard of blog id, the usage held
+// by the other shards. The shards are read one after the other without a
+// lock, so concurrent writes of an author to several shards can together go
+// a little past the quota.
+func (s *server) usageElsewhere(cmd *blogpb.BlogCommand, id uint64) error {
+  if cmd.GetQuota() == nil {
+    return nil
+  }
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  i := shardIndex(id, len(s.shards.dbs))
+  // only a new blog comes with the ACL it is stored with, the others keep
+  // the one of the stored blog
+  author := usageOwner(cmd.GetBlog())
+  if cmd.GetOp() != blogpb.BlogCommand_CREATE {
+    err := s.shards.dbs[i].View(func(tx *bolt.Tx) error {
+      blog, err := getBlog(tx, id)
+      author = usageOwner(blog)
+      return err
+    })
+    if err != nil {
+      return err
+    }
+  }
+  // SET_ACL counts the blog for its new owner
+  if owner := cmd.GetAcl().GetOwner(); owner != "" {
+    author = owner
+  }
+  used := &blogpb.StorageUsage{AuthorId: author}
+  total := &blogpb.StorageUsage{}
+  for j, db := range s.shards.dbs {
+    if j == i {
+      continue
+    }
+    err := db.View(func(tx *bolt.Tx) error {
+      u, err := authorUsage(tx, author)
+      if err != nil {
+        return err
+      }
+      t, err := totalUsage(tx)
+      if err != nil {
+        return err
+      }
+      addTo(used, u)
+      addTo(total, t)
+      return nil
+    })
+    if err != nil {
+      return err
+    }
+  }
+  cmd.AuthorUsageElsewhere = used
+  cmd.TotalUsageElsewhere = total
+  return nil
+}
+
+func addTo(usage, other *blogpb.StorageUsage) {
+  usage.Posts += other.GetPosts()
+  usage.Bytes += other.GetBytes()
+}
+
+func (s *server) GetUsage(ctx context.Context, req *blogpb.GetUsageRequest) (*blogpb.GetUsageResponse, error) {
+  fmt.Printf("GetUsage was invoked with: %v\n\n", req)
+  // once authentication is on, authors only see their own usage
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if s.tokens != nil && req.GetAuthorId() != "" && req.GetAuthorId() != caller && !s.admins[caller] {
+    return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("%v can't read the usage of %v", caller, req.GetAuthorId()))
+  }
+  res := &blogpb.GetUsageResponse{
+    Total: &blogpb.StorageUsage{},
+    Quota: s.quota,
+  }
+  author := req.GetAuthorId()
+  if author != "" {
+    res.Author = &blogpb.StorageUsage{AuthorId: author}
+  }
+  err = s.viewShards(func(tx *bolt.Tx) error {
+    t, err := totalUsage(tx)
+    if err != nil {
+      return err
+    }
+    addTo(res.Total, t)
+    if author == "" {
+      return nil
+    }
+    u, err := authorUsage(tx, author)
+    if err != nil {
+      return err
+    }
+    addTo(res.Author, u)
+    return nil
+  })
+  if err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read the usage: %v", err))
+  }
+  if res.Quota == nil {
+    res.Quota = &blogpb.StorageQuota{}
+  }
+  return res, nil
+}
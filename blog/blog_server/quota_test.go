@@ -0,0 +1,97 @@
+package main
+
+import(
+  "context"
+  "testing"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+func TestQuotaCountsForTheCaller(t *testing.T) {
+  s := newTestServer(t, t.TempDir())
+  defer s.Close()
+  s.tokens = map[string]string{"luis-token": "luis", "ana-token": "ana"}
+  s.quota = &blogpb.StorageQuota{MaxPostsPerAuthor: 1}
+  luis := withToken(context.Background(), "luis-token")
+
+  createTestBlog(t, luis, s, "First")
+  // claiming to be someone else doesn't get around the quota
+  _, err := s.CreateBlog(luis, &blogpb.CreateBlogRequest{
+    Blog: &blogpb.Blog{AuthorId: "someone-else", Title: "Second"},
+  })
+  if status.Code(err) != codes.ResourceExhausted {
+    t.Fatalf("CreateBlog over the quota: got %v, want ResourceExhausted", err)
+  }
+  // nor does it take up the quota of whoever is named
+  _, err = s.CreateBlog(withToken(context.Background(), "ana-token"), &blogpb.CreateBlogRequest{
+    Blog: &blogpb.Blog{AuthorId: "luis", Title: "By ana"},
+  })
+  if err != nil {
+    t.Fatalf("CreateBlog by another caller: %v", err)
+  }
+
+  res, err := s.GetUsage(luis, &blogpb.GetUsageRequest{AuthorId: "luis"})
+  if err != nil {
+    t.Fatalf("GetUsage: %v", err)
+  }
+  if res.GetAuthor().GetPosts() != 1 || res.GetTotal().GetPosts() != 2 {
+    t.Errorf("got %v posts for luis and %v in total, want 1 and 2", res.GetAuthor().GetPosts(), res.GetTotal().GetPosts())
+  }
+}
+
+func TestQuotaOnChangeOfOwner(t *testing.T) {
+  s := newTestServer(t, t.TempDir())
+  defer s.Close()
+  s.tokens = map[string]string{"luis-token": "luis", "ana-token": "ana"}
+  s.quota = &blogpb.StorageQuota{MaxPostsPerAuthor: 1}
+  luis := withToken(context.Background(), "luis-token")
+  ana := withToken(context.Background(), "ana-token")
+
+  createTestBlog(t, ana, s, "By ana")
+  given := createTestBlog(t, luis, s, "By luis")
+  _, err := s.SetBlogAcl(luis, &blogpb.SetBlogAclRequest{
+    BlogId: given.GetId(),
+    Acl:    &blogpb.BlogAcl{Owner: "ana", Public: true},
+  })
+  if status.Code(err) != codes.ResourceExhausted {
+    t.Fatalf("SetBlogAcl giving ana a blog over her quota: got %v, want ResourceExhausted", err)
+  }
+  // other changes of the ACL go through
+  _, err = s.SetBlogAcl(luis, &blogpb.SetBlogAclRequest{
+    BlogId: given.GetId(),
+    Acl:    &blogpb.BlogAcl{Public: true, Viewers: []string{"ana"}},
+  })
+  if err != nil {
+    t.Fatalf("SetBlogAcl keeping the owner: %v", err)
+  }
+}
+
+func TestGetUsageOfOthers(t *testing.T) {
+  s := newTestServer(t, t.TempDir())
+  defer s.Close()
+  s.tokens = map[string]string{"luis-token": "luis"}
+  s.addAdmins(map[string]string{"admin-token": "root"})
+  ctx := context.Background()
+
+  for _, tc := range []struct {
+    token, author string
+    want          codes.Code
+  }{
+    {"", "luis", codes.Unauthenticated},
+    {"luis-token", "luis", codes.OK},
+    {"luis-token", "", codes.OK},
+    {"luis-token", "ana", codes.PermissionDenied},
+    {"admin-token", "ana", codes.OK},
+  } {
+    callCtx := ctx
+    if tc.token != "" {
+      callCtx = withToken(ctx, tc.token)
+    }
+    _, err := s.GetUsage(callCtx, &blogpb.GetUsageRequest{AuthorId: tc.author})
+    if status.Code(err) != tc.want {
+      t.Errorf("GetUsage of %q with %q: got %v, want %v", tc.author, tc.token, err, tc.want)
+    }
+  }
+}
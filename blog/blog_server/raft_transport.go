@@ -0,0 +1,528 @@
+package main
+
+import(
+  "context"
+  "io"
+  "sync"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/hashicorp/raft"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/backoff"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+const (
+  raftRPCTimeout    = 10 * time.Second
+  snapshotChunkSize = 64 << 10
+)
+
+var raftDialBackoff = backoff.Config{
+  BaseDelay:  100 * time.Millisecond,
+  Multiplier: 1.6,
+  Jitter:     0.2,
+  MaxDelay:   time.Second,
+}
+
+// grpcTransport carries the Raft RPCs between nodes over gRPC, using the
+// RaftTransport service registered on the same server as BlogService. The
+// address of a node is therefore its gRPC address.
+type grpcTransport struct {
+  localAddr raft.ServerAddress
+  // token authenticates the node to the others, one of their -raft-tokens
+  token     string
+  consumer  chan raft.RPC
+
+  heartbeatMu sync.Mutex
+  heartbeatFn func(raft.RPC)
+
+  connsMu   sync.Mutex
+  conns     map[raft.ServerAddress]*grpc.ClientConn
+  shutdown  chan struct{}
+  closeOnce sync.Once
+}
+
+func newGrpcTransport(localAddr, token string) *grpcTransport {
+  return &grpcTransport{
+    localAddr: raft.ServerAddress(localAddr),
+    token:     token,
+    consumer:  make(chan raft.RPC),
+    conns:     make(map[raft.ServerAddress]*grpc.ClientConn),
+    shutdown:  make(chan struct{}),
+  }
+}
+
+func (t *grpcTransport) Consumer() <-chan raft.RPC {
+  return t.consumer
+}
+
+func (t *grpcTransport) LocalAddr() raft.ServerAddress {
+  return t.localAddr
+}
+
+// AppendEntriesPipeline is not supported, Raft falls back to sending
+// AppendEntries one at a time.
+func (t *grpcTransport) AppendEntriesPipeline(id raft.ServerID, target raft.ServerAddress) (raft.AppendPipeline, error) {
+  return nil, raft.ErrPipelineReplicationNotSupported
+}
+
+func (t *grpcTransport) AppendEntries(id raft.ServerID, target raft.ServerAddress, args *raft.AppendEntriesRequest, resp *raft.AppendEntriesResponse) error {
+  c, err := t.client(target)
+  if err != nil {
+    return err
+  }
+  ctx, cancel := t.context()
+  defer cancel()
+  res, err := c.AppendEntries(ctx, encodeAppendEntriesRequest(args))
+  if err != nil {
+    return err
+  }
+  *resp = *decodeAppendEntriesResponse(res)
+  return nil
+}
+
+func (t *grpcTransport) RequestVote(id raft.ServerID, target raft.ServerAddress, args *raft.RequestVoteRequest, resp *raft.RequestVoteResponse) error {
+  c, err := t.client(target)
+  if err != nil {
+    return err
+  }
+  ctx, cancel := t.context()
+  defer cancel()
+  res, err := c.RequestVote(ctx, &blogpb.RaftRequestVoteRequest{
+    ProtocolVersion:    int64(args.ProtocolVersion),
+    Id:                 args.ID,
+    Addr:               args.Addr,
+    Term:               args.Term,
+    Candidate:          args.Candidate,
+    LastLogIndex:       args.LastLogIndex,
+    LastLogTerm:        args.LastLogTerm,
+    LeadershipTransfer: args.LeadershipTransfer,
+  })
+  if err != nil {
+    return err
+  }
+  *resp = raft.RequestVoteResponse{
+    RPCHeader: rpcHeader(res.GetProtocolVersion(), res.GetId(), res.GetAddr()),
+    Term:      res.GetTerm(),
+    Peers:     res.GetPeers(),
+    Granted:   res.GetGranted(),
+  }
+  return nil
+}
+
+// InstallSnapshot streams the snapshot in chunks, the first message also
+// carrying the request.
+func (t *grpcTransport) InstallSnapshot(id raft.ServerID, target raft.ServerAddress, args *raft.InstallSnapshotRequest, resp *raft.InstallSnapshotResponse, data io.Reader) error {
+  c, err := t.client(target)
+  if err != nil {
+    return err
+  }
+  stream, err := c.InstallSnapshot(t.withToken(context.Background()))
+  if err != nil {
+    return err
+  }
+  msg := &blogpb.RaftInstallSnapshotRequest{
+    ProtocolVersion:    int64(args.ProtocolVersion),
+    Id:                 args.ID,
+    Addr:               args.Addr,
+    SnapshotVersion:    int64(args.SnapshotVersion),
+    Term:               args.Term,
+    Leader:             args.Leader,
+    LastLogIndex:       args.LastLogIndex,
+    LastLogTerm:        args.LastLogTerm,
+    Peers:              args.Peers,
+    Configuration:      args.Configuration,
+    ConfigurationIndex: args.ConfigurationIndex,
+    Size:               args.Size,
+  }
+  buf := make([]byte, snapshotChunkSize)
+  sent := false
+  for {
+    n, err := data.Read(buf)
+    if n > 0 {
+      msg.Data = buf[:n]
+      if err := stream.Send(msg); err != nil {
+        return err
+      }
+      msg = &blogpb.RaftInstallSnapshotRequest{}
+      sent = true
+    }
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      stream.CloseSend()
+      return err
+    }
+  }
+  if !sent {
+    // empty snapshot, the request still has to go through
+    if err := stream.Send(msg); err != nil {
+      return err
+    }
+  }
+  res, err := stream.CloseAndRecv()
+  if err != nil {
+    return err
+  }
+  *resp = raft.InstallSnapshotResponse{
+    RPCHeader: rpcHeader(res.GetProtocolVersion(), res.GetId(), res.GetAddr()),
+    Term:      res.GetTerm(),
+    Success:   res.GetSuccess(),
+  }
+  return nil
+}
+
+func (t *grpcTransport) EncodePeer(id raft.ServerID, addr raft.ServerAddress) []byte {
+  return []byte(addr)
+}
+
+func (t *grpcTransport) DecodePeer(buf []byte) raft.ServerAddress {
+  return raft.ServerAddress(buf)
+}
+
+func (t *grpcTransport) SetHeartbeatHandler(cb func(rpc raft.RPC)) {
+  t.heartbeatMu.Lock()
+  defer t.heartbeatMu.Unlock()
+  t.heartbeatFn = cb
+}
+
+func (t *grpcTransport) TimeoutNow(id raft.ServerID, target raft.ServerAddress, args *raft.TimeoutNowRequest, resp *raft.TimeoutNowResponse) error {
+  c, err := t.client(target)
+  if err != nil {
+    return err
+  }
+  ctx, cancel := t.context()
+  defer cancel()
+  res, err := c.TimeoutNow(ctx, &blogpb.RaftTimeoutNowRequest{
+    ProtocolVersion: int64(args.ProtocolVersion),
+    Id:              args.ID,
+    Addr:            args.Addr,
+  })
+  if err != nil {
+    return err
+  }
+  *resp = raft.TimeoutNowResponse{
+    RPCHeader: rpcHeader(res.GetProtocolVersion(), res.GetId(), res.GetAddr()),
+  }
+  return nil
+}
+
+// Close stops accepting RPCs and closes the connections to the other nodes.
+func (t *grpcTransport) Close() error {
+  t.closeOnce.Do(func() {
+    close(t.shutdown)
+  })
+  t.connsMu.Lock()
+  defer t.connsMu.Unlock()
+  for addr, cc := range t.conns {
+    cc.Close()
+    delete(t.conns, addr)
+  }
+  return nil
+}
+
+func (t *grpcTransport) client(target raft.ServerAddress) (blogpb.RaftTransportClient, error) {
+  t.connsMu.Lock()
+  defer t.connsMu.Unlock()
+  select {
+  case <-t.shutdown:
+    return nil, raft.ErrTransportShutdown
+  default:
+  }
+  cc, ok := t.conns[target]
+  if !ok {
+    var err error
+    // retry quickly, a restarted node must be reached before it starts an
+    // election
+    cc, err = grpc.Dial(string(target), grpc.WithInsecure(), grpc.WithConnectParams(grpc.ConnectParams{
+      Backoff:           raftDialBackoff,
+      MinConnectTimeout: raftDialBackoff.MaxDelay,
+    }))
+    if err != nil {
+      return nil, err
+    }
+    t.conns[target] = cc
+  }
+  return blogpb.NewRaftTransportClient(cc), nil
+}
+
+// context returns the context of a call to another node, bounded by
+// raftRPCTimeout.
+func (t *grpcTransport) context() (context.Context, context.CancelFunc) {
+  return context.WithTimeout(t.withToken(context.Background()), raftRPCTimeout)
+}
+
+// withToken returns ctx carrying the token of the node, if set.
+func (t *grpcTransport) withToken(ctx context.Context) context.Context {
+  if t.token == "" {
+    return ctx
+  }
+  return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+t.token)
+}
+
+// dispatch hands an RPC received from another node to Raft and waits for
+// its response.
+func (t *grpcTransport) dispatch(ctx context.Context, command interface{}, data io.Reader) (interface{}, error) {
+  respCh := make(chan raft.RPCResponse, 1)
+  rpc := raft.RPC{
+    Command:  command,
+    Reader:   data,
+    RespChan: respCh,
+  }
+
+  // heartbeats skip the queue so they aren't delayed by disk IO
+  if req, ok := command.(*raft.AppendEntriesRequest); ok && isHeartbeat(req) {
+    t.heartbeatMu.Lock()
+    fn := t.heartbeatFn
+    t.heartbeatMu.Unlock()
+    if fn != nil {
+      fn(rpc)
+      return t.wait(ctx, respCh)
+    }
+  }
+
+  select {
+  case t.consumer <- rpc:
+  case <-ctx.Done():
+    return nil, status.FromContextError(ctx.Err()).Err()
+  case <-t.shutdown:
+    return nil, status.Error(codes.Unavailable, raft.ErrTransportShutdown.Error())
+  }
+  return t.wait(ctx, respCh)
+}
+
+func (t *grpcTransport) wait(ctx context.Context, respCh chan raft.RPCResponse) (interface{}, error) {
+  select {
+  case resp := <-respCh:
+    if resp.Error != nil {
+      return nil, status.Error(codes.Unknown, resp.Error.Error())
+    }
+    return resp.Response, nil
+  case <-ctx.Done():
+    return nil, status.FromContextError(ctx.Err()).Err()
+  case <-t.shutdown:
+    return nil, status.Error(codes.Unavailable, raft.ErrTransportShutdown.Error())
+  }
+}
+
+func isHeartbeat(req *raft.AppendEntriesRequest) bool {
+  return req.Term != 0 && req.Leader != nil &&
+    req.PrevLogEntry == 0 && req.PrevLogTerm == 0 &&
+    len(req.Entries) == 0 && req.LeaderCommitIndex == 0
+}
+
+// raftTransportServer is the receiving side of grpcTransport. peer checks
+// that the calls come from a node of the cluster.
+type raftTransportServer struct {
+  t    *grpcTransport
+  peer func(ctx context.Context) error
+}
+
+func (s *raftTransportServer) AppendEntries(ctx context.Context, req *blogpb.RaftAppendEntriesRequest) (*blogpb.RaftAppendEntriesResponse, error) {
+  if err := s.peer(ctx); err != nil {
+    return nil, err
+  }
+  resp, err := s.t.dispatch(ctx, decodeAppendEntriesRequest(req), nil)
+  if err != nil {
+    return nil, err
+  }
+  return encodeAppendEntriesResponse(resp.(*raft.AppendEntriesResponse)), nil
+}
+
+func (s *raftTransportServer) RequestVote(ctx context.Context, req *blogpb.RaftRequestVoteRequest) (*blogpb.RaftRequestVoteResponse, error) {
+  if err := s.peer(ctx); err != nil {
+    return nil, err
+  }
+  resp, err := s.t.dispatch(ctx, &raft.RequestVoteRequest{
+    RPCHeader:          rpcHeader(req.GetProtocolVersion(), req.GetId(), req.GetAddr()),
+    Term:               req.GetTerm(),
+    Candidate:          req.GetCandidate(),
+    LastLogIndex:       req.GetLastLogIndex(),
+    LastLogTerm:        req.GetLastLogTerm(),
+    LeadershipTransfer: req.GetLeadershipTransfer(),
+  }, nil)
+  if err != nil {
+    return nil, err
+  }
+  vote := resp.(*raft.RequestVoteResponse)
+  return &blogpb.RaftRequestVoteResponse{
+    ProtocolVersion: int64(vote.ProtocolVersion),
+    Id:              vote.ID,
+    Addr:            vote.Addr,
+    Term:            vote.Term,
+    Peers:           vote.Peers,
+    Granted:         vote.Granted,
+  }, nil
+}
+
+func (s *raftTransportServer) InstallSnapshot(stream blogpb.RaftTransport_InstallSnapshotServer) error {
+  if err := s.peer(stream.Context()); err != nil {
+    return err
+  }
+  first, err := stream.Recv()
+  if err != nil {
+    return err
+  }
+  req := &raft.InstallSnapshotRequest{
+    RPCHeader:          rpcHeader(first.GetProtocolVersion(), first.GetId(), first.GetAddr()),
+    SnapshotVersion:    raft.SnapshotVersion(first.GetSnapshotVersion()),
+    Term:               first.GetTerm(),
+    Leader:             first.GetLeader(),
+    LastLogIndex:       first.GetLastLogIndex(),
+    LastLogTerm:        first.GetLastLogTerm(),
+    Peers:              first.GetPeers(),
+    Configuration:      first.GetConfiguration(),
+    ConfigurationIndex: first.GetConfigurationIndex(),
+    Size:               first.GetSize(),
+  }
+
+  // feed the chunks to Raft as they arrive
+  pr, pw := io.Pipe()
+  go func() {
+    msg := first
+    for {
+      if _, err := pw.Write(msg.GetData()); err != nil {
+        return
+      }
+      var err error
+      msg, err = stream.Recv()
+      if err == io.EOF {
+        pw.Close()
+        return
+      }
+      if err != nil {
+        pw.CloseWithError(err)
+        return
+      }
+    }
+  }()
+  defer pr.Close()
+
+  resp, err := s.t.dispatch(stream.Context(), req, pr)
+  if err != nil {
+    return err
+  }
+  res := resp.(*raft.InstallSnapshotResponse)
+  return stream.SendAndClose(&blogpb.RaftInstallSnapshotResponse{
+    ProtocolVersion: int64(res.ProtocolVersion),
+    Id:              res.ID,
+    Addr:            res.Addr,
+    Term:            res.Term,
+    Success:         res.Success,
+  })
+}
+
+func (s *raftTransportServer) TimeoutNow(ctx context.Context, req *blogpb.RaftTimeoutNowRequest) (*blogpb.RaftTimeoutNowResponse, error) {
+  if err := s.peer(ctx); err != nil {
+    return nil, err
+  }
+  resp, err := s.t.dispatch(ctx, &raft.TimeoutNowRequest{
+    RPCHeader: rpcHeader(req.GetProtocolVersion(), req.GetId(), req.GetAddr()),
+  }, nil)
+  if err != nil {
+    return nil, err
+  }
+  res := resp.(*raft.TimeoutNowResponse)
+  return &blogpb.RaftTimeoutNowResponse{
+    ProtocolVersion: int64(res.ProtocolVersion),
+    Id:              res.ID,
+    Addr:            res.Addr,
+  }, nil
+}
+
+// rpcHeader rebuilds the RPCHeader carried by a message.
+func rpcHeader(version int64, id, addr []byte) raft.RPCHeader {
+  return raft.RPCHeader{
+    ProtocolVersion: raft.ProtocolVersion(version),
+    ID:              id,
+    Addr:            addr,
+  }
+}
+
+// unixNano and fromUnixNano convert the times of the messages, 0 standing
+// for the zero time.
+func unixNano(t time.Time) int64 {
+  if t.IsZero() {
+    return 0
+  }
+  return t.UnixNano()
+}
+
+func fromUnixNano(ns int64) time.Time {
+  if ns == 0 {
+    return time.Time{}
+  }
+  return time.Unix(0, ns)
+}
+
+func encodeAppendEntriesRequest(args *raft.AppendEntriesRequest) *blogpb.RaftAppendEntriesRequest {
+  req := &blogpb.RaftAppendEntriesRequest{
+    ProtocolVersion:   int64(args.ProtocolVersion),
+    Id:                args.ID,
+    Addr:              args.Addr,
+    Term:              args.Term,
+    Leader:            args.Leader,
+    PrevLogEntry:      args.PrevLogEntry,
+    PrevLogTerm:       args.PrevLogTerm,
+    LeaderCommitIndex: args.LeaderCommitIndex,
+  }
+  for _, l := range args.Entries {
+    req.Entries = append(req.Entries, &blogpb.RaftLog{
+      Index:      l.Index,
+      Term:       l.Term,
+      Type:       uint32(l.Type),
+      Data:       l.Data,
+      Extensions: l.Extensions,
+      AppendedAt: unixNano(l.AppendedAt),
+    })
+  }
+  return req
+}
+
+func decodeAppendEntriesRequest(req *blogpb.RaftAppendEntriesRequest) *raft.AppendEntriesRequest {
+  args := &raft.AppendEntriesRequest{
+    RPCHeader:         rpcHeader(req.GetProtocolVersion(), req.GetId(), req.GetAddr()),
+    Term:              req.GetTerm(),
+    Leader:            req.GetLeader(),
+    PrevLogEntry:      req.GetPrevLogEntry(),
+    PrevLogTerm:       req.GetPrevLogTerm(),
+    LeaderCommitIndex: req.GetLeaderCommitIndex(),
+  }
+  for _, l := range req.GetEntries() {
+    args.Entries = append(args.Entries, &raft.Log{
+      Index:      l.GetIndex(),
+      Term:       l.GetTerm(),
+      Type:       raft.LogType(l.GetType()),
+      Data:       l.GetData(),
+      Extensions: l.GetExtensions(),
+      AppendedAt: fromUnixNano(l.GetAppendedAt()),
+    })
+  }
+  return args
+}
+
+func encodeAppendEntriesResponse(resp *raft.AppendEntriesResponse) *blogpb.RaftAppendEntriesResponse {
+  return &blogpb.RaftAppendEntriesResponse{
+    ProtocolVersion: int64(resp.ProtocolVersion),
+    Id:              resp.ID,
+    Addr:            resp.Addr,
+    Term:            resp.Term,
+    LastLog:         resp.LastLog,
+    Success:         resp.Success,
+    NoRetryBackoff:  resp.NoRetryBackoff,
+  }
+}
+
+func decodeAppendEntriesResponse(res *blogpb.RaftAppendEntriesResponse) *raft.AppendEntriesResponse {
+  return &raft.AppendEntriesResponse{
+    RPCHeader:      rpcHeader(res.GetProtocolVersion(), res.GetId(), res.GetAddr()),
+    Term:           res.GetTerm(),
+    LastLog:        res.GetLastLog(),
+    Success:        res.GetSuccess(),
+    NoRetryBackoff: res.GetNoRetryBackoff(),
+  }
+}
@@ -0,0 +1,131 @@
+package main
+
+import(
+  "net"
+  "reflect"
+  "sync/atomic"
+  "testing"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/hashicorp/raft"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+func TestAppendEntriesRoundTrip(t *testing.T) {
+  header := raft.RPCHeader{
+    ProtocolVersion: raft.ProtocolVersionMax,
+    ID:              []byte("node1"),
+    Addr:            []byte("localhost:50051"),
+  }
+  req := &raft.AppendEntriesRequest{
+    RPCHeader:         header,
+    Term:              3,
+    PrevLogEntry:      7,
+    PrevLogTerm:       2,
+    LeaderCommitIndex: 7,
+    Entries: []*raft.Log{
+      {Index: 8, Term: 3, Type: raft.LogCommand, Data: []byte("cmd"), AppendedAt: time.Unix(1700000000, 42)},
+      {Index: 9, Term: 3, Type: raft.LogNoop},
+    },
+  }
+  got := decodeAppendEntriesRequest(encodeAppendEntriesRequest(req))
+  if !reflect.DeepEqual(got.RPCHeader, header) {
+    t.Errorf("got header %+v, want %+v", got.RPCHeader, header)
+  }
+  for i, l := range got.Entries {
+    if !l.AppendedAt.Equal(req.Entries[i].AppendedAt) {
+      t.Errorf("entry %v: got AppendedAt %v, want %v", i, l.AppendedAt, req.Entries[i].AppendedAt)
+    }
+  }
+
+  resp := &raft.AppendEntriesResponse{RPCHeader: header, Term: 3, LastLog: 9, Success: true}
+  if back := decodeAppendEntriesResponse(encodeAppendEntriesResponse(resp)); !reflect.DeepEqual(back.RPCHeader, header) {
+    t.Errorf("got response header %+v, want %+v", back.RPCHeader, header)
+  }
+}
+
+// serveRaftTransport serves the receiving side of a grpcTransport, checking
+// the callers with s, on a local port.
+func serveRaftTransport(t *testing.T, s *server) *grpcTransport {
+  t.Helper()
+  lis, err := net.Listen("tcp", "localhost:0")
+  if err != nil {
+    t.Fatal(err)
+  }
+  transport := newGrpcTransport(lis.Addr().String(), "")
+  gs := grpc.NewServer()
+  blogpb.RegisterRaftTransportServer(gs, &raftTransportServer{t: transport, peer: s.raftNode})
+  go gs.Serve(lis)
+  t.Cleanup(func() {
+    transport.Close()
+    gs.Stop()
+  })
+  return transport
+}
+
+func TestGrpcTransportRequiresANodeToken(t *testing.T) {
+  s := newTestServer(t, t.TempDir())
+  defer s.Close()
+  s.addRaftNodes(map[string]string{"node-token": "node1"})
+  s.tokens["user-token"] = "luis"
+  target := serveRaftTransport(t, s)
+
+  // stand in for Raft on the target, granting every vote
+  var received int32
+  go func() {
+    for {
+      select {
+      case rpc := <-target.Consumer():
+        atomic.AddInt32(&received, 1)
+        switch rpc.Command.(type) {
+        case *raft.RequestVoteRequest:
+          rpc.Respond(&raft.RequestVoteResponse{Term: 3, Granted: true}, nil)
+        case *raft.AppendEntriesRequest:
+          rpc.Respond(&raft.AppendEntriesResponse{Term: 3, Success: true}, nil)
+        }
+      case <-target.shutdown:
+        return
+      }
+    }
+  }()
+
+  vote := &raft.RequestVoteRequest{Term: 3, Candidate: []byte("node1"), LastLogIndex: 7, LastLogTerm: 2}
+  appendEntries := &raft.AppendEntriesRequest{
+    Term:         3,
+    PrevLogEntry: 7,
+    PrevLogTerm:  2,
+    Entries:      []*raft.Log{{Index: 8, Term: 3, Type: raft.LogConfiguration, Data: []byte("members")}},
+  }
+  for _, tc := range []struct {
+    token string
+    want  codes.Code
+  }{
+    {"", codes.Unauthenticated},
+    {"forged-token", codes.Unauthenticated},
+    {"user-token", codes.PermissionDenied},
+    {"node-token", codes.OK},
+  } {
+    caller := newGrpcTransport("node1", tc.token)
+    var voteResp raft.RequestVoteResponse
+    err := caller.RequestVote("node2", target.LocalAddr(), vote, &voteResp)
+    if status.Code(err) != tc.want {
+      t.Errorf("RequestVote with %q: got %v, want %v", tc.token, err, tc.want)
+    }
+    var appendResp raft.AppendEntriesResponse
+    err = caller.AppendEntries("node2", target.LocalAddr(), appendEntries, &appendResp)
+    if status.Code(err) != tc.want {
+      t.Errorf("AppendEntries with %q: got %v, want %v", tc.token, err, tc.want)
+    }
+    if tc.want == codes.OK && (!voteResp.Granted || !appendResp.Success) {
+      t.Errorf("with %q: got %+v and %+v, want the vote granted and the entries appended", tc.token, voteResp, appendResp)
+    }
+    caller.Close()
+  }
+  // only the calls of the node reached Raft
+  if n := atomic.LoadInt32(&received); n != 2 {
+    t.Errorf("Raft received %v RPCs, want 2", n)
+  }
+}
@@ -0,0 +1,325 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "math"
+  "sort"
+  "strings"
+  "sync"
+  "unicode"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+const (
+  defaultRelatedBlogs = 5
+  maxRelatedBlogs     = 50
+  // titleWeight is how many times a word of the title counts compared to
+  // one of the content
+  titleWeight = 3
+)
+
+// stopWords are too common to tell blogs apart.
+var stopWords = map[string]bool{
+  "a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+  "be": true, "but": true, "by": true, "for": true, "from": true, "has": true,
+  "have": true, "he": true, "her": true, "his": true, "i": true, "if": true,
+  "in": true, "is": true, "it": true, "its": true, "my": true, "not": true,
+  "of": true, "on": true, "or": true, "our": true, "she": true, "so": true,
+  "that": true, "the": true, "their": true, "them": true, "then": true,
+  "there": true, "they": true, "this": true, "to": true, "was": true,
+  "we": true, "were": true, "what": true, "when": true, "which": true,
+  "who": true, "will": true, "with": true, "you": true, "your": true,
+}
+
+// tokenize splits text into lower case words, without stop words.
+func tokenize(text string) []string {
+  words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+    return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+  })
+  terms := words[:0]
+  for _, w := range words {
+    if len(w) > 1 && !stopWords[w] {
+      terms = append(terms, w)
+    }
+  }
+  return terms
+}
+
+// termFrequencies returns the term frequencies of the title and content of
+// blog.
+func termFrequencies(blog *blogpb.Blog) map[string]float64 {
+  tf := make(map[string]float64)
+  for _, t := range tokenize(blog.GetTitle()) {
+    tf[t] += titleWeight
+  }
+  for _, t := range tokenize(blog.GetContent()) {
+    tf[t]++
+  }
+  return tf
+}
+
+// relatedIndex holds the term frequencies of every blog, and the number of
+// blogs using each term, for TF-IDF similarity. It is built from the
+// database on first use, then only the blogs written since the last query
+// are read again. Weights are computed at query time, since the IDF of a
+// term changes with every blog. A nil index indexes nothing.
+type relatedIndex struct {
+  mu sync.Mutex
+  // docs is nil until the index is built
+  docs     map[uint64]map[string]float64
+  df       map[string]int
+  postings map[string]map[uint64]struct{}
+  // dirty are the blogs written since the last refresh
+  dirty    map[uint64]struct{}
+  building bool
+  // gen changes on every reset, so that blogs read before it aren't indexed
+  gen uint64
+}
+
+func newRelatedIndex() *relatedIndex {
+  return &relatedIndex{dirty: make(map[uint64]struct{})}
+}
+
+// touch marks blog id as written.
+func (r *relatedIndex) touch(id uint64) {
+  if r == nil {
+    return
+  }
+  r.mu.Lock()
+  defer r.mu.Unlock()
+  // the build reads every blog anyway, unless it is already running
+  if r.docs != nil || r.building {
+    r.dirty[id] = struct{}{}
+  }
+}
+
+// reset drops the whole index, when the database is replaced.
+func (r *relatedIndex) reset() {
+  if r == nil {
+    return
+  }
+  r.mu.Lock()
+  defer r.mu.Unlock()
+  r.gen++
+  r.docs = nil
+  r.df = nil
+  r.postings = nil
+  r.dirty = make(map[uint64]struct{})
+  r.building = false
+}
+
+func (r *relatedIndex) add(id uint64, tf map[string]float64) {
+  r.docs[id] = tf
+  for t := range tf {
+    r.df[t]++
+    if r.postings[t] == nil {
+      r.postings[t] = make(map[uint64]struct{})
+    }
+    r.postings[t][id] = struct{}{}
+  }
+}
+
+func (r *relatedIndex) remove(id uint64) {
+  for t := range r.docs[id] {
+    if r.df[t]--; r.df[t] == 0 {
+      delete(r.df, t)
+      delete(r.postings, t)
+    } else {
+      delete(r.postings[t], id)
+    }
+  }
+  delete(r.docs, id)
+}
+
+// weight is the TF-IDF weight of a term of frequency tf, with a sublinear
+// TF and a smoothed IDF.
+func (r *relatedIndex) weight(term string, tf float64) float64 {
+  idf := math.Log(float64(1+len(r.docs))/float64(1+r.df[term])) + 1
+  return (1 + math.Log(tf)) * idf
+}
+
+func (r *relatedIndex) norm(tf map[string]float64) float64 {
+  var sum float64
+  for t, f := range tf {
+    w := r.weight(t, f)
+    sum += w * w
+  }
+  return math.Sqrt(sum)
+}
+
+// similar returns the ids of the blogs sharing terms with blog id by
+// decreasing cosine similarity, and their scores.
+func (r *relatedIndex) similar(id uint64) ([]uint64, map[uint64]float64) {
+  target := r.docs[id]
+  targetNorm := r.norm(target)
+  if targetNorm == 0 {
+    return nil, nil
+  }
+  dots := make(map[uint64]float64)
+  for t, f := range target {
+    w := r.weight(t, f)
+    for other := range r.postings[t] {
+      if other != id {
+        dots[other] += w * r.weight(t, r.docs[other][t])
+      }
+    }
+  }
+  ids := make([]uint64, 0, len(dots))
+  scores := make(map[uint64]float64, len(dots))
+  for other, dot := range dots {
+    ids = append(ids, other)
+    scores[other] = dot / (targetNorm * r.norm(r.docs[other]))
+  }
+  sort.Slice(ids, func(i, j int) bool {
+    if scores[ids[i]] != scores[ids[j]] {
+      return scores[ids[i]] > scores[ids[j]]
+    }
+    return ids[i] < ids[j]
+  })
+  return ids, scores
+}
+
+// refreshRelated brings the related index up to date with the database and
+// returns with its lock held. The blogs are read without the lock, so that
+// a database swap waiting for it can't deadlock with the reads.
+func (s *server) refreshRelated() error {
+  r := s.related
+  r.mu.Lock()
+  for {
+    if r.docs == nil {
+      gen := r.gen
+      r.building = true
+      r.mu.Unlock()
+      docs := make(map[uint64]map[string]float64)
+      err := s.eachBlog(func(blog *blogpb.Blog) error {
+        docs[blog.GetId()] = termFrequencies(blog)
+        return nil
+      })
+      r.mu.Lock()
+      if err != nil {
+        r.building = false
+        r.mu.Unlock()
+        return err
+      }
+      if r.gen != gen {
+        continue
+      }
+      r.building = false
+      r.docs = make(map[uint64]map[string]float64, len(docs))
+      r.df = make(map[string]int)
+      r.postings = make(map[string]map[uint64]struct{})
+      for id, tf := range docs {
+        r.add(id, tf)
+      }
+    }
+    if len(r.dirty) == 0 {
+      return nil
+    }
+
+    gen := r.gen
+    dirty := r.dirty
+    r.dirty = make(map[uint64]struct{})
+    r.mu.Unlock()
+    blogs := make(map[uint64]*blogpb.Blog, len(dirty))
+    var err error
+    for id := range dirty {
+      err = s.viewBlog(id, func(tx *bolt.Tx) error {
+        blog, err := getBlog(tx, id)
+        blogs[id] = blog
+        return err
+      })
+      if err != nil {
+        break
+      }
+    }
+    r.mu.Lock()
+    if err != nil {
+      // try them again next time
+      for id := range dirty {
+        r.dirty[id] = struct{}{}
+      }
+      r.mu.Unlock()
+      return err
+    }
+    if r.gen != gen {
+      continue
+    }
+    for id, blog := range blogs {
+      r.remove(id)
+      if blog != nil {
+        r.add(id, termFrequencies(blog))
+      }
+    }
+  }
+}
+
+// RelatedBlogs returns the blogs most similar to a blog by TF-IDF cosine
+// similarity of their title and content, among those the caller can read.
+func (s *server) RelatedBlogs(ctx context.Context, req *blogpb.RelatedBlogsRequest) (*blogpb.RelatedBlogsResponse, error) {
+  fmt.Printf("RelatedBlogs was invoked with: %v\n\n", req)
+  caller, err := s.caller(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if s.related == nil {
+    return nil, status.Error(codes.Unimplemented, "Related blogs are disabled on this server")
+  }
+  limit := int(req.GetLimit())
+  if limit <= 0 {
+    limit = defaultRelatedBlogs
+  } else if limit > maxRelatedBlogs {
+    limit = maxRelatedBlogs
+  }
+
+  id := req.GetBlogId()
+  var blog *blogpb.Blog
+  err = s.viewBlog(id, func(tx *bolt.Tx) error {
+    var err error
+    blog, err = getBlog(tx, id)
+    if err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+    }
+    if blog == nil {
+      return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", id))
+    }
+    return s.checkRead(blog, caller)
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  if err := s.refreshRelated(); err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not index the blogs: %v", err))
+  }
+  ids, scores := s.related.similar(id)
+  s.related.mu.Unlock()
+
+  res := &blogpb.RelatedBlogsResponse{}
+  for _, other := range ids {
+    if len(res.Blogs) == limit {
+      break
+    }
+    var blog *blogpb.Blog
+    err := s.viewBlog(other, func(tx *bolt.Tx) error {
+      var err error
+      blog, err = getBlog(tx, other)
+      return err
+    })
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+    }
+    if blog == nil || s.checkRead(blog, caller) != nil {
+      continue
+    }
+    res.Blogs = append(res.Blogs, &blogpb.RelatedBlog{
+      Blog:  s.visibleBlog(localize(blog, nil), caller),
+      Score: scores[other],
+    })
+  }
+  return res, nil
+}
@@ -0,0 +1,350 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "log"
+  "sync"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+const (
+  // changeBatchSize is the number of changelog entries read per transaction
+  // when streaming them to a follower.
+  changeBatchSize   = 256
+  heartbeatInterval = time.Second
+  maxFollowBackoff  = 30 * time.Second
+)
+
+// replica is the state of a follower. A server with a nil replica is the
+// leader and accepts writes.
+type replica struct {
+  leaderAddr string
+  client     blogpb.ReplicationServiceClient
+  // token authenticates the follower to the leader, if set
+  token string
+
+  mu          sync.Mutex
+  connected   bool
+  leaderSeq   uint64
+  lastContact time.Time
+  applyDelay  time.Duration
+}
+
+func newReplica(leaderAddr, token string, cc *grpc.ClientConn) *replica {
+  return &replica{
+    leaderAddr: leaderAddr,
+    client:     blogpb.NewReplicationServiceClient(cc),
+    token:      token,
+  }
+}
+
+// addReplicators accepts the tokens of the followers, a map like the one of
+// loadAuthTokens.
+func (s *server) addReplicators(tokens map[string]string) {
+  if s.tokens == nil {
+    s.tokens = make(map[string]string)
+  }
+  if s.replicators == nil {
+    s.replicators = make(map[string]bool)
+  }
+  for token, follower := range tokens {
+    s.tokens[token] = follower
+    s.replicators[follower] = true
+  }
+}
+
+// replicator is authenticated for ReplicationService, which streams every
+// blog whatever its ACL, so only followers and admins can call it once
+// authentication is on.
+func (s *server) replicator(ctx context.Context) error {
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return err
+  }
+  if s.tokens != nil && !s.replicators[caller] && !s.admins[caller] {
+    return status.Error(codes.PermissionDenied, fmt.Sprintf("%v is not a follower", caller))
+  }
+  return nil
+}
+
+// checkWritable rejects writes on followers and on cluster nodes that aren't
+// the leader. The address of the leader is sent back in the x-blog-leader
+// trailer so that clients can retry there.
+func (s *server) checkWritable(ctx context.Context) error {
+  if s.cluster != nil {
+    return s.cluster.checkLeader(ctx)
+  }
+  if s.replica == nil {
+    return nil
+  }
+  grpc.SetTrailer(ctx, metadata.Pairs("x-blog-leader", s.replica.leaderAddr))
+  return status.Error(codes.FailedPrecondition, fmt.Sprintf("This server is a read-only follower, send writes to the leader at %v", s.replica.leaderAddr))
+}
+
+func (s *server) StreamChanges(req *blogpb.StreamChangesRequest, stream blogpb.ReplicationService_StreamChangesServer) error {
+  fmt.Printf("StreamChanges was invoked with: %v\n\n", req)
+  if err := s.replicator(stream.Context()); err != nil {
+    return err
+  }
+  if s.shards != nil {
+    return status.Error(codes.FailedPrecondition, "Replication is not supported when the blogs are split in shards")
+  }
+
+  next := req.GetFromSeq()
+  if next == 0 {
+    next = 1
+  }
+  heartbeat := time.NewTicker(heartbeatInterval)
+  defer heartbeat.Stop()
+
+  for {
+    // get the channel before reading, so no write can slip in between
+    changed := s.changes.wait()
+
+    var entries []*blogpb.ChangeEntry
+    var head uint64
+    err := s.view(func(tx *bolt.Tx) error {
+      head = lastChangeSeq(tx)
+      var err error
+      entries, err = readChanges(tx, next, changeBatchSize)
+      return err
+    })
+    if err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Could not read changelog: %v", err))
+    }
+
+    for _, entry := range entries {
+      err := stream.Send(&blogpb.StreamChangesResponse{
+        Entry:      entry,
+        LeaderSeq:  head,
+        LeaderTime: time.Now().UnixNano(),
+      })
+      if err != nil {
+        return err
+      }
+      next = entry.GetSeq() + 1
+    }
+    if len(entries) == changeBatchSize {
+      // there is probably more to send already
+      continue
+    }
+
+    select {
+    case <-changed:
+    case <-heartbeat.C:
+      err := stream.Send(&blogpb.StreamChangesResponse{
+        LeaderSeq:  head,
+        LeaderTime: time.Now().UnixNano(),
+      })
+      if err != nil {
+        return err
+      }
+    case <-stream.Context().Done():
+      return stream.Context().Err()
+    }
+  }
+}
+
+func (s *server) ReplicationStatus(ctx context.Context, req *blogpb.ReplicationStatusRequest) (*blogpb.ReplicationStatusResponse, error) {
+  if err := s.replicator(ctx); err != nil {
+    return nil, err
+  }
+  var applied uint64
+  err := s.view(func(tx *bolt.Tx) error {
+    applied = lastChangeSeq(tx)
+    return nil
+  })
+  if err != nil {
+    return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read changelog: %v", err))
+  }
+
+  res := &blogpb.ReplicationStatusResponse{
+    Role:       blogpb.ReplicationStatusResponse_LEADER,
+    AppliedSeq: applied,
+    LeaderSeq:  applied,
+  }
+  if r := s.replica; r != nil {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    res.Role = blogpb.ReplicationStatusResponse_FOLLOWER
+    res.LeaderAddr = r.leaderAddr
+    res.Connected = r.connected
+    res.LeaderSeq = r.leaderSeq
+    if r.leaderSeq > applied {
+      res.LagEntries = r.leaderSeq - applied
+    }
+    res.ApplyDelaySeconds = r.applyDelay.Seconds()
+    if !r.lastContact.IsZero() {
+      res.SecondsSinceContact = time.Since(r.lastContact).Seconds()
+    }
+  }
+  return res, nil
+}
+
+// follow keeps the database in sync with the leader until ctx is done,
+// reconnecting with a growing delay whenever the stream breaks.
+func (s *server) follow(ctx context.Context) {
+  backoff := time.Second
+  for {
+    received, err := s.followOnce(ctx)
+    s.replica.setConnected(false)
+    if ctx.Err() != nil {
+      return
+    }
+    log.Printf("Replication from %v interrupted: %v\n", s.replica.leaderAddr, err)
+    if received {
+      backoff = time.Second
+    }
+    select {
+    case <-time.After(backoff):
+    case <-ctx.Done():
+      return
+    }
+    if backoff *= 2; backoff > maxFollowBackoff {
+      backoff = maxFollowBackoff
+    }
+  }
+}
+
+// followOnce streams the changelog of the leader from the first entry this
+// node doesn't have and applies it until the stream breaks.
+func (s *server) followOnce(ctx context.Context) (bool, error) {
+  var from uint64
+  err := s.view(func(tx *bolt.Tx) error {
+    from = lastChangeSeq(tx) + 1
+    return nil
+  })
+  if err != nil {
+    return false, err
+  }
+
+  if s.replica.token != "" {
+    ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+s.replica.token)
+  }
+  stream, err := s.replica.client.StreamChanges(ctx, &blogpb.StreamChangesRequest{
+    FromSeq: from,
+  })
+  if err != nil {
+    return false, err
+  }
+  received := false
+  for {
+    res, err := stream.Recv()
+    if err != nil {
+      return received, err
+    }
+    if !received {
+      fmt.Printf("Following %v from changelog entry %v\n\n", s.replica.leaderAddr, from)
+      received = true
+    }
+    entry := res.GetEntry()
+    if entry != nil {
+      err := s.update(func(tx *bolt.Tx) error {
+        return applyChange(tx, entry)
+      })
+      if err != nil {
+        return received, fmt.Errorf("could not apply changelog entry %v: %v", entry.GetSeq(), err)
+      }
+      // views are read apart from the cached blogs
+      if entry.GetOp() != blogpb.ChangeEntry_VIEW {
+        s.cache.invalidate(entry.GetBlogId())
+        s.related.touch(entry.GetBlogId())
+      }
+    }
+    s.replica.contact(res, entry)
+  }
+}
+
+func (r *replica) setConnected(connected bool) {
+  r.mu.Lock()
+  defer r.mu.Unlock()
+  r.connected = connected
+}
+
+// contact records a message received from the leader.
+func (r *replica) contact(res *blogpb.StreamChangesResponse, applied *blogpb.ChangeEntry) {
+  r.mu.Lock()
+  defer r.mu.Unlock()
+  now := time.Now()
+  r.connected = true
+  r.lastContact = now
+  r.leaderSeq = res.GetLeaderSeq()
+  if applied != nil {
+    r.applyDelay = now.Sub(time.Unix(0, applied.GetTimestamp()))
+  }
+}
+
+// applyChange replays a changelog entry of the leader. The entry keeps its
+// sequence number, so the changelog of a follower is a copy of the leader's
+// one and can be streamed to other followers in turn.
+func applyChange(tx *bolt.Tx, entry *blogpb.ChangeEntry) error {
+  if entry.GetSeq() <= lastChangeSeq(tx) {
+    // already applied
+    return nil
+  }
+  b := tx.Bucket(blogBucket)
+  switch entry.GetOp() {
+  case blogpb.ChangeEntry_PUT:
+    // entries written before the views had their own bucket carry the
+    // total views of the blog
+    if views := entry.GetBlog().GetViews(); views != 0 {
+      if err := setViews(tx, entry.GetBlogId(), views); err != nil {
+        return err
+      }
+    }
+    if err := storeBlog(tx, entry.GetBlog()); err != nil {
+      return err
+    }
+    // keep the id generator past the replicated ids
+    if b.Sequence() < entry.GetBlogId() {
+      if err := b.SetSequence(entry.GetBlogId()); err != nil {
+        return err
+      }
+    }
+  case blogpb.ChangeEntry_DELETE:
+    if err := dropBlog(tx, entry.GetBlogId()); err != nil {
+      return err
+    }
+    if err := setViews(tx, entry.GetBlogId(), 0); err != nil {
+      return err
+    }
+  case blogpb.ChangeEntry_PUT_SERIES:
+    if err := storeSeries(tx, entry.GetSeries()); err != nil {
+      return err
+    }
+    sb := tx.Bucket(seriesBucket)
+    if sb.Sequence() < entry.GetSeriesId() {
+      if err := sb.SetSequence(entry.GetSeriesId()); err != nil {
+        return err
+      }
+    }
+  case blogpb.ChangeEntry_DELETE_SERIES:
+    if err := dropSeries(tx, entry.GetSeriesId()); err != nil {
+      return err
+    }
+  case blogpb.ChangeEntry_PUT_CHUNK:
+    if err := storeChunk(tx, entry.GetBlogId(), entry.GetContentVersion(), entry.GetChunkIndex(), entry.GetChunk()); err != nil {
+      return err
+    }
+  case blogpb.ChangeEntry_DELETE_CHUNKS:
+    if err := dropChunks(tx, entry.GetBlogId(), entry.GetContentVersion()); err != nil {
+      return err
+    }
+  case blogpb.ChangeEntry_VIEW:
+    // followers only know the totals
+    if err := setViews(tx, entry.GetBlogId(), blogViews(tx, entry.GetBlogId(), 0)+entry.GetViews()); err != nil {
+      return err
+    }
+  default:
+    return fmt.Errorf("unknown operation %v", entry.GetOp())
+  }
+  return appendChange(tx, entry)
+}
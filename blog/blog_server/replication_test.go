@@ -0,0 +1,264 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "net"
+  "testing"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+)
+
+const replicationTimeout = 5 * time.Second
+
+// serveTestServer serves s over gRPC on a local port and returns a
+// connection to it.
+func serveTestServer(t *testing.T, s *server) (string, *grpc.ClientConn) {
+  t.Helper()
+  lis, err := net.Listen("tcp", "localhost:0")
+  if err != nil {
+    t.Fatal(err)
+  }
+  gs := grpc.NewServer()
+  blogpb.RegisterBlogServiceServer(gs, s)
+  blogpb.RegisterReplicationServiceServer(gs, s)
+  go gs.Serve(lis)
+  t.Cleanup(gs.Stop)
+
+  addr := lis.Addr().String()
+  cc, err := grpc.Dial(addr, grpc.WithInsecure())
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() { cc.Close() })
+  return addr, cc
+}
+
+// gatedReplication lets the follower receive one message from the leader
+// for each value sent on gate, and all of them once gate is closed.
+type gatedReplication struct {
+  blogpb.ReplicationServiceClient
+  gate chan struct{}
+}
+
+func (c *gatedReplication) StreamChanges(ctx context.Context, in *blogpb.StreamChangesRequest, opts ...grpc.CallOption) (blogpb.ReplicationService_StreamChangesClient, error) {
+  stream, err := c.ReplicationServiceClient.StreamChanges(ctx, in, opts...)
+  if err != nil {
+    return nil, err
+  }
+  return &gatedStream{stream, c.gate}, nil
+}
+
+type gatedStream struct {
+  blogpb.ReplicationService_StreamChangesClient
+  gate chan struct{}
+}
+
+func (s *gatedStream) Recv() (*blogpb.StreamChangesResponse, error) {
+  select {
+  case <-s.gate:
+  case <-s.Context().Done():
+    return nil, s.Context().Err()
+  }
+  return s.ReplicationService_StreamChangesClient.Recv()
+}
+
+// follow makes s follow the leader at leaderAddr until the test ends.
+func follow(t *testing.T, s *server, leaderAddr, token string, gate chan struct{}) {
+  t.Helper()
+  cc, err := grpc.Dial(leaderAddr, grpc.WithInsecure())
+  if err != nil {
+    t.Fatal(err)
+  }
+  s.replica = newReplica(leaderAddr, token, cc)
+  if gate != nil {
+    s.replica.client = &gatedReplication{s.replica.client, gate}
+  }
+  ctx, cancel := context.WithCancel(context.Background())
+  done := make(chan struct{})
+  go func() {
+    s.follow(ctx)
+    close(done)
+  }()
+  t.Cleanup(func() {
+    cancel()
+    <-done
+    cc.Close()
+  })
+}
+
+// startLeader serves a leader requiring tokens, accepting "user-token" for
+// luis and "follower-token" for a follower.
+func startLeader(t *testing.T) (string, blogpb.BlogServiceClient) {
+  s := newTestServer(t, t.TempDir())
+  t.Cleanup(s.Close)
+  s.tokens = map[string]string{"user-token": "luis"}
+  s.addReplicators(map[string]string{"follower-token": "follower"})
+  addr, cc := serveTestServer(t, s)
+  return addr, blogpb.NewBlogServiceClient(cc)
+}
+
+// startFollower serves a follower of the leader at leaderAddr, which it
+// follows until the test ends.
+func startFollower(t *testing.T, leaderAddr string, gate chan struct{}) (blogpb.BlogServiceClient, blogpb.ReplicationServiceClient) {
+  s := newTestServer(t, t.TempDir())
+  t.Cleanup(s.Close)
+  _, cc := serveTestServer(t, s)
+  follow(t, s, leaderAddr, "follower-token", gate)
+  return blogpb.NewBlogServiceClient(cc), blogpb.NewReplicationServiceClient(cc)
+}
+
+func asUser(token string) context.Context {
+  return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// waitRead waits for the follower to serve blog id as checked by fn.
+func waitRead(t *testing.T, c blogpb.BlogServiceClient, id uint64, fn func(*blogpb.Blog, error) error) {
+  t.Helper()
+  eventually(t, replicationTimeout, func() error {
+    res, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: id})
+    return fn(res.GetBlog(), err)
+  })
+}
+
+func hasTitle(title string) func(*blogpb.Blog, error) error {
+  return func(blog *blogpb.Blog, err error) error {
+    if err != nil {
+      return err
+    }
+    if blog.GetTitle() != title {
+      return fmt.Errorf("got title %q, want %q", blog.GetTitle(), title)
+    }
+    return nil
+  }
+}
+
+func TestReplicationWrites(t *testing.T) {
+  leaderAddr, leader := startLeader(t)
+  follower, _ := startFollower(t, leaderAddr, nil)
+  ctx := asUser("user-token")
+
+  created, err := leader.CreateBlog(ctx, &blogpb.CreateBlogRequest{
+    Blog: &blogpb.Blog{AuthorId: "luis", Title: "Created", Content: "Hello"},
+  })
+  if err != nil {
+    t.Fatalf("CreateBlog: %v", err)
+  }
+  blog := created.GetBlog()
+  waitRead(t, follower, blog.GetId(), hasTitle("Created"))
+
+  blog.Title = "Updated"
+  if _, err := leader.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: blog}); err != nil {
+    t.Fatalf("UpdateBlog: %v", err)
+  }
+  waitRead(t, follower, blog.GetId(), hasTitle("Updated"))
+
+  if _, err := leader.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: blog.GetId()}); err != nil {
+    t.Fatalf("DeleteBlog: %v", err)
+  }
+  waitRead(t, follower, blog.GetId(), func(blog *blogpb.Blog, err error) error {
+    if status.Code(err) != codes.NotFound {
+      return fmt.Errorf("got %v after the delete, want NotFound", err)
+    }
+    return nil
+  })
+}
+
+func TestReplicationFollowerRejectsWrites(t *testing.T) {
+  leaderAddr, _ := startLeader(t)
+  follower, _ := startFollower(t, leaderAddr, nil)
+
+  var trailer metadata.MD
+  _, err := follower.CreateBlog(asUser("user-token"), &blogpb.CreateBlogRequest{
+    Blog: &blogpb.Blog{AuthorId: "luis", Title: "Lost"},
+  }, grpc.Trailer(&trailer))
+  if status.Code(err) != codes.FailedPrecondition {
+    t.Fatalf("CreateBlog on the follower: got %v, want FailedPrecondition", err)
+  }
+  if got := trailer.Get("x-blog-leader"); len(got) != 1 || got[0] != leaderAddr {
+    t.Errorf("got x-blog-leader %v, want %v", got, leaderAddr)
+  }
+}
+
+func TestReplicationCredentials(t *testing.T) {
+  leaderAddr, _ := startLeader(t)
+  cc, err := grpc.Dial(leaderAddr, grpc.WithInsecure())
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer cc.Close()
+  repl := blogpb.NewReplicationServiceClient(cc)
+
+  for token, want := range map[string]codes.Code{
+    "":               codes.Unauthenticated,
+    "user-token":     codes.PermissionDenied,
+    "follower-token": codes.OK,
+  } {
+    ctx := context.Background()
+    if token != "" {
+      ctx = asUser(token)
+    }
+    _, err := repl.ReplicationStatus(ctx, &blogpb.ReplicationStatusRequest{})
+    if status.Code(err) != want {
+      t.Errorf("ReplicationStatus with %q: got %v, want %v", token, err, want)
+    }
+    ctx, cancel := context.WithCancel(ctx)
+    stream, err := repl.StreamChanges(ctx, &blogpb.StreamChangesRequest{})
+    if err == nil {
+      // the first message is a heartbeat at the latest
+      _, err = stream.Recv()
+    }
+    cancel()
+    if status.Code(err) != want {
+      t.Errorf("StreamChanges with %q: got %v, want %v", token, err, want)
+    }
+  }
+}
+
+func TestReplicationLag(t *testing.T) {
+  leaderAddr, leader := startLeader(t)
+  ctx := asUser("user-token")
+  for i := 0; i < 3; i++ {
+    _, err := leader.CreateBlog(ctx, &blogpb.CreateBlogRequest{
+      Blog: &blogpb.Blog{AuthorId: "luis", Title: fmt.Sprintf("Blog %v", i+1)},
+    })
+    if err != nil {
+      t.Fatalf("CreateBlog: %v", err)
+    }
+  }
+
+  // let the follower apply the first of the three entries only
+  gate := make(chan struct{}, 1)
+  gate <- struct{}{}
+  _, followerRepl := startFollower(t, leaderAddr, gate)
+  eventually(t, replicationTimeout, func() error {
+    res, err := followerRepl.ReplicationStatus(context.Background(), &blogpb.ReplicationStatusRequest{})
+    if err != nil {
+      return err
+    }
+    if res.GetRole() != blogpb.ReplicationStatusResponse_FOLLOWER || !res.GetConnected() {
+      return fmt.Errorf("got %v, want a connected follower", res)
+    }
+    if res.GetAppliedSeq() != 1 || res.GetLeaderSeq() != 3 || res.GetLagEntries() != 2 {
+      return fmt.Errorf("got %v, want 2 entries behind", res)
+    }
+    return nil
+  })
+
+  close(gate)
+  eventually(t, replicationTimeout, func() error {
+    res, err := followerRepl.ReplicationStatus(context.Background(), &blogpb.ReplicationStatusRequest{})
+    if err != nil {
+      return err
+    }
+    if res.GetAppliedSeq() != 3 || res.GetLagEntries() != 0 {
+      return fmt.Errorf("got %v, want the follower caught up", res)
+    }
+    return nil
+  })
+}
@@ -0,0 +1,495 @@
+package main
+
+import(
+  "context"
+  "fmt"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+var (
+  // seriesBucket holds the series by id. Series always live in blog.db,
+  // even when the blogs are split in shards.
+  seriesBucket = []byte("Series")
+  // seriesMembersBucket maps the id of every blog in a series to the id of
+  // its series.
+  seriesMembersBucket = []byte("SeriesMembers")
+)
+
+// getSeries returns the series stored under id, or nil if there is none.
+func getSeries(tx *bolt.Tx, id uint64) (*blogpb.Series, error) {
+  seriesBytes := tx.Bucket(seriesBucket).Get(uitob(id))
+  if seriesBytes == nil {
+    return nil, nil
+  }
+  series := &blogpb.Series{}
+  if err := unmarshalValue(tx, seriesBucket, uitob(id), seriesBytes, series); err != nil {
+    return nil, fmt.Errorf("series %v: %v", id, err)
+  }
+  return series, nil
+}
+
+// seriesOf returns the id of the series blog id is part of, 0 if none.
+func seriesOf(tx *bolt.Tx, blogID uint64) uint64 {
+  v := tx.Bucket(seriesMembersBucket).Get(uitob(blogID))
+  if v == nil {
+    return 0
+  }
+  return btoui(v)
+}
+
+// putSeries stores series and records the write, made at at, in the
+// changelog.
+func putSeries(tx *bolt.Tx, at int64, series *blogpb.Series) error {
+  if err := storeSeries(tx, series); err != nil {
+    return err
+  }
+  return appendChange(tx, &blogpb.ChangeEntry{
+    Op:       blogpb.ChangeEntry_PUT_SERIES,
+    SeriesId:  series.GetId(),
+    Series:    series,
+    Timestamp: at,
+  })
+}
+
+// removeSeries deletes series id and records the delete, made at at, in the
+// changelog.
+func removeSeries(tx *bolt.Tx, at int64, id uint64) error {
+  if err := dropSeries(tx, id); err != nil {
+    return err
+  }
+  return appendChange(tx, &blogpb.ChangeEntry{
+    Op:        blogpb.ChangeEntry_DELETE_SERIES,
+    SeriesId:  id,
+    Timestamp: at,
+  })
+}
+
+// storeSeries stores series under its id and keeps the members index in
+// step with its blogs.
+func storeSeries(tx *bolt.Tx, series *blogpb.Series) error {
+  if err := dropMembers(tx, series.GetId()); err != nil {
+    return err
+  }
+  members := tx.Bucket(seriesMembersBucket)
+  for _, blogID := range series.GetBlogIds() {
+    if err := members.Put(uitob(blogID), uitob(series.GetId())); err != nil {
+      return err
+    }
+  }
+  return putMessage(tx, seriesBucket, uitob(series.GetId()), series)
+}
+
+func dropSeries(tx *bolt.Tx, id uint64) error {
+  if err := dropMembers(tx, id); err != nil {
+    return err
+  }
+  return tx.Bucket(seriesBucket).Delete(uitob(id))
+}
+
+// dropMembers removes the blogs series id had when it was last stored from
+// the members index.
+func dropMembers(tx *bolt.Tx, id uint64) error {
+  old, err := getSeries(tx, id)
+  if err != nil || old == nil {
+    return err
+  }
+  members := tx.Bucket(seriesMembersBucket)
+  for _, blogID := range old.GetBlogIds() {
+    if err := members.Delete(uitob(blogID)); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+func isSeriesCommand(cmd *blogpb.BlogCommand) bool {
+  switch cmd.GetOp() {
+  case blogpb.BlogCommand_CREATE_SERIES, blogpb.BlogCommand_DELETE_SERIES, blogpb.BlogCommand_ADD_TO_SERIES,
+    blogpb.BlogCommand_MOVE_IN_SERIES, blogpb.BlogCommand_REMOVE_FROM_SERIES:
+    return true
+  }
+  return false
+}
+
+// checkFreeBlog fails unless blog id can join series seriesID, not being
+// part of any series yet.
+func checkFreeBlog(tx *bolt.Tx, blogID, seriesID uint64) error {
+  switch other := seriesOf(tx, blogID); other {
+  case 0:
+    return nil
+  case seriesID:
+    return status.Error(codes.AlreadyExists, fmt.Sprintf("Blog %v is already part of series %v", blogID, seriesID))
+  default:
+    return status.Error(codes.FailedPrecondition, fmt.Sprintf("Blog %v is already part of series %v", blogID, other))
+  }
+}
+
+func indexOf(ids []uint64, id uint64) int {
+  for i, v := range ids {
+    if v == id {
+      return i
+    }
+  }
+  return -1
+}
+
+// insertAt inserts id in ids before index i.
+func insertAt(ids []uint64, i int, id uint64) []uint64 {
+  ids = append(ids, 0)
+  copy(ids[i+1:], ids[i:])
+  ids[i] = id
+  return ids
+}
+
+// applySeriesCommand runs a series command in tx and returns the series it
+// created or changed. Like applyCommand it must give the same result on
+// every node of a cluster, and it checks everything before writing since
+// the Raft state machine commits failed commands too.
+func applySeriesCommand(tx *bolt.Tx, cmd *blogpb.BlogCommand) (*blogpb.Series, error) {
+  if cmd.GetOp() == blogpb.BlogCommand_CREATE_SERIES {
+    series := cmd.GetSeries()
+    for i, blogID := range series.GetBlogIds() {
+      if indexOf(series.GetBlogIds()[:i], blogID) >= 0 {
+        return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Blog %v is listed twice", blogID))
+      }
+      if err := checkFreeBlog(tx, blogID, 0); err != nil {
+        return nil, err
+      }
+    }
+    id, err := tx.Bucket(seriesBucket).NextSequence()
+    if err != nil {
+      return nil, err
+    }
+    series.Id = id
+    return series, putSeries(tx, cmd.GetTimestamp(), series)
+  }
+
+  id := cmd.GetSeriesId()
+  series, err := getSeries(tx, id)
+  if err != nil {
+    return nil, err
+  }
+  if series == nil {
+    return nil, status.Error(codes.NotFound, fmt.Sprintf("Could not find series with id %v", id))
+  }
+  if caller := cmd.GetCaller(); caller != "" && series.GetOwner() != caller {
+    return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("Only the owner can change series %v", id))
+  }
+
+  blogID := cmd.GetBlogId()
+  i := indexOf(series.GetBlogIds(), blogID)
+  switch cmd.GetOp() {
+  case blogpb.BlogCommand_DELETE_SERIES:
+    return nil, removeSeries(tx, cmd.GetTimestamp(), id)
+  case blogpb.BlogCommand_ADD_TO_SERIES:
+    if err := checkFreeBlog(tx, blogID, id); err != nil {
+      return nil, err
+    }
+    pos := int(cmd.GetPosition())
+    if pos <= 0 || pos > len(series.GetBlogIds()) {
+      pos = len(series.GetBlogIds()) + 1
+    }
+    series.BlogIds = insertAt(series.GetBlogIds(), pos-1, blogID)
+  case blogpb.BlogCommand_MOVE_IN_SERIES:
+    if i < 0 {
+      return nil, status.Error(codes.NotFound, fmt.Sprintf("Blog %v is not part of series %v", blogID, id))
+    }
+    pos := int(cmd.GetPosition())
+    if pos <= 0 || pos > len(series.GetBlogIds()) {
+      return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Position %v is out of range, series %v has %v blogs", pos, id, len(series.GetBlogIds())))
+    }
+    ids := append(series.GetBlogIds()[:i:i], series.GetBlogIds()[i+1:]...)
+    series.BlogIds = insertAt(ids, pos-1, blogID)
+  case blogpb.BlogCommand_REMOVE_FROM_SERIES:
+    if i < 0 {
+      return nil, status.Error(codes.NotFound, fmt.Sprintf("Blog %v is not part of series %v", blogID, id))
+    }
+    series.BlogIds = append(series.GetBlogIds()[:i:i], series.GetBlogIds()[i+1:]...)
+  default:
+    return nil, fmt.Errorf("unknown command %v", cmd.GetOp())
+  }
+  return series, putSeries(tx, cmd.GetTimestamp(), series)
+}
+
+// removeBlogFromSeries takes a blog deleted at at out of its series, so that
+// the remaining blogs keep their order.
+func removeBlogFromSeries(tx *bolt.Tx, at int64, blogID uint64) error {
+  id := seriesOf(tx, blogID)
+  if id == 0 {
+    return nil
+  }
+  series, err := getSeries(tx, id)
+  if err != nil || series == nil {
+    return err
+  }
+  if i := indexOf(series.GetBlogIds(), blogID); i >= 0 {
+    series.BlogIds = append(series.GetBlogIds()[:i], series.GetBlogIds()[i+1:]...)
+  }
+  return putSeries(tx, at, series)
+}
+
+// executeSeries runs a series command, committing it through Raft first in
+// cluster mode.
+func (s *server) executeSeries(ctx context.Context, cmd *blogpb.BlogCommand) (*blogpb.Series, error) {
+  if s.cluster != nil {
+    res := s.cluster.commit(ctx, cmd)
+    return res.series, res.err
+  }
+  var series *blogpb.Series
+  err := s.update(func(tx *bolt.Tx) error {
+    var err error
+    series, err = applySeriesCommand(tx, cmd)
+    return err
+  })
+  return series, err
+}
+
+// fetchBlog returns blog id, or nil if there is none.
+func (s *server) fetchBlog(id uint64) (*blogpb.Blog, error) {
+  var blog *blogpb.Blog
+  err := s.viewBlog(id, func(tx *bolt.Tx) error {
+    var err error
+    blog, err = getBlog(tx, id)
+    return err
+  })
+  return blog, err
+}
+
+// checkSeriesBlog fails unless blog id exists and caller can edit it, which
+// is needed to put it in a series.
+func (s *server) checkSeriesBlog(id uint64, caller string) error {
+  blog, err := s.fetchBlog(id)
+  if err != nil {
+    return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+  }
+  if blog == nil {
+    return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", id))
+  }
+  return checkAccess(blog, caller, true)
+}
+
+// seriesNavigation returns where blog id is in its series, nil if it isn't
+// part of one.
+func (s *server) seriesNavigation(id uint64, caller string, locales []string) (*blogpb.SeriesNavigation, error) {
+  var series *blogpb.Series
+  err := s.view(func(tx *bolt.Tx) error {
+    seriesID := seriesOf(tx, id)
+    if seriesID == 0 {
+      return nil
+    }
+    var err error
+    series, err = getSeries(tx, seriesID)
+    return err
+  })
+  if err != nil || series == nil {
+    return nil, err
+  }
+  ids := series.GetBlogIds()
+  i := indexOf(ids, id)
+  if i < 0 {
+    return nil, nil
+  }
+
+  nav := &blogpb.SeriesNavigation{
+    SeriesId:    series.GetId(),
+    SeriesTitle: series.GetTitle(),
+    Position:    int32(i + 1),
+    Count:       int32(len(ids)),
+  }
+  for j := i - 1; j >= 0 && nav.Previous == nil; j-- {
+    if nav.Previous, err = s.seriesBlog(ids[j], caller, locales); err != nil {
+      return nil, err
+    }
+  }
+  for j := i + 1; j < len(ids) && nav.Next == nil; j++ {
+    if nav.Next, err = s.seriesBlog(ids[j], caller, locales); err != nil {
+      return nil, err
+    }
+  }
+  return nav, nil
+}
+
+// seriesBlog returns blog id of a series the way caller may see it, or nil
+// if caller can't read it or it is gone.
+func (s *server) seriesBlog(id uint64, caller string, locales []string) (*blogpb.Blog, error) {
+  blog, err := s.fetchBlog(id)
+  if err != nil || blog == nil || s.checkRead(blog, caller) != nil {
+    return nil, err
+  }
+  return s.visibleBlog(localize(blog, locales), caller), nil
+}
+
+func (s *server) CreateSeries(ctx context.Context, req *blogpb.CreateSeriesRequest) (*blogpb.CreateSeriesResponse, error) {
+  fmt.Printf("CreateSeries was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  series := req.GetSeries()
+  if series.GetTitle() == "" {
+    return nil, status.Error(codes.InvalidArgument, "The title is required")
+  }
+  series.Id = 0
+  series.Owner = caller
+  for _, id := range series.GetBlogIds() {
+    if err := s.checkSeriesBlog(id, caller); err != nil {
+      return nil, err
+    }
+  }
+
+  series, err = s.executeSeries(ctx, &blogpb.BlogCommand{
+    Op:     blogpb.BlogCommand_CREATE_SERIES,
+    Series: series,
+    Caller: caller,
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.CreateSeriesResponse{
+    Series: series,
+  }, nil
+}
+
+func (s *server) GetSeries(ctx context.Context, req *blogpb.GetSeriesRequest) (*blogpb.GetSeriesResponse, error) {
+  fmt.Printf("GetSeries was invoked with: %v\n\n", req)
+  caller, err := s.caller(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  id := req.GetSeriesId()
+  var series *blogpb.Series
+  err = s.view(func(tx *bolt.Tx) error {
+    var err error
+    series, err = getSeries(tx, id)
+    if err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+    }
+    if series == nil {
+      return status.Error(codes.NotFound, fmt.Sprintf("Could not find series with id %v", id))
+    }
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  res := &blogpb.GetSeriesResponse{
+    Series: series,
+  }
+  for _, blogID := range series.GetBlogIds() {
+    blog, err := s.seriesBlog(blogID, caller, req.GetLocales())
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+    }
+    if blog != nil {
+      res.Blogs = append(res.Blogs, blog)
+    }
+  }
+  return res, nil
+}
+
+func (s *server) DeleteSeries(ctx context.Context, req *blogpb.DeleteSeriesRequest) (*blogpb.DeleteSeriesResponse, error) {
+  fmt.Printf("DeleteSeries was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  _, err = s.executeSeries(ctx, &blogpb.BlogCommand{
+    Op:       blogpb.BlogCommand_DELETE_SERIES,
+    SeriesId: req.GetSeriesId(),
+    Caller:   caller,
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.DeleteSeriesResponse{}, nil
+}
+
+func (s *server) AddToSeries(ctx context.Context, req *blogpb.AddToSeriesRequest) (*blogpb.AddToSeriesResponse, error) {
+  fmt.Printf("AddToSeries was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if err := s.checkSeriesBlog(req.GetBlogId(), caller); err != nil {
+    return nil, err
+  }
+
+  series, err := s.executeSeries(ctx, &blogpb.BlogCommand{
+    Op:       blogpb.BlogCommand_ADD_TO_SERIES,
+    SeriesId: req.GetSeriesId(),
+    BlogId:   req.GetBlogId(),
+    Position: req.GetPosition(),
+    Caller:   caller,
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.AddToSeriesResponse{
+    Series: series,
+  }, nil
+}
+
+func (s *server) MoveInSeries(ctx context.Context, req *blogpb.MoveInSeriesRequest) (*blogpb.MoveInSeriesResponse, error) {
+  fmt.Printf("MoveInSeries was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  series, err := s.executeSeries(ctx, &blogpb.BlogCommand{
+    Op:       blogpb.BlogCommand_MOVE_IN_SERIES,
+    SeriesId: req.GetSeriesId(),
+    BlogId:   req.GetBlogId(),
+    Position: req.GetPosition(),
+    Caller:   caller,
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.MoveInSeriesResponse{
+    Series: series,
+  }, nil
+}
+
+func (s *server) RemoveFromSeries(ctx context.Context, req *blogpb.RemoveFromSeriesRequest) (*blogpb.RemoveFromSeriesResponse, error) {
+  fmt.Printf("RemoveFromSeries was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  series, err := s.executeSeries(ctx, &blogpb.BlogCommand{
+    Op:       blogpb.BlogCommand_REMOVE_FROM_SERIES,
+    SeriesId: req.GetSeriesId(),
+    BlogId:   req.GetBlogId(),
+    Caller:   caller,
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.RemoveFromSeriesResponse{
+    Series: series,
+  }, nil
+}
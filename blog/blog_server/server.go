@@ -1,24 +1,67 @@
 package main
 
 import(
+  "flag"
   "fmt"
   "log"
   "net"
+  "net/http"
   "os"
   "context"
   "os/signal"
+  "strings"
+  "sync"
+  "time"
   "encoding/binary"
 
   "github.com/villegasl/go_grpc_course/blog/blogpb"
   "github.com/boltdb/bolt"
-  "github.com/golang/protobuf/proto"
   "google.golang.org/grpc"
   "google.golang.org/grpc/status"
   "google.golang.org/grpc/codes"
 )
 
 type server struct{
-  db *bolt.DB
+  // mu guards db, which is replaced when the database is compacted.
+  // Requests hold it for reading through view and update.
+  mu   sync.RWMutex
+  db   *bolt.DB
+  path string
+
+  // changes is notified after every write transaction
+  changes notifier
+  // replica is set when the server follows a leader
+  replica *replica
+  // cluster is set in cluster mode, where writes go through Raft
+  cluster *cluster
+  // shards is set when the blogs are split across several files
+  shards *shardSet
+
+  // batchSize and batchDelay configure the group commit of creates
+  batchSize  int
+  batchDelay time.Duration
+
+  // cache holds the blogs read recently, nil when caching is disabled
+  cache *blogCache
+  // moderator screens the blogs before they are stored, if set
+  moderator moderator
+  // tokens maps bearer tokens to caller identities, ACLs are only enforced
+  // when it is set
+  tokens map[string]string
+  // admins are the identities allowed to call the maintenance RPCs
+  admins map[string]bool
+  // replicators are the identities of the followers, allowed to stream the
+  // changelog
+  replicators map[string]bool
+  // raftNodes are the identities of the nodes of the cluster, allowed to
+  // call the Raft RPCs
+  raftNodes map[string]bool
+  // views counts the reads of ReadBlog until they are flushed, if set
+  views *viewCounter
+  // related indexes the words of the blogs for RelatedBlogs
+  related *relatedIndex
+  // quota limits the storage of the blogs, nil for no limit
+  quota *blogpb.StorageQuota
 }
 
 type blogItem struct {
@@ -32,99 +75,173 @@ func NewBlogServer(filepath string) *server {
   // Open the Bolt database "blog.db" located in the filepath directory.
   // It will be created if it doesn't exist.
   fmt.Println("Connecting to Bolt")
-  BoltDB, err := bolt.Open(filepath+"/blog.db", 0600, nil)
+  path := filepath+"/blog.db"
+  BoltDB, err := bolt.Open(path, 0600, nil)
   if err != nil {
     log.Fatal(err)
   }
-  return &server{BoltDB}
+  return &server{
+    db:   BoltDB,
+    path: path,
+  }
 }
 
 func (s *server) Close() {
   fmt.Println("Closing Bolt")
+  s.mu.Lock()
+  defer s.mu.Unlock()
   s.db.Close()
+  if s.shards != nil {
+    for _, db := range s.shards.dbs {
+      db.Close()
+    }
+  }
+}
+
+// view runs fn in a read-only transaction of the current database.
+func (s *server) view(fn func(*bolt.Tx) error) error {
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  return s.db.View(fn)
+}
+
+// update runs fn in a read-write transaction of the current database.
+func (s *server) update(fn func(*bolt.Tx) error) error {
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  err := s.db.Update(fn)
+  if err == nil {
+    s.changes.notify()
+  }
+  return err
+}
+
+// execute runs a write, committing it through Raft first in cluster mode.
+func (s *server) execute(ctx context.Context, cmd *blogpb.BlogCommand) (*blogpb.Blog, error) {
+  s.limit(cmd)
+  if s.cluster != nil {
+    return s.cluster.apply(ctx, cmd)
+  }
+  if s.shards != nil {
+    blog, err := s.executeSharded(cmd)
+    s.written(cmd, blog)
+    return blog, err
+  }
+  var blog *blogpb.Blog
+  fn := func(tx *bolt.Tx) error {
+    var err error
+    blog, err = applyCommand(tx, cmd)
+    return err
+  }
+  if cmd.GetOp() == blogpb.BlogCommand_CREATE {
+    // creates don't depend on each other, so they can share a transaction
+    err := s.batchBlog(0, fn)
+    s.written(cmd, blog)
+    return blog, err
+  }
+  err := s.update(fn)
+  s.written(cmd, blog)
+  return blog, err
+}
+
+// written drops what the server derived from the blog cmd wrote, blog being
+// the result of the command.
+func (s *server) written(cmd *blogpb.BlogCommand, blog *blogpb.Blog) {
+  // views are read apart from the cached blogs
+  if isSeriesCommand(cmd) || cmd.GetOp() == blogpb.BlogCommand_VIEW {
+    return
+  }
+  id := commandBlogID(cmd)
+  if id == 0 {
+    // creates only have an id once they ran
+    id = blog.GetId()
+  }
+  s.cache.invalidate(id)
+  switch cmd.GetOp() {
+  case blogpb.BlogCommand_CREATE, blogpb.BlogCommand_UPDATE, blogpb.BlogCommand_DELETE, blogpb.BlogCommand_COMMIT_CONTENT:
+    s.related.touch(id)
+  }
 }
 
+// setupDB brings the database up to the latest schema version, creating the
+// buckets of a new database on the way.
 func (s *server) setupDB() {
-  s.db.Update(func(tx *bolt.Tx) error {
-    _, err := tx.CreateBucketIfNotExists([]byte("Blog"))
-    if err != nil {
-      return fmt.Errorf("Could not create bucket: %s", err)
-    }
-    return nil
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  _, err := migrateDB(s.db, false, func(format string, args ...interface{}) {
+    fmt.Printf("  "+format+"\n", args...)
   })
+  if err != nil {
+    log.Fatalf("Could not migrate database: %v", err)
+  }
+  if err := checkDataKeys(s.db); err != nil {
+    log.Fatalf("Could not open the database: %v", err)
+  }
 }
 
 func (s *server) ListBlog(req *blogpb.ListBlogRequest, stream blogpb.BlogService_ListBlogServer) error {
   fmt.Printf("ListBlog was invoked\n\n")
+  caller, err := s.caller(stream.Context())
+  if err != nil {
+    return err
+  }
+  if req.GetAsOf() != 0 {
+    return s.listBlogAsOf(req, stream, caller)
+  }
 
-  return s.db.View(func(tx *bolt.Tx) error {
-    b := tx.Bucket([]byte("Blog"))
-    c := b.Cursor()
-
-    for k, v := c.First(); k != nil; k, v = c.Next() {
-      blog := &blogpb.Blog{}
-      err := proto.Unmarshal(v, blog)
-      if err != nil {
-        return status.Error(codes.Internal, fmt.Sprintf("Unmarshal error: %v\n", err))
-      }
-      stream.Send(&blogpb.ListBlogResponse {
-        Blog: blog,
-      })
+  var sendErr error
+  err = s.eachBlog(func(blog *blogpb.Blog) error {
+    // skip the blogs the caller can't see
+    if s.checkRead(blog, caller) != nil {
+      return nil
     }
-    return nil
+    sendErr = stream.Send(&blogpb.ListBlogResponse {
+      Blog: s.visibleBlog(localize(blog, req.GetLocales()), caller),
+    })
+    return sendErr
   })
+  if sendErr != nil {
+    return sendErr
+  }
+  if err != nil {
+    return status.Error(codes.Internal, fmt.Sprintf("Unmarshal error: %v\n", err))
+  }
+  return nil
 }
 
 func (s *server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*blogpb.DeleteBlogResponse, error) {
   fmt.Printf("DeleteBlog was invoked with: %v\n\n", req)
-  id := uitob(req.GetBlogId())
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  id := req.GetBlogId()
 
   // printing all the blogs for debuging purposes
-  err := s.db.View(func(tx *bolt.Tx) error {
-    fmt.Printf("Before deleting blog number %v\n\n", req.GetBlogId())
-    b := tx.Bucket([]byte("Blog"))
-    c := b.Cursor()
-    for k, v := c.First(); k != nil; k, v = c.Next() {
-      blog := &blogpb.Blog{}
-      err := proto.Unmarshal(v, blog)
-      if err != nil {
-        return err
-      }
-      fmt.Printf("Key=%v, Value=%v\n", k, blog)
-    }
+  fmt.Printf("Before deleting blog number %v\n\n", id)
+  err = s.eachBlog(func(blog *blogpb.Blog) error {
+    fmt.Printf("Key=%v, Value=%v\n", uitob(blog.GetId()), blog)
     return nil
   })
   if err != nil {
     log.Fatalf("Something went wrong: %v\n\n", err)
   }
 
-  err = s.db.Update(func(tx *bolt.Tx) error {
-    b := tx.Bucket([]byte("Blog"))
-
-    blogBytes := b.Get(id)
-    if blogBytes == nil {
-      fmt.Printf("Could not find blog with id %v\n\n", btoui(id))
-      return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", btoui(id)))
-    }
-
-    return b.Delete(id)
+  _, err = s.execute(ctx, &blogpb.BlogCommand{
+    Op:     blogpb.BlogCommand_DELETE,
+    BlogId: id,
+    Caller: caller,
   })
   if err != nil {
     return nil, err
   }
   // printing all the blogs for debuging purposes
-  err = s.db.View(func(tx *bolt.Tx) error {
-    fmt.Printf("After deleting blog number %v\n\n", req.GetBlogId())
-    b := tx.Bucket([]byte("Blog"))
-    c := b.Cursor()
-    for k, v := c.First(); k != nil; k, v = c.Next() {
-      blog := &blogpb.Blog{}
-      err := proto.Unmarshal(v, blog)
-      if err != nil {
-        return err
-      }
-      fmt.Printf("Key=%v, Value=%v\n", k, blog)
-    }
+  fmt.Printf("After deleting blog number %v\n\n", id)
+  err = s.eachBlog(func(blog *blogpb.Blog) error {
+    fmt.Printf("Key=%v, Value=%v\n", uitob(blog.GetId()), blog)
     return nil
   })
   if err != nil {
@@ -138,82 +255,149 @@ func (s *server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest)
 
 func (s *server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*blogpb.UpdateBlogResponse, error) {
   fmt.Printf("UpdateBlog was invoked with: %v\n\n", req)
-  
-  blog := req.GetBlog()
-  id := uitob(blog.GetId())
-
-  err := s.db.Update(func(tx *bolt.Tx) error {
-    b := tx.Bucket([]byte("Blog"))
-
-    blogBytes := b.Get(id)
-    if blogBytes == nil {
-      fmt.Printf("Could not find blog with id %v\n\n", btoui(id))
-      return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", btoui(id)))
-    }
-
-    // Marshal blog post into protocol buffer
-    serializedBlogPost, err := proto.Marshal(blog)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if l := req.GetBlog().GetLocale(); l != "" && !strings.EqualFold(l, req.GetBlog().GetOriginalLocale()) {
+    return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("The blog is in its %v translation, change it with UpsertBlogTranslation", l))
+  }
+  if caller != "" {
+    // don't let moderation quarantine what the caller can't change anyway
+    id := req.GetBlog().GetId()
+    err := s.viewBlog(id, func(tx *bolt.Tx) error {
+      current, err := getBlog(tx, id)
+      if err != nil {
+        return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+      }
+      if current == nil {
+        return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", id))
+      }
+      return checkAccess(current, caller, true)
+    })
     if err != nil {
-      return err
+      return nil, err
     }
-    // save blog post to the DB
-    return b.Put(id, serializedBlogPost)
+  }
+
+  quarantineID, err := s.moderate(ctx, req.GetBlog(), caller, true)
+  if err != nil {
+    return nil, err
+  }
+  if quarantineID != 0 {
+    return &blogpb.UpdateBlogResponse {
+      QuarantineId: quarantineID,
+    }, nil
+  }
+
+  // save blog post to the DB
+  blog, err := s.execute(ctx, &blogpb.BlogCommand{
+    Op:     blogpb.BlogCommand_UPDATE,
+    Blog:   req.GetBlog(),
+    Caller: caller,
   })
   if err != nil {
     return nil, err
   }
   fmt.Printf("Blog %v updated successfully\n\n", blog.GetId())
   return &blogpb.UpdateBlogResponse {
-    Blog: blog,
+    Blog: localize(blog, nil),
   }, nil
 }
 
 func (s *server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
   fmt.Printf("ReadBlog was invoked with: %v\n\n", req)
+  caller, err := s.caller(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if req.GetAsOf() != 0 {
+    return s.readBlogAsOf(req, caller)
+  }
 
-  id := uitob(req.GetBlogId())
-  blog := &blogpb.Blog{}
-
-  err := s.db.View(func(tx *bolt.Tx) error {
-    b := tx.Bucket([]byte("Blog"))
-
-    blogBytes := b.Get(id)
-    if blogBytes == nil {
-      return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", btoui(id)))
+  id := req.GetBlogId()
+  blog, gen := s.cache.get(id)
+  if blog == nil {
+    err = s.viewBlog(id, func(tx *bolt.Tx) error {
+      var err error
+      blog, err = getBlog(tx, id)
+      if err != nil {
+        return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+      }
+      if blog == nil {
+        return status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", id))
+      }
+      return nil
+    })
+    if err != nil {
+      return nil, err
     }
-    err := proto.Unmarshal(blogBytes, blog)
+    s.cache.add(blog, gen)
+  } else {
+    // the cached blog has the views it had when it was read
+    err = s.viewBlog(id, func(tx *bolt.Tx) error {
+      blog.Views = blogViews(tx, id, blog.GetViews())
+      return nil
+    })
     if err != nil {
-      return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v\n", err))
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read the views: %v", err))
     }
-    return nil
-  })
-  if err != nil {
+  }
+  if err := s.checkRead(blog, caller); err != nil {
     return nil, err
   }
+  s.views.add(id)
 
-  return &blogpb.ReadBlogResponse {
-    Blog: blog,
-  }, nil
+  res := &blogpb.ReadBlogResponse {
+    Blog: s.visibleBlog(localize(blog, req.GetLocales()), caller),
+  }
+  if req.GetWithNavigation() {
+    res.Navigation, err = s.seriesNavigation(id, caller, req.GetLocales())
+    if err != nil {
+      return nil, status.Error(codes.Internal, fmt.Sprintf("Could not read the series: %v", err))
+    }
+  }
+  return res, nil
 }
 
 func (s *server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
   fmt.Printf("CreateBlog was invoked with: %v\n\n", req)
-  blog := req.GetBlog()
-
-  err := s.db.Update(func(tx *bolt.Tx) error {
-    b := tx.Bucket([]byte("Blog"))
-    // Generate ID for the blog post.
-    blog.Id, _ = b.NextSequence()
-    // Marshal blog post into protocol buffer
-    serializedBlogPost, err := proto.Marshal(blog)
-    if err != nil {
-      return err
-    }
-    // save blog post to the DB
-    return b.Put(uitob(blog.Id), serializedBlogPost)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  req.GetBlog().Acl = s.newBlogACL(req.GetBlog(), caller)
+  req.GetBlog().Likes = 0
+  req.GetBlog().Views = 0
+  req.GetBlog().Locale = ""
+  req.GetBlog().TranslatedLocales = nil
+  req.GetBlog().Translations = nil
+
+  quarantineID, err := s.moderate(ctx, req.GetBlog(), caller, false)
+  if err != nil {
+    return nil, err
+  }
+  if quarantineID != 0 {
+    return &blogpb.CreateBlogResponse {
+      QuarantineId: quarantineID,
+    }, nil
+  }
+
+  // save blog post to the DB
+  blog, err := s.execute(ctx, &blogpb.BlogCommand{
+    Op:   blogpb.BlogCommand_CREATE,
+    Blog: req.GetBlog(),
   })
   if err != nil {
-    log.Fatalf("Could not create blog: %v", err)
+    if _, ok := status.FromError(err); ok {
+      return nil, err
+    }
     return nil, status.Error(codes.Internal, fmt.Sprintf("Internal error: %v", err))
   }
   return &blogpb.CreateBlogResponse {
@@ -231,25 +415,189 @@ func btoui(b []byte) uint64 {
   return binary.BigEndian.Uint64(b)
 }
 
+// dialAddr turns the address a listener is bound to into one that can be
+// dialed locally, replacing unspecified hosts such as 0.0.0.0 by localhost.
+func dialAddr(addr net.Addr) string {
+  tcpAddr, ok := addr.(*net.TCPAddr)
+  if !ok || !tcpAddr.IP.IsUnspecified() {
+    return addr.String()
+  }
+  return fmt.Sprintf("localhost:%d", tcpAddr.Port)
+}
+
 func main() {
   // if we crash the go code, we get the file name and line number
   log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-  blogServer := NewBlogServer("database")
+  // maintenance subcommands work on the database while the server is stopped
+  if len(os.Args) > 1 {
+    switch os.Args[1] {
+    case "compact":
+      runCompact(os.Args[2:])
+      return
+    case "migrate":
+      runMigrate(os.Args[2:])
+      return
+    case "reshard":
+      runReshard(os.Args[2:])
+      return
+    case "bench":
+      runBench(os.Args[2:])
+      return
+    }
+  }
+
+  grpcAddr := flag.String("addr", "0.0.0.0:50051", "address of the gRPC server")
+  dir := flag.String("dir", "database", "directory of the Bolt database")
+  leaderAddr := flag.String("follow", "", "address of a leader to replicate, making this server a read-only follower")
+  followToken := flag.String("follow-token", "", "bearer token sent to the leader when following it, one of its -replication-tokens")
+  httpAddr := flag.String("http", "0.0.0.0:8081", "address of the HTTP/JSON gateway, empty to disable it")
+  grpcWebAddr := flag.String("grpcweb", "0.0.0.0:8082", "address of the gRPC-Web endpoint for browsers, empty to disable it")
+  corsOrigins := flag.String("cors-origins", "", "comma separated list of origins allowed to call the gRPC-Web endpoint, * for any")
+  raftID := flag.String("raft-id", "", "id of this node, enables cluster mode where writes are committed through Raft")
+  raftAdvertise := flag.String("raft-advertise", "", "gRPC address the other nodes of the cluster reach this node at, defaults to the listening address")
+  raftBootstrap := flag.Bool("raft-bootstrap", false, "start a new cluster made of this node, only on the first node of the cluster")
+  raftTokens := flag.String("raft-tokens", "", "JSON file mapping bearer tokens to node identities, allowed to call the Raft RPCs, required with -raft-id, enables authentication like -auth-tokens")
+  raftToken := flag.String("raft-token", "", "bearer token sent to the other nodes of the cluster, one of their -raft-tokens")
+  cacheEntries := flag.Int("cache-entries", 1000, "largest number of blogs kept in the ReadBlog cache, 0 to disable it")
+  cacheBytes := flag.Int64("cache-bytes", 64<<20, "largest encoded size in bytes of the blogs kept in the ReadBlog cache")
+  authTokens := flag.String("auth-tokens", "", "JSON file mapping bearer tokens to caller identities, enables per-blog ACLs")
+  adminTokens := flag.String("admin-tokens", "", "JSON file mapping bearer tokens to admin identities, allowed to call the maintenance RPCs, enables authentication like -auth-tokens")
+  replicationTokens := flag.String("replication-tokens", "", "JSON file mapping bearer tokens to follower identities, allowed to stream the changelog, enables authentication like -auth-tokens")
+  moderationRules := flag.String("moderation-rules", "", "JSON file of keyword and regular expression rules screening new and updated blogs")
+  shards := flag.Int("shards", 0, "number of Bolt files the blogs are split across, 0 to keep them in blog.db")
+  batchSize := flag.Int("batch-size", 0, "largest number of concurrent creates committed in one transaction, 0 for one transaction per create")
+  batchDelay := flag.Duration("batch-delay", 10*time.Millisecond, "longest time a create waits for others to join its transaction")
+  viewsFlush := flag.Duration("views-flush", 10*time.Second, "how often the views counted by ReadBlog are written, 0 to not count views")
+  compression := flag.Int("compression-threshold", defaultCompressionThreshold, "size in bytes from which the serialized blogs are compressed with snappy, 0 to store them uncompressed")
+  quotaPosts := flag.Uint64("quota-posts-per-author", 0, "largest number of blogs an author can have, 0 for no limit")
+  quotaBytes := flag.Uint64("quota-bytes-per-author", 0, "largest number of bytes the blogs of an author can take, 0 for no limit")
+  quotaTotal := flag.Uint64("quota-total-bytes", 0, "largest number of bytes all the blogs can take, 0 for no limit")
+  masterKeyFile := flag.String("master-key-file", "", "file holding the base64 encoded master key that encrypts the stored blogs, defaults to $"+masterKeyEnv)
+  flag.Parse()
+
+  if *raftID != "" && *leaderAddr != "" {
+    log.Fatalf("-raft-id and -follow can't be used together")
+  }
+  if *raftID != "" && (*raftTokens == "" || *raftToken == "") {
+    log.Fatalf("-raft-id needs -raft-tokens and -raft-token, the Raft RPCs are served on the client port")
+  }
+  if *shards > 0 && (*raftID != "" || *leaderAddr != "") {
+    log.Fatalf("-shards can't be used with -raft-id or -follow")
+  }
+
+  if err := useMasterKey(*masterKeyFile); err != nil {
+    log.Fatalf("Could not load the master key: %v", err)
+  }
+  compressionThreshold = *compression
+
+  blogServer := NewBlogServer(*dir)
   defer blogServer.Close()
 
   // create Blog collection
   blogServer.setupDB()
+  if *shards > 0 {
+    if err := blogServer.setupShards(*dir, *shards); err != nil {
+      log.Fatalf("Could not open the shards: %v", err)
+    }
+  } else if err := blogServer.checkUnsharded(); err != nil {
+    log.Fatalf("Could not open the database: %v", err)
+  }
+  blogServer.setBatching(*batchSize, *batchDelay)
+  blogServer.cache = newBlogCache(*cacheEntries, *cacheBytes)
+  blogServer.related = newRelatedIndex()
+  if *quotaPosts > 0 || *quotaBytes > 0 || *quotaTotal > 0 {
+    blogServer.quota = &blogpb.StorageQuota{
+      MaxPostsPerAuthor: *quotaPosts,
+      MaxBytesPerAuthor: *quotaBytes,
+      MaxTotalBytes:     *quotaTotal,
+    }
+  }
+  if *authTokens != "" {
+    tokens, err := loadAuthTokens(*authTokens)
+    if err != nil {
+      log.Fatalf("Could not load the auth tokens: %v", err)
+    }
+    blogServer.tokens = tokens
+  }
+  if *adminTokens != "" {
+    tokens, err := loadAuthTokens(*adminTokens)
+    if err != nil {
+      log.Fatalf("Could not load the admin tokens: %v", err)
+    }
+    blogServer.addAdmins(tokens)
+  }
+  if *replicationTokens != "" {
+    tokens, err := loadAuthTokens(*replicationTokens)
+    if err != nil {
+      log.Fatalf("Could not load the replication tokens: %v", err)
+    }
+    blogServer.addReplicators(tokens)
+  }
+  if *raftTokens != "" {
+    tokens, err := loadAuthTokens(*raftTokens)
+    if err != nil {
+      log.Fatalf("Could not load the Raft tokens: %v", err)
+    }
+    blogServer.addRaftNodes(tokens)
+  }
+  if *moderationRules != "" {
+    m, err := loadRulesModerator(*moderationRules)
+    if err != nil {
+      log.Fatalf("Could not load the moderation rules: %v", err)
+    }
+    blogServer.moderator = m
+  }
+
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  if *leaderAddr != "" {
+    cc, err := grpc.Dial(*leaderAddr, grpc.WithInsecure())
+    if err != nil {
+      log.Fatalf("Could not connect to the leader: %v", err)
+    }
+    defer cc.Close()
+    blogServer.replica = newReplica(*leaderAddr, *followToken, cc)
+    go blogServer.follow(ctx)
+  }
+
+  if *viewsFlush > 0 {
+    blogServer.views = newViewCounter()
+    go blogServer.flushViewsEvery(ctx, *viewsFlush)
+  }
+  go blogServer.recompress(ctx)
 
   fmt.Println("Blog Service Started")
 
-  lis, err := net.Listen("tcp", "0.0.0.0:50051")
+  lis, err := net.Listen("tcp", *grpcAddr)
   if err != nil {
     log.Fatalf("Failed to listen: %v", err)
   }
 
   s := grpc.NewServer()
   blogpb.RegisterBlogServiceServer(s, blogServer)
+  blogpb.RegisterBlogAdminServiceServer(s, blogServer)
+  blogpb.RegisterReplicationServiceServer(s, blogServer)
+  blogpb.RegisterClusterServiceServer(s, blogServer)
+
+  if *raftID != "" {
+    // the nodes of a cluster talk through the gRPC server
+    advertise := *raftAdvertise
+    if advertise == "" {
+      advertise = dialAddr(lis.Addr())
+    }
+    transport := newGrpcTransport(advertise, *raftToken)
+    blogpb.RegisterRaftTransportServer(s, &raftTransportServer{t: transport, peer: blogServer.raftNode})
+    blogServer.cluster, err = newCluster(blogServer, clusterConfig{
+      id:        *raftID,
+      dir:       *dir + "/raft",
+      bootstrap: *raftBootstrap,
+      transport: transport,
+    })
+    if err != nil {
+      log.Fatalf("Could not start the cluster: %v", err)
+    }
+  }
 
   go func() {
     fmt.Println("Starting Server...\n")
@@ -258,13 +606,62 @@ func main() {
     }
   }()
 
+  var httpServer *http.Server
+  if *httpAddr != "" {
+    // the gateway reaches the gRPC server through the loopback interface
+    cc, err := grpc.Dial(dialAddr(lis.Addr()), grpc.WithInsecure())
+    if err != nil {
+      log.Fatalf("Could not connect the gateway: %v", err)
+    }
+    defer cc.Close()
+
+    httpServer = &http.Server{
+      Addr:    *httpAddr,
+      Handler: newGateway(cc),
+    }
+    go func() {
+      fmt.Printf("Starting HTTP/JSON gateway on %v...\n\n", *httpAddr)
+      if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+        log.Fatalf("failed to serve HTTP: %v", err)
+      }
+    }()
+  }
+
+  var grpcWebServer *http.Server
+  if *grpcWebAddr != "" {
+    grpcWebServer = &http.Server{
+      Addr:    *grpcWebAddr,
+      Handler: newGrpcWebHandler(blogServer, strings.Split(*corsOrigins, ",")),
+    }
+    go func() {
+      fmt.Printf("Starting gRPC-Web endpoint on %v...\n\n", *grpcWebAddr)
+      if err := grpcWebServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+        log.Fatalf("failed to serve gRPC-Web: %v", err)
+      }
+    }()
+  }
+
   // Wait for Control C to exit
   ch := make(chan os.Signal, 1)
   signal.Notify(ch, os.Interrupt)
 
   // Block until a signal is received
   <-ch
+  if httpServer != nil {
+    fmt.Println("Stopping the HTTP/JSON gateway")
+    httpServer.Close()
+  }
+  if grpcWebServer != nil {
+    fmt.Println("Stopping the gRPC-Web endpoint")
+    grpcWebServer.Close()
+  }
+  // the leader still has to be there to record the last views
+  blogServer.flushViews(context.Background())
+  if blogServer.cluster != nil {
+    blogServer.cluster.Close()
+  }
   fmt.Println("Stopping the server")
+  cancel()
   s.Stop()
   fmt.Println("Closing the listener")
   lis.Close()
@@ -0,0 +1,55 @@
+package main
+
+import(
+  "context"
+  "testing"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "google.golang.org/grpc/metadata"
+)
+
+// newTestServer opens a server on the database in dir, creating it if needed.
+func newTestServer(t *testing.T, dir string) *server {
+  t.Helper()
+  s := NewBlogServer(dir)
+  s.setupDB()
+  return s
+}
+
+// eventually calls fn until it succeeds, failing the test with its last
+// error if it doesn't within timeout.
+func eventually(t *testing.T, timeout time.Duration, fn func() error) {
+  t.Helper()
+  deadline := time.Now().Add(timeout)
+  for {
+    err := fn()
+    if err == nil {
+      return
+    }
+    if time.Now().After(deadline) {
+      t.Fatal(err)
+    }
+    time.Sleep(20 * time.Millisecond)
+  }
+}
+
+// withToken returns ctx carrying token the way a client sends it.
+func withToken(ctx context.Context, token string) context.Context {
+  return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func createTestBlog(t *testing.T, ctx context.Context, s *server, title string) *blogpb.Blog {
+  t.Helper()
+  res, err := s.CreateBlog(ctx, &blogpb.CreateBlogRequest{
+    Blog: &blogpb.Blog{
+      AuthorId: "luis",
+      Title:    title,
+      Content:  "Content of " + title,
+    },
+  })
+  if err != nil {
+    t.Fatalf("CreateBlog(%q): %v", title, err)
+  }
+  return res.GetBlog()
+}
@@ -0,0 +1,636 @@
+package main
+
+import(
+  "errors"
+  "flag"
+  "fmt"
+  "hash/fnv"
+  "log"
+  "os"
+  "path/filepath"
+  "sync"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+)
+
+const (
+  // idBlockSize is the number of blog ids reserved at once in the main
+  // database, so creates only need a write transaction on their shard. The
+  // unused ids of a block are skipped after a restart.
+  idBlockSize = 128
+  // reshardBatchSize is the number of blogs copied per transaction when
+  // resharding.
+  reshardBatchSize = 1000
+)
+
+// shardCountKey, in the metadata bucket of the main database, is the number
+// of shard files the blogs are spread across, absent when they are stored in
+// the main database itself.
+var shardCountKey = []byte("shard_count")
+
+// errDone stops the readers of mergeShards once the merge is over.
+var errDone = errors.New("done")
+
+// shardSet spreads the blogs across several Bolt files so that writes to
+// different shards don't wait for each other. The main database keeps the
+// metadata and the sequence of the Blog bucket, which allocates the ids.
+type shardSet struct {
+  // dbs and paths are guarded by server.mu like server.db
+  dbs   []*bolt.DB
+  paths []string
+  ids   idAllocator
+}
+
+func shardsDir(dir string) string {
+  return filepath.Join(dir, "shards")
+}
+
+func shardPath(dir string, i int) string {
+  return filepath.Join(dir, fmt.Sprintf("blog-%d.db", i))
+}
+
+// shardIndex returns the shard of n that holds blog id.
+func shardIndex(id uint64, n int) int {
+  h := fnv.New64a()
+  h.Write(uitob(id))
+  return int(h.Sum64() % uint64(n))
+}
+
+// openShards opens (creating them if needed) the n shard files in dir and
+// brings them up to the latest schema version.
+func openShards(dir string, n int) ([]*bolt.DB, []string, error) {
+  if err := os.MkdirAll(dir, 0700); err != nil {
+    return nil, nil, err
+  }
+  var dbs []*bolt.DB
+  var paths []string
+  closeAll := func() {
+    for _, db := range dbs {
+      db.Close()
+    }
+  }
+  for i := 0; i < n; i++ {
+    path := shardPath(dir, i)
+    db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+    if err != nil {
+      closeAll()
+      return nil, nil, err
+    }
+    dbs = append(dbs, db)
+    paths = append(paths, path)
+    _, err = migrateDB(db, false, func(format string, args ...interface{}) {
+      fmt.Printf("  shard %v: "+format+"\n", append([]interface{}{i}, args...)...)
+    })
+    if err == nil {
+      err = checkDataKeys(db)
+    }
+    if err != nil {
+      closeAll()
+      return nil, nil, fmt.Errorf("shard %v: %v", i, err)
+    }
+  }
+  return dbs, paths, nil
+}
+
+// shardCount returns the number of shards recorded in the main database.
+func shardCount(tx *bolt.Tx) int {
+  b := tx.Bucket(metaBucket)
+  if b == nil {
+    return 0
+  }
+  v := b.Get(shardCountKey)
+  if v == nil {
+    return 0
+  }
+  return int(btoui(v))
+}
+
+// setupShards switches the server to n shards under dir. A database that
+// already holds blogs can only change its number of shards offline with the
+// reshard subcommand.
+func (s *server) setupShards(dir string, n int) error {
+  err := s.update(func(tx *bolt.Tx) error {
+    current := shardCount(tx)
+    if current == n {
+      return nil
+    }
+    if current != 0 {
+      return fmt.Errorf("the database is split in %v shards, run blog_server reshard to change it to %v", current, n)
+    }
+    if k, _ := tx.Bucket(blogBucket).Cursor().First(); k != nil {
+      return fmt.Errorf("the database holds blogs, run blog_server reshard to split it in %v shards", n)
+    }
+    return tx.Bucket(metaBucket).Put(shardCountKey, uitob(uint64(n)))
+  })
+  if err != nil {
+    return err
+  }
+  dbs, paths, err := openShards(shardsDir(dir), n)
+  if err != nil {
+    return err
+  }
+  s.shards = &shardSet{
+    dbs:   dbs,
+    paths: paths,
+    ids:   idAllocator{reserve: s.reserveIDs},
+  }
+  return nil
+}
+
+// checkUnsharded fails if the database was split in shards, since the server
+// would not see the blogs.
+func (s *server) checkUnsharded() error {
+  return s.view(func(tx *bolt.Tx) error {
+    if n := shardCount(tx); n != 0 {
+      return fmt.Errorf("the database is split in %v shards, start the server with -shards %v", n, n)
+    }
+    return nil
+  })
+}
+
+// reserveIDs takes n ids from the sequence of the main database and returns
+// the first one.
+func (s *server) reserveIDs(n uint64) (uint64, error) {
+  var first uint64
+  err := s.update(func(tx *bolt.Tx) error {
+    b := tx.Bucket(blogBucket)
+    first = b.Sequence() + 1
+    return b.SetSequence(b.Sequence() + n)
+  })
+  return first, err
+}
+
+// idAllocator hands out blog ids from blocks reserved in the main database.
+type idAllocator struct {
+  reserve func(n uint64) (uint64, error)
+
+  mu    sync.Mutex
+  next  uint64
+  limit uint64
+}
+
+func (a *idAllocator) nextID() (uint64, error) {
+  a.mu.Lock()
+  defer a.mu.Unlock()
+  if a.next == a.limit {
+    first, err := a.reserve(idBlockSize)
+    if err != nil {
+      return 0, err
+    }
+    a.next, a.limit = first, first+idBlockSize
+  }
+  id := a.next
+  a.next++
+  return id, nil
+}
+
+// viewBlog runs fn in a read-only transaction of the database holding blog id.
+func (s *server) viewBlog(id uint64, fn func(*bolt.Tx) error) error {
+  if s.shards == nil {
+    return s.view(fn)
+  }
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  return s.shards.dbs[shardIndex(id, len(s.shards.dbs))].View(fn)
+}
+
+// updateBlog runs fn in a read-write transaction of the database holding
+// blog id.
+func (s *server) updateBlog(id uint64, fn func(*bolt.Tx) error) error {
+  if s.shards == nil {
+    return s.update(fn)
+  }
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  err := s.shards.dbs[shardIndex(id, len(s.shards.dbs))].Update(fn)
+  if err == nil {
+    s.changes.notify()
+  }
+  return err
+}
+
+// executeSharded runs a write on the shard of its blog. Creates take their
+// id from the allocator first since every shard has its own sequence.
+func (s *server) executeSharded(cmd *blogpb.BlogCommand) (*blogpb.Blog, error) {
+  if cmd.GetOp() == blogpb.BlogCommand_CREATE {
+    id, err := s.shards.ids.nextID()
+    if err != nil {
+      return nil, err
+    }
+    blog := cmd.GetBlog()
+    initBlog(blog, id)
+    if err := s.usageElsewhere(cmd, id); err != nil {
+      return nil, err
+    }
+    return blog, s.batchBlog(id, func(tx *bolt.Tx) error {
+      if err := checkQuota(tx, cmd, nil, blog); err != nil {
+        return err
+      }
+      return putBlog(tx, cmd.GetTimestamp(), blog)
+    })
+  }
+  if err := s.usageElsewhere(cmd, commandBlogID(cmd)); err != nil {
+    return nil, err
+  }
+  var blog *blogpb.Blog
+  err := s.updateBlog(commandBlogID(cmd), func(tx *bolt.Tx) error {
+    var err error
+    blog, err = applyCommand(tx, cmd)
+    return err
+  })
+  if err == nil && cmd.GetOp() == blogpb.BlogCommand_DELETE {
+    // the series are in blog.db, a blog left behind by a crash in between
+    // is skipped by the readers of the series
+    err = s.update(func(tx *bolt.Tx) error {
+      return removeBlogFromSeries(tx, cmd.GetTimestamp(), commandBlogID(cmd))
+    })
+  }
+  return blog, err
+}
+
+// viewShards runs fn in a read-only transaction of every database holding
+// blogs, one after the other.
+func (s *server) viewShards(fn func(*bolt.Tx) error) error {
+  if s.shards == nil {
+    return s.view(fn)
+  }
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  for _, db := range s.shards.dbs {
+    if err := db.View(fn); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// fileCount returns the number of database files, the main database and
+// the shards.
+func (s *server) fileCount() int {
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  if s.shards == nil {
+    return 1
+  }
+  return 1 + len(s.shards.dbs)
+}
+
+// viewFiles runs fn in a read-only transaction of every database file, the
+// main database first and then the shards.
+func (s *server) viewFiles(fn func(*bolt.Tx) error) error {
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  if err := s.db.View(fn); err != nil {
+    return err
+  }
+  if s.shards != nil {
+    for _, db := range s.shards.dbs {
+      if err := db.View(fn); err != nil {
+        return err
+      }
+    }
+  }
+  return nil
+}
+
+// updateFile runs fn in a read-write transaction of database file i, in the
+// order of viewFiles.
+func (s *server) updateFile(i int, fn func(*bolt.Tx) error) error {
+  if i == 0 {
+    return s.update(fn)
+  }
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  return s.shards.dbs[i-1].Update(fn)
+}
+
+// eachBlog calls fn for every blog in id order, wherever it is stored.
+func (s *server) eachBlog(fn func(blog *blogpb.Blog) error) error {
+  if s.shards == nil {
+    return s.view(func(tx *bolt.Tx) error {
+      return forEachBlog(tx, fn)
+    })
+  }
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  return mergeShards(s.shards.dbs, fn)
+}
+
+// shardItem is a blog read from a shard, or the error that stopped it.
+type shardItem struct {
+  blog *blogpb.Blog
+  err  error
+}
+
+// mergeShards reads every shard in its own goroutine and calls fn for their
+// blogs in id order. Each shard is already sorted by id, so the next blog is
+// always the smallest of the ones at the head of the shards.
+func mergeShards(dbs []*bolt.DB, fn func(blog *blogpb.Blog) error) error {
+  done := make(chan struct{})
+  defer close(done)
+
+  streams := make([]chan shardItem, len(dbs))
+  for i, db := range dbs {
+    streams[i] = make(chan shardItem, 64)
+    go func(db *bolt.DB, out chan<- shardItem) {
+      defer close(out)
+      err := db.View(func(tx *bolt.Tx) error {
+        return forEachBlog(tx, func(blog *blogpb.Blog) error {
+          select {
+          case out <- shardItem{blog: blog}:
+            return nil
+          case <-done:
+            return errDone
+          }
+        })
+      })
+      if err != nil && err != errDone {
+        select {
+        case out <- shardItem{err: err}:
+        case <-done:
+        }
+      }
+    }(db, streams[i])
+  }
+
+  heads := make([]*blogpb.Blog, len(dbs))
+  pull := func(i int) error {
+    item, ok := <-streams[i]
+    if item.err != nil {
+      return fmt.Errorf("shard %v: %v", i, item.err)
+    }
+    if ok {
+      heads[i] = item.blog
+    } else {
+      heads[i] = nil
+    }
+    return nil
+  }
+  for i := range streams {
+    if err := pull(i); err != nil {
+      return err
+    }
+  }
+  for {
+    min := -1
+    for i, blog := range heads {
+      if blog != nil && (min < 0 || blog.GetId() < heads[min].GetId()) {
+        min = i
+      }
+    }
+    if min < 0 {
+      return nil
+    }
+    if err := fn(heads[min]); err != nil {
+      return err
+    }
+    if err := pull(min); err != nil {
+      return err
+    }
+  }
+}
+
+// runReshard implements the "reshard" maintenance subcommand, which spreads
+// the blogs across a new number of shard files while the server is stopped:
+//
+//   blog_server reshard -dir database -shards 8
+//
+// The blogs of a database that isn't sharded yet are moved out of blog.db.
+// The new shards are written next to the current ones and only replace them
+// once they are complete.
+func runReshard(args []string) {
+  fs := flag.NewFlagSet("reshard", flag.ExitOnError)
+  dir := fs.String("dir", "database", "directory of the Bolt database")
+  n := fs.Int("shards", 0, "number of shards to split the blogs in")
+  masterKeyFile := fs.String("master-key-file", "", "file holding the master key of an encrypted database, defaults to $"+masterKeyEnv)
+  fs.Parse(args)
+  if *n < 1 {
+    log.Fatalf("-shards must be at least 1")
+  }
+  if err := useMasterKey(*masterKeyFile); err != nil {
+    log.Fatalf("Could not load the master key: %v", err)
+  }
+
+  // fail right away if a server is using the database
+  mainDB, err := bolt.Open(filepath.Join(*dir, "blog.db"), 0600, &bolt.Options{Timeout: time.Second})
+  if err != nil {
+    log.Fatalf("Could not open database: %v", err)
+  }
+  defer mainDB.Close()
+  if _, err := migrateDB(mainDB, false, func(format string, args ...interface{}) {
+    fmt.Printf("  "+format+"\n", args...)
+  }); err != nil {
+    log.Fatalf("Could not migrate database: %v", err)
+  }
+
+  var current int
+  mainDB.View(func(tx *bolt.Tx) error {
+    current = shardCount(tx)
+    return nil
+  })
+  if current == *n {
+    fmt.Printf("The database is already split in %v shards\n", current)
+    return
+  }
+
+  var sources []*bolt.DB
+  if current == 0 {
+    sources = []*bolt.DB{mainDB}
+  } else {
+    for i := 0; i < current; i++ {
+      db, err := bolt.Open(shardPath(shardsDir(*dir), i), 0600, &bolt.Options{Timeout: time.Second})
+      if err != nil {
+        log.Fatalf("Could not open shard %v: %v", i, err)
+      }
+      defer db.Close()
+      sources = append(sources, db)
+    }
+  }
+
+  newDir := shardsDir(*dir) + ".new"
+  os.RemoveAll(newDir)
+  targets, _, err := openShards(newDir, *n)
+  if err != nil {
+    log.Fatalf("Could not create the new shards: %v", err)
+  }
+  copied, err := reshard(sources, targets)
+  for _, db := range targets {
+    db.Close()
+  }
+  if err != nil {
+    os.RemoveAll(newDir)
+    log.Fatalf("Could not copy the blogs: %v", err)
+  }
+
+  // swap the directories, then record the new layout
+  oldDir := shardsDir(*dir) + ".old"
+  os.RemoveAll(oldDir)
+  if current != 0 {
+    if err := os.Rename(shardsDir(*dir), oldDir); err != nil {
+      log.Fatalf("Could not move the old shards: %v", err)
+    }
+  }
+  if err := os.Rename(newDir, shardsDir(*dir)); err != nil {
+    log.Fatalf("Could not move the new shards: %v", err)
+  }
+  syncDir(*dir)
+  err = mainDB.Update(func(tx *bolt.Tx) error {
+    if current == 0 {
+      // the blogs now live in the shards, only the sequence stays
+      if err := emptyBucket(tx, blogBucket); err != nil {
+        return err
+      }
+      if err := emptyBucket(tx, changelogBucket); err != nil {
+        return err
+      }
+      for _, name := range blogDataBuckets() {
+        if err := emptyBucket(tx, name); err != nil {
+          return err
+        }
+      }
+      // the shards counted their blogs as they were copied
+      if err := resetUsage(tx); err != nil {
+        return err
+      }
+    }
+    return tx.Bucket(metaBucket).Put(shardCountKey, uitob(uint64(*n)))
+  })
+  if err != nil {
+    log.Fatalf("Could not record the new number of shards: %v", err)
+  }
+  os.RemoveAll(oldDir)
+  fmt.Printf("Moved %v blogs from %v to %v shards\n", copied, len(sources), *n)
+}
+
+// reshard copies the blogs of sources into the shard of targets they belong
+// to and returns how many were copied.
+func reshard(sources, targets []*bolt.DB) (int, error) {
+  copied := 0
+  var batch []*blogpb.Blog
+  flush := func() error {
+    byShard := make(map[int][]*blogpb.Blog)
+    for _, blog := range batch {
+      i := shardIndex(blog.GetId(), len(targets))
+      byShard[i] = append(byShard[i], blog)
+    }
+    for i, blogs := range byShard {
+      err := targets[i].Update(func(tx *bolt.Tx) error {
+        for _, blog := range blogs {
+          if err := putBlog(tx, 0, blog); err != nil {
+            return err
+          }
+        }
+        return nil
+      })
+      if err != nil {
+        return err
+      }
+    }
+    copied += len(batch)
+    batch = batch[:0]
+    return nil
+  }
+  for _, src := range sources {
+    err := src.View(func(tx *bolt.Tx) error {
+      return forEachBlog(tx, func(blog *blogpb.Blog) error {
+        batch = append(batch, blog)
+        if len(batch) == reshardBatchSize {
+          return flush()
+        }
+        return nil
+      })
+    })
+    if err != nil {
+      return copied, err
+    }
+  }
+  if err := flush(); err != nil {
+    return copied, err
+  }
+  return copied, reshardBlogData(sources, targets)
+}
+
+// blogDataBuckets are the buckets keyed by blog id first, besides the blogs
+// themselves, which move along with their blog.
+func blogDataBuckets() [][]byte {
+  return append(append([][]byte(nil), engagementBuckets...), contentBuckets...)
+}
+
+// reshardBlogData copies the likes, views and content chunks of the blogs
+// of sources into the shard of targets their blog belongs to. Sealed values
+// are encrypted again with the data key of their new database file.
+func reshardBlogData(sources, targets []*bolt.DB) error {
+  type kv struct {
+    name, k, v []byte
+  }
+  var batch []kv
+  flush := func() error {
+    byShard := make(map[int][]kv)
+    for _, e := range batch {
+      i := shardIndex(btoui(e.k[:8]), len(targets))
+      byShard[i] = append(byShard[i], e)
+    }
+    for i, entries := range byShard {
+      err := targets[i].Update(func(tx *bolt.Tx) error {
+        for _, e := range entries {
+          v := e.v
+          if isSealedBucket(e.name) {
+            var err error
+            if v, err = sealValue(tx, e.name, e.k, v); err != nil {
+              return err
+            }
+          }
+          if err := tx.Bucket(e.name).Put(e.k, v); err != nil {
+            return err
+          }
+        }
+        return nil
+      })
+      if err != nil {
+        return err
+      }
+    }
+    batch = batch[:0]
+    return nil
+  }
+  for _, src := range sources {
+    for _, name := range blogDataBuckets() {
+      name := name
+      err := src.View(func(tx *bolt.Tx) error {
+        return tx.Bucket(name).ForEach(func(k, v []byte) error {
+          if isSealedBucket(name) {
+            var err error
+            if v, err = openValue(tx, name, k, v); err != nil {
+              return err
+            }
+          }
+          // keys and values are only valid during the transaction
+          batch = append(batch, kv{name, append([]byte(nil), k...), append([]byte(nil), v...)})
+          if len(batch) == reshardBatchSize {
+            return flush()
+          }
+          return nil
+        })
+      })
+      if err != nil {
+        return err
+      }
+    }
+  }
+  return flush()
+}
+
+// emptyBucket deletes every key of a top level bucket but keeps its sequence.
+func emptyBucket(tx *bolt.Tx, name []byte) error {
+  seq := tx.Bucket(name).Sequence()
+  if err := tx.DeleteBucket(name); err != nil {
+    return err
+  }
+  b, err := tx.CreateBucket(name)
+  if err != nil {
+    return err
+  }
+  return b.SetSequence(seq)
+}
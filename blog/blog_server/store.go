@@ -0,0 +1,388 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "sync"
+  "time"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "github.com/golang/protobuf/proto"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// rewriteBatchSize is the number of values read, and at most written again,
+// per transaction by rewriteValues.
+const rewriteBatchSize = 1000
+
+var (
+  blogBucket = []byte("Blog")
+  // changelogBucket records every write to blogBucket, keyed by sequence
+  // number. Followers replicate the store by replaying it.
+  changelogBucket = []byte("Changelog")
+)
+
+// getBlog returns the blog stored under id, or nil if there is none.
+func getBlog(tx *bolt.Tx, id uint64) (*blogpb.Blog, error) {
+  blogBytes := tx.Bucket(blogBucket).Get(uitob(id))
+  if blogBytes == nil {
+    return nil, nil
+  }
+  blog := &blogpb.Blog{}
+  if err := unmarshalValue(tx, blogBucket, uitob(id), blogBytes, blog); err != nil {
+    return nil, err
+  }
+  blog.Views = blogViews(tx, id, blog.GetViews())
+  return blog, nil
+}
+
+// forEachBlog calls fn for every stored blog in id order.
+func forEachBlog(tx *bolt.Tx, fn func(blog *blogpb.Blog) error) error {
+  c := tx.Bucket(blogBucket).Cursor()
+  for k, v := c.First(); k != nil; k, v = c.Next() {
+    blog := &blogpb.Blog{}
+    if err := unmarshalValue(tx, blogBucket, k, v, blog); err != nil {
+      return fmt.Errorf("blog %v: %v", btoui(k), err)
+    }
+    blog.Views = blogViews(tx, btoui(k), blog.GetViews())
+    if err := fn(blog); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// putBlog stores blog under its id and records the write, made at unix
+// nanoseconds at, in the changelog.
+func putBlog(tx *bolt.Tx, at int64, blog *blogpb.Blog) error {
+  if err := storeBlog(tx, blog); err != nil {
+    return err
+  }
+  return appendChange(tx, &blogpb.ChangeEntry{
+    Op:        blogpb.ChangeEntry_PUT,
+    BlogId:    blog.GetId(),
+    Blog:      withoutViews(tx, blog),
+    Timestamp: at,
+  })
+}
+
+// removeBlog deletes blog id and records the delete, made at at, in the
+// changelog.
+func removeBlog(tx *bolt.Tx, at int64, id uint64) error {
+  if err := dropBlog(tx, id); err != nil {
+    return err
+  }
+  return appendChange(tx, &blogpb.ChangeEntry{
+    Op:        blogpb.ChangeEntry_DELETE,
+    BlogId:    id,
+    Timestamp: at,
+  })
+}
+
+// storeBlog and dropBlog write the Blog bucket and keep the storage usage
+// counted along. The views of blog aren't stored, only VIEW commands change
+// them.
+func storeBlog(tx *bolt.Tx, blog *blogpb.Blog) error {
+  blog = withoutViews(tx, blog)
+  if err := trackUsage(tx, blog); err != nil {
+    return err
+  }
+  return putMessage(tx, blogBucket, uitob(blog.GetId()), blog)
+}
+
+func dropBlog(tx *bolt.Tx, id uint64) error {
+  if err := untrackUsage(tx, id); err != nil {
+    return err
+  }
+  return tx.Bucket(blogBucket).Delete(uitob(id))
+}
+
+// putMessage stores msg under key k of bucket name, compressed and
+// encrypted as configured.
+func putMessage(tx *bolt.Tx, name, k []byte, msg proto.Message) error {
+  v, err := proto.Marshal(msg)
+  if err != nil {
+    return err
+  }
+  if v, err = sealValue(tx, name, k, compressValue(name, v)); err != nil {
+    return err
+  }
+  return tx.Bucket(name).Put(k, v)
+}
+
+// unmarshalValue decodes into msg the value v stored under key k of bucket
+// name.
+func unmarshalValue(tx *bolt.Tx, name, k, v []byte, msg proto.Message) error {
+  v, err := openValue(tx, name, k, v)
+  if err != nil {
+    return err
+  }
+  if v, err = decompressValue(v); err != nil {
+    return err
+  }
+  return proto.Unmarshal(v, msg)
+}
+
+// appendChange adds entry at the end of the changelog. Entries without a
+// sequence number get the next one, and the current time if they have no
+// timestamp either. Commands carry the time the leader received them, so
+// that every node of a cluster records the same.
+func appendChange(tx *bolt.Tx, entry *blogpb.ChangeEntry) error {
+  b := tx.Bucket(changelogBucket)
+  if entry.GetSeq() == 0 {
+    seq, err := b.NextSequence()
+    if err != nil {
+      return err
+    }
+    entry.Seq = seq
+    if entry.GetTimestamp() == 0 {
+      entry.Timestamp = time.Now().UnixNano()
+    }
+  } else if err := b.SetSequence(entry.GetSeq()); err != nil {
+    return err
+  }
+  return putMessage(tx, changelogBucket, uitob(entry.GetSeq()), entry)
+}
+
+// lastChangeSeq returns the sequence number of the last changelog entry.
+func lastChangeSeq(tx *bolt.Tx) uint64 {
+  return tx.Bucket(changelogBucket).Sequence()
+}
+
+// readChanges returns up to max changelog entries starting at from.
+func readChanges(tx *bolt.Tx, from uint64, max int) ([]*blogpb.ChangeEntry, error) {
+  var entries []*blogpb.ChangeEntry
+  c := tx.Bucket(changelogBucket).Cursor()
+  for k, v := c.Seek(uitob(from)); k != nil && len(entries) < max; k, v = c.Next() {
+    entry := &blogpb.ChangeEntry{}
+    if err := unmarshalValue(tx, changelogBucket, k, v, entry); err != nil {
+      return nil, fmt.Errorf("changelog entry %v: %v", btoui(k), err)
+    }
+    entries = append(entries, entry)
+  }
+  return entries, nil
+}
+
+// rewriteValues stores again the values of bucket name of database file i
+// for which rewrite returns a new value, nil keeping the current one. It
+// goes through the bucket a batch at a time, so that other writes can run
+// in between, until it is done or ctx is, and returns the number of values
+// it changed.
+func (s *server) rewriteValues(ctx context.Context, i int, name []byte, rewrite func(tx *bolt.Tx, k, v []byte) ([]byte, error)) (uint64, error) {
+  type pending struct {
+    k, v []byte
+  }
+  var count uint64
+  var after []byte
+  for {
+    if err := ctx.Err(); err != nil {
+      return count, err
+    }
+    done := false
+    err := s.updateFile(i, func(tx *bolt.Tx) error {
+      b := tx.Bucket(name)
+      if b == nil {
+        done = true
+        return nil
+      }
+      var batch []pending
+      c := b.Cursor()
+      k, v := c.First()
+      if after != nil {
+        k, v = c.Seek(after)
+        if k != nil && string(k) == string(after) {
+          k, v = c.Next()
+        }
+      }
+      for n := 0; k != nil && n < rewriteBatchSize; k, v = c.Next() {
+        n++
+        after = append(after[:0], k...)
+        newV, err := rewrite(tx, k, v)
+        if err != nil {
+          return fmt.Errorf("%s %v: %v", name, btoui(k), err)
+        }
+        // the new value may still point into the pages of the old one
+        if newV != nil {
+          batch = append(batch, pending{append([]byte(nil), k...), append([]byte(nil), newV...)})
+        }
+      }
+      done = k == nil
+      // putting keys while the cursor is on them can make it skip some
+      for _, p := range batch {
+        if err := b.Put(p.k, p.v); err != nil {
+          return err
+        }
+      }
+      count += uint64(len(batch))
+      return nil
+    })
+    if err != nil || done {
+      return count, err
+    }
+  }
+}
+
+// notifier wakes up goroutines waiting for the database to change, such as
+// the changelog streams of the followers.
+type notifier struct {
+  mu sync.Mutex
+  ch chan struct{}
+}
+
+// wait returns a channel that is closed on the next call to notify.
+func (n *notifier) wait() <-chan struct{} {
+  n.mu.Lock()
+  defer n.mu.Unlock()
+  if n.ch == nil {
+    n.ch = make(chan struct{})
+  }
+  return n.ch
+}
+
+func (n *notifier) notify() {
+  n.mu.Lock()
+  defer n.mu.Unlock()
+  if n.ch != nil {
+    close(n.ch)
+    n.ch = nil
+  }
+}
+
+// commandBlogID returns the id of the blog changed by cmd, 0 for creates.
+func commandBlogID(cmd *blogpb.BlogCommand) uint64 {
+  switch cmd.GetOp() {
+  case blogpb.BlogCommand_UPDATE:
+    return cmd.GetBlog().GetId()
+  case blogpb.BlogCommand_CREATE:
+    return 0
+  }
+  return cmd.GetBlogId()
+}
+
+// initBlog gives a blog being created its id. Its content is whole, it only
+// gets chunks through WriteBlogContent.
+func initBlog(blog *blogpb.Blog, id uint64) {
+  blog.Id = id
+  blog.ContentTruncated = false
+  blog.ContentSize = 0
+  blog.ContentVersion = 0
+}
+
+// applyCommand runs a write on the store and returns the blog it created or
+// changed. It is used directly by standalone servers and by the Raft state
+// machine in cluster mode, so it must give the same result on every node.
+// Commands with a caller are checked against the ACL of their blog in the
+// same transaction.
+func applyCommand(tx *bolt.Tx, cmd *blogpb.BlogCommand) (*blogpb.Blog, error) {
+  if cmd.GetOp() == blogpb.BlogCommand_CREATE {
+    blog := cmd.GetBlog()
+    // Generate ID for the blog post.
+    id, err := tx.Bucket(blogBucket).NextSequence()
+    if err != nil {
+      return nil, err
+    }
+    initBlog(blog, id)
+    if err := checkQuota(tx, cmd, nil, blog); err != nil {
+      return nil, err
+    }
+    return blog, putBlog(tx, cmd.GetTimestamp(), blog)
+  }
+
+  id := commandBlogID(cmd)
+  current, err := getBlog(tx, id)
+  if err != nil {
+    return nil, err
+  }
+  if current == nil {
+    fmt.Printf("Could not find blog with id %v\n\n", id)
+    return nil, status.Error(codes.NotFound, fmt.Sprintf("Could not find blog with id %v\n", id))
+  }
+  caller := cmd.GetCaller()
+
+  switch cmd.GetOp() {
+  case blogpb.BlogCommand_UPDATE:
+    if err := checkAccess(current, caller, true); err != nil {
+      return nil, err
+    }
+    blog := cmd.GetBlog()
+    // the ACL only changes through SET_ACL, and the counters and
+    // translations through their own commands
+    blog.Acl = current.GetAcl()
+    blog.Likes = current.GetLikes()
+    blog.Views = current.GetViews()
+    blog.Translations = current.GetTranslations()
+    blog.Locale = ""
+    blog.TranslatedLocales = nil
+    // uploaded content stays in chunks as long as its preview is sent back
+    // unchanged, any other content replaces it
+    if current.GetContentTruncated() && blog.GetContent() == current.GetContent() {
+      blog.ContentTruncated = true
+      blog.ContentSize = current.GetContentSize()
+      blog.ContentVersion = current.GetContentVersion()
+    } else {
+      blog.ContentTruncated = false
+      blog.ContentSize = 0
+      blog.ContentVersion = 0
+    }
+    // a failed command writes nothing, the Raft state machine commits its
+    // transaction anyway
+    if err := checkQuota(tx, cmd, current, blog); err != nil {
+      return nil, err
+    }
+    if version := current.GetContentVersion(); version != 0 && version != blog.GetContentVersion() {
+      if err := removeChunks(tx, cmd.GetTimestamp(), id, version); err != nil {
+        return nil, err
+      }
+    }
+    return blog, putBlog(tx, cmd.GetTimestamp(), blog)
+  case blogpb.BlogCommand_DELETE:
+    if caller != "" && !isOwner(current.GetAcl(), caller) {
+      return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("Only the owner can delete blog %v", id))
+    }
+    if err := removeContent(tx, cmd.GetTimestamp(), current); err != nil {
+      return nil, err
+    }
+    if err := removeEngagement(tx, id); err != nil {
+      return nil, err
+    }
+    if err := removeBlogFromSeries(tx, cmd.GetTimestamp(), id); err != nil {
+      return nil, err
+    }
+    return nil, removeBlog(tx, cmd.GetTimestamp(), id)
+  case blogpb.BlogCommand_SET_ACL:
+    if caller != "" && !isOwner(current.GetAcl(), caller) {
+      return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("Only the owner can change the ACL of blog %v", id))
+    }
+    acl := cmd.GetAcl()
+    if acl.GetOwner() == "" {
+      acl.Owner = current.GetAcl().GetOwner()
+    }
+    // a new owner takes the blog over, it has to fit in their quota
+    if acl.GetOwner() != usageOwner(current) {
+      moved := proto.Clone(current).(*blogpb.Blog)
+      moved.Acl = acl
+      if err := checkQuota(tx, cmd, current, moved); err != nil {
+        return nil, err
+      }
+    }
+    current.Acl = acl
+    return current, putBlog(tx, cmd.GetTimestamp(), current)
+  case blogpb.BlogCommand_LIKE, blogpb.BlogCommand_UNLIKE:
+    return applyLike(tx, current, cmd)
+  case blogpb.BlogCommand_VIEW:
+    return applyViews(tx, current, cmd)
+  case blogpb.BlogCommand_UPSERT_TRANSLATION, blogpb.BlogCommand_DELETE_TRANSLATION:
+    if err := checkAccess(current, caller, true); err != nil {
+      return nil, err
+    }
+    return applyTranslation(tx, current, cmd)
+  case blogpb.BlogCommand_APPEND_CONTENT, blogpb.BlogCommand_COMMIT_CONTENT:
+    if err := checkAccess(current, caller, true); err != nil {
+      return nil, err
+    }
+    return applyContent(tx, current, cmd)
+  }
+  return nil, fmt.Errorf("unknown command %v", cmd.GetOp())
+}
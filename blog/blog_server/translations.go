@@ -0,0 +1,212 @@
+package main
+
+import(
+  "context"
+  "fmt"
+  "regexp"
+  "sort"
+  "strings"
+
+  "github.com/villegasl/go_grpc_course/blog/blogpb"
+  "github.com/boltdb/bolt"
+  "github.com/golang/protobuf/proto"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// localePattern accepts BCP 47 language tags made of a language and any
+// number of subtags, which is as far as they are checked.
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+
+// canonicalLocale returns locale with the usual case of its subtags:
+// "pt-BR", "zh-Hant-TW".
+func canonicalLocale(locale string) string {
+  subtags := strings.Split(locale, "-")
+  subtags[0] = strings.ToLower(subtags[0])
+  for i := 1; i < len(subtags); i++ {
+    switch len(subtags[i]) {
+    case 2:
+      subtags[i] = strings.ToUpper(subtags[i])
+    case 4:
+      subtags[i] = strings.ToUpper(subtags[i][:1]) + strings.ToLower(subtags[i][1:])
+    default:
+      subtags[i] = strings.ToLower(subtags[i])
+    }
+  }
+  return strings.Join(subtags, "-")
+}
+
+// lookupLocales returns the locales tried for a requested one, the locale
+// itself then every shorter prefix of its subtags: "zh-Hant-TW", "zh-Hant",
+// "zh".
+func lookupLocales(locale string) []string {
+  var chain []string
+  for locale != "" {
+    chain = append(chain, locale)
+    i := strings.LastIndex(locale, "-")
+    if i < 0 {
+      break
+    }
+    locale = locale[:i]
+  }
+  return chain
+}
+
+// negotiateLocale returns the translation of blog best matching the
+// preferred locales, or nil when the original matches first or nothing
+// matches at all.
+func negotiateLocale(blog *blogpb.Blog, locales []string) *blogpb.BlogTranslation {
+  for _, requested := range locales {
+    for _, l := range lookupLocales(strings.TrimSpace(requested)) {
+      if strings.EqualFold(l, blog.GetOriginalLocale()) {
+        return nil
+      }
+      for _, t := range blog.GetTranslations() {
+        if strings.EqualFold(l, t.GetLocale()) {
+          return t
+        }
+      }
+    }
+  }
+  return nil
+}
+
+// localize returns a copy of blog to send back, in the locale negotiated
+// from locales and without its stored translations.
+func localize(blog *blogpb.Blog, locales []string) *blogpb.Blog {
+  localized := *blog
+  localized.Locale = blog.GetOriginalLocale()
+  if t := negotiateLocale(blog, locales); t != nil {
+    localized.Title = t.GetTitle()
+    localized.Content = t.GetContent()
+    localized.Locale = t.GetLocale()
+    // translations are stored whole, never in chunks
+    localized.ContentTruncated = false
+    localized.ContentSize = 0
+    localized.ContentVersion = 0
+  }
+  localized.TranslatedLocales = nil
+  for _, t := range blog.GetTranslations() {
+    localized.TranslatedLocales = append(localized.TranslatedLocales, t.GetLocale())
+  }
+  localized.Translations = nil
+  return &localized
+}
+
+// applyTranslation runs an UPSERT_TRANSLATION or DELETE_TRANSLATION command
+// on blog. Translations are kept sorted by locale.
+func applyTranslation(tx *bolt.Tx, blog *blogpb.Blog, cmd *blogpb.BlogCommand) (*blogpb.Blog, error) {
+  locale := cmd.GetTranslation().GetLocale()
+  var kept []*blogpb.BlogTranslation
+  for _, t := range blog.GetTranslations() {
+    if !strings.EqualFold(t.GetLocale(), locale) {
+      kept = append(kept, t)
+    }
+  }
+
+  if cmd.GetOp() == blogpb.BlogCommand_DELETE_TRANSLATION {
+    if len(kept) == len(blog.GetTranslations()) {
+      return nil, status.Error(codes.NotFound, fmt.Sprintf("Blog %v has no %v translation", blog.GetId(), locale))
+    }
+  } else {
+    if strings.EqualFold(locale, blog.GetOriginalLocale()) {
+      return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Blog %v is written in %v, update it with UpdateBlog", blog.GetId(), locale))
+    }
+    kept = append(kept, cmd.GetTranslation())
+    sort.Slice(kept, func(i, j int) bool {
+      return kept[i].GetLocale() < kept[j].GetLocale()
+    })
+    if cmd.GetQuota() != nil {
+      translated := proto.Clone(blog).(*blogpb.Blog)
+      translated.Translations = kept
+      if err := checkQuota(tx, cmd, blog, translated); err != nil {
+        return nil, err
+      }
+    }
+  }
+  blog.Translations = kept
+  return blog, putBlog(tx, cmd.GetTimestamp(), blog)
+}
+
+// moderateOrRefuse screens a translation or a piece of uploaded content,
+// named by what, like the blogs. They are only ever stored or refused, so
+// the ones moderation would quarantine are refused as well.
+func (s *server) moderateOrRefuse(ctx context.Context, blog *blogpb.Blog, what string) error {
+  if s.moderator == nil {
+    return nil
+  }
+  v, err := s.moderator.moderate(ctx, blog)
+  if err != nil {
+    return status.Error(codes.Unavailable, fmt.Sprintf("Could not moderate %v: %v", what, err))
+  }
+  if v.action != actionApprove {
+    refused := strings.ToUpper(what[:1]) + what[1:] + " refused by moderation: " + strings.Join(v.reasons, "; ")
+    fmt.Printf("%v\n\n", refused)
+    return status.Error(codes.PermissionDenied, refused)
+  }
+  return nil
+}
+
+func (s *server) UpsertBlogTranslation(ctx context.Context, req *blogpb.UpsertBlogTranslationRequest) (*blogpb.UpsertBlogTranslationResponse, error) {
+  fmt.Printf("UpsertBlogTranslation was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  t := req.GetTranslation()
+  if !localePattern.MatchString(t.GetLocale()) {
+    return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid locale %q", t.GetLocale()))
+  }
+  t.Locale = canonicalLocale(t.GetLocale())
+  err = s.moderateOrRefuse(ctx, &blogpb.Blog{
+    Id:      req.GetBlogId(),
+    Title:   t.GetTitle(),
+    Content: t.GetContent(),
+  }, "translation")
+  if err != nil {
+    return nil, err
+  }
+
+  blog, err := s.execute(ctx, &blogpb.BlogCommand{
+    Op:          blogpb.BlogCommand_UPSERT_TRANSLATION,
+    BlogId:      req.GetBlogId(),
+    Translation: t,
+    Caller:      caller,
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.UpsertBlogTranslationResponse{
+    Blog: s.visibleBlog(localize(blog, []string{t.GetLocale()}), caller),
+  }, nil
+}
+
+func (s *server) DeleteBlogTranslation(ctx context.Context, req *blogpb.DeleteBlogTranslationRequest) (*blogpb.DeleteBlogTranslationResponse, error) {
+  fmt.Printf("DeleteBlogTranslation was invoked with: %v\n\n", req)
+  if err := s.checkWritable(ctx); err != nil {
+    return nil, err
+  }
+  caller, err := s.authenticated(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if req.GetLocale() == "" {
+    return nil, status.Error(codes.InvalidArgument, "The locale is required")
+  }
+
+  _, err = s.execute(ctx, &blogpb.BlogCommand{
+    Op:     blogpb.BlogCommand_DELETE_TRANSLATION,
+    BlogId: req.GetBlogId(),
+    Translation: &blogpb.BlogTranslation{
+      Locale: req.GetLocale(),
+    },
+    Caller: caller,
+  })
+  if err != nil {
+    return nil, err
+  }
+  return &blogpb.DeleteBlogTranslationResponse{}, nil
+}
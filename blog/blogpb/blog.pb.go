@@ -24,11 +24,221 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type DiffLine_Op int32
+
+const (
+	DiffLine_EQUAL  DiffLine_Op = 0
+	DiffLine_DELETE DiffLine_Op = 1
+	DiffLine_INSERT DiffLine_Op = 2
+)
+
+var DiffLine_Op_name = map[int32]string{
+	0: "EQUAL",
+	1: "DELETE",
+	2: "INSERT",
+}
+
+var DiffLine_Op_value = map[string]int32{
+	"EQUAL":  0,
+	"DELETE": 1,
+	"INSERT": 2,
+}
+
+func (x DiffLine_Op) String() string {
+	return proto.EnumName(DiffLine_Op_name, int32(x))
+}
+
+func (DiffLine_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{24, 0}
+}
+
+type TopBlogsRequest_Metric int32
+
+const (
+	TopBlogsRequest_VIEWS TopBlogsRequest_Metric = 0
+	TopBlogsRequest_LIKES TopBlogsRequest_Metric = 1
+)
+
+var TopBlogsRequest_Metric_name = map[int32]string{
+	0: "VIEWS",
+	1: "LIKES",
+}
+
+var TopBlogsRequest_Metric_value = map[string]int32{
+	"VIEWS": 0,
+	"LIKES": 1,
+}
+
+func (x TopBlogsRequest_Metric) String() string {
+	return proto.EnumName(TopBlogsRequest_Metric_name, int32(x))
+}
+
+func (TopBlogsRequest_Metric) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{57, 0}
+}
+
+type ChangeEntry_Op int32
+
+const (
+	ChangeEntry_PUT           ChangeEntry_Op = 0
+	ChangeEntry_DELETE        ChangeEntry_Op = 1
+	ChangeEntry_PUT_SERIES    ChangeEntry_Op = 2
+	ChangeEntry_DELETE_SERIES ChangeEntry_Op = 3
+	ChangeEntry_PUT_CHUNK     ChangeEntry_Op = 4
+	ChangeEntry_DELETE_CHUNKS ChangeEntry_Op = 5
+	ChangeEntry_VIEW          ChangeEntry_Op = 6
+)
+
+var ChangeEntry_Op_name = map[int32]string{
+	0: "PUT",
+	1: "DELETE",
+	2: "PUT_SERIES",
+	3: "DELETE_SERIES",
+	4: "PUT_CHUNK",
+	5: "DELETE_CHUNKS",
+	6: "VIEW",
+}
+
+var ChangeEntry_Op_value = map[string]int32{
+	"PUT":           0,
+	"DELETE":        1,
+	"PUT_SERIES":    2,
+	"DELETE_SERIES": 3,
+	"PUT_CHUNK":     4,
+	"DELETE_CHUNKS": 5,
+	"VIEW":          6,
+}
+
+func (x ChangeEntry_Op) String() string {
+	return proto.EnumName(ChangeEntry_Op_name, int32(x))
+}
+
+func (ChangeEntry_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{75, 0}
+}
+
+type ReplicationStatusResponse_Role int32
+
+const (
+	ReplicationStatusResponse_LEADER   ReplicationStatusResponse_Role = 0
+	ReplicationStatusResponse_FOLLOWER ReplicationStatusResponse_Role = 1
+)
+
+var ReplicationStatusResponse_Role_name = map[int32]string{
+	0: "LEADER",
+	1: "FOLLOWER",
+}
+
+var ReplicationStatusResponse_Role_value = map[string]int32{
+	"LEADER":   0,
+	"FOLLOWER": 1,
+}
+
+func (x ReplicationStatusResponse_Role) String() string {
+	return proto.EnumName(ReplicationStatusResponse_Role_name, int32(x))
+}
+
+func (ReplicationStatusResponse_Role) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{79, 0}
+}
+
+type BlogCommand_Op int32
+
+const (
+	BlogCommand_CREATE             BlogCommand_Op = 0
+	BlogCommand_UPDATE             BlogCommand_Op = 1
+	BlogCommand_DELETE             BlogCommand_Op = 2
+	BlogCommand_SET_ACL            BlogCommand_Op = 3
+	BlogCommand_LIKE               BlogCommand_Op = 4
+	BlogCommand_UNLIKE             BlogCommand_Op = 5
+	BlogCommand_VIEW               BlogCommand_Op = 6
+	BlogCommand_UPSERT_TRANSLATION BlogCommand_Op = 7
+	BlogCommand_DELETE_TRANSLATION BlogCommand_Op = 8
+	BlogCommand_CREATE_SERIES      BlogCommand_Op = 9
+	BlogCommand_DELETE_SERIES      BlogCommand_Op = 10
+	BlogCommand_ADD_TO_SERIES      BlogCommand_Op = 11
+	BlogCommand_MOVE_IN_SERIES     BlogCommand_Op = 12
+	BlogCommand_REMOVE_FROM_SERIES BlogCommand_Op = 13
+	BlogCommand_APPEND_CONTENT     BlogCommand_Op = 14
+	BlogCommand_COMMIT_CONTENT     BlogCommand_Op = 15
+)
+
+var BlogCommand_Op_name = map[int32]string{
+	0:  "CREATE",
+	1:  "UPDATE",
+	2:  "DELETE",
+	3:  "SET_ACL",
+	4:  "LIKE",
+	5:  "UNLIKE",
+	6:  "VIEW",
+	7:  "UPSERT_TRANSLATION",
+	8:  "DELETE_TRANSLATION",
+	9:  "CREATE_SERIES",
+	10: "DELETE_SERIES",
+	11: "ADD_TO_SERIES",
+	12: "MOVE_IN_SERIES",
+	13: "REMOVE_FROM_SERIES",
+	14: "APPEND_CONTENT",
+	15: "COMMIT_CONTENT",
+}
+
+var BlogCommand_Op_value = map[string]int32{
+	"CREATE":             0,
+	"UPDATE":             1,
+	"DELETE":             2,
+	"SET_ACL":            3,
+	"LIKE":               4,
+	"UNLIKE":             5,
+	"VIEW":               6,
+	"UPSERT_TRANSLATION": 7,
+	"DELETE_TRANSLATION": 8,
+	"CREATE_SERIES":      9,
+	"DELETE_SERIES":      10,
+	"ADD_TO_SERIES":      11,
+	"MOVE_IN_SERIES":     12,
+	"REMOVE_FROM_SERIES": 13,
+	"APPEND_CONTENT":     14,
+	"COMMIT_CONTENT":     15,
+}
+
+func (x BlogCommand_Op) String() string {
+	return proto.EnumName(BlogCommand_Op_name, int32(x))
+}
+
+func (BlogCommand_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{80, 0}
+}
+
 type Blog struct {
-	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	AuthorId             string   `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Id       uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId string `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Title    string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content  string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// only sent back to the owner and editors. It is set with SetBlogAcl,
+	// UpdateBlog keeps the current one.
+	Acl *BlogAcl `protobuf:"bytes,5,opt,name=acl,proto3" json:"acl,omitempty"`
+	// engagement counters, kept by the server. Views are counted in memory by
+	// ReadBlog and written periodically, so they lag behind a little.
+	Likes uint64 `protobuf:"varint,6,opt,name=likes,proto3" json:"likes,omitempty"`
+	Views uint64 `protobuf:"varint,7,opt,name=views,proto3" json:"views,omitempty"`
+	// locale title and content are written in, such as "en", optional
+	OriginalLocale string `protobuf:"bytes,8,opt,name=original_locale,json=originalLocale,proto3" json:"original_locale,omitempty"`
+	// set on the blogs sent back: the locale title and content were picked
+	// in, and every locale the blog is translated to
+	Locale            string   `protobuf:"bytes,9,opt,name=locale,proto3" json:"locale,omitempty"`
+	TranslatedLocales []string `protobuf:"bytes,10,rep,name=translated_locales,json=translatedLocales,proto3" json:"translated_locales,omitempty"`
+	// kept by the server and never sent back, see UpsertBlogTranslation.
+	// UpdateBlog keeps the current ones.
+	Translations []*BlogTranslation `protobuf:"bytes,11,rep,name=translations,proto3" json:"translations,omitempty"`
+	// set when the content was uploaded with WriteBlogContent and is too
+	// large to travel in one message: content then only holds its beginning,
+	// StreamBlogContent sends all content_size bytes of it
+	ContentTruncated bool   `protobuf:"varint,12,opt,name=content_truncated,json=contentTruncated,proto3" json:"content_truncated,omitempty"`
+	ContentSize      uint64 `protobuf:"varint,13,opt,name=content_size,json=contentSize,proto3" json:"content_size,omitempty"`
+	// kept by the server, identifies the stored chunks of the content and
+	// changes with every upload
+	ContentVersion       uint64   `protobuf:"varint,14,opt,name=content_version,json=contentVersion,proto3" json:"content_version,omitempty"`
+	Tags                 []string `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -87,6 +297,292 @@ func (m *Blog) GetContent() string {
 	return ""
 }
 
+func (m *Blog) GetAcl() *BlogAcl {
+	if m != nil {
+		return m.Acl
+	}
+	return nil
+}
+
+func (m *Blog) GetLikes() uint64 {
+	if m != nil {
+		return m.Likes
+	}
+	return 0
+}
+
+func (m *Blog) GetViews() uint64 {
+	if m != nil {
+		return m.Views
+	}
+	return 0
+}
+
+func (m *Blog) GetOriginalLocale() string {
+	if m != nil {
+		return m.OriginalLocale
+	}
+	return ""
+}
+
+func (m *Blog) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *Blog) GetTranslatedLocales() []string {
+	if m != nil {
+		return m.TranslatedLocales
+	}
+	return nil
+}
+
+func (m *Blog) GetTranslations() []*BlogTranslation {
+	if m != nil {
+		return m.Translations
+	}
+	return nil
+}
+
+func (m *Blog) GetContentTruncated() bool {
+	if m != nil {
+		return m.ContentTruncated
+	}
+	return false
+}
+
+func (m *Blog) GetContentSize() uint64 {
+	if m != nil {
+		return m.ContentSize
+	}
+	return 0
+}
+
+func (m *Blog) GetContentVersion() uint64 {
+	if m != nil {
+		return m.ContentVersion
+	}
+	return 0
+}
+
+func (m *Blog) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// A piece of the content of a blog as stored, at most 64KB.
+type BlogChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlogChunk) Reset()         { *m = BlogChunk{} }
+func (m *BlogChunk) String() string { return proto.CompactTextString(m) }
+func (*BlogChunk) ProtoMessage()    {}
+func (*BlogChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{1}
+}
+
+func (m *BlogChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlogChunk.Unmarshal(m, b)
+}
+func (m *BlogChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlogChunk.Marshal(b, m, deterministic)
+}
+func (m *BlogChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlogChunk.Merge(m, src)
+}
+func (m *BlogChunk) XXX_Size() int {
+	return xxx_messageInfo_BlogChunk.Size(m)
+}
+func (m *BlogChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlogChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlogChunk proto.InternalMessageInfo
+
+func (m *BlogChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// The state of an upload of content in progress, kept by the server.
+type ContentUpload struct {
+	Version              uint64   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Offset               uint64   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContentUpload) Reset()         { *m = ContentUpload{} }
+func (m *ContentUpload) String() string { return proto.CompactTextString(m) }
+func (*ContentUpload) ProtoMessage()    {}
+func (*ContentUpload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{2}
+}
+
+func (m *ContentUpload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentUpload.Unmarshal(m, b)
+}
+func (m *ContentUpload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentUpload.Marshal(b, m, deterministic)
+}
+func (m *ContentUpload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentUpload.Merge(m, src)
+}
+func (m *ContentUpload) XXX_Size() int {
+	return xxx_messageInfo_ContentUpload.Size(m)
+}
+func (m *ContentUpload) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentUpload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContentUpload proto.InternalMessageInfo
+
+func (m *ContentUpload) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ContentUpload) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// A blog in another locale than the original.
+type BlogTranslation struct {
+	Locale               string   `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content              string   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlogTranslation) Reset()         { *m = BlogTranslation{} }
+func (m *BlogTranslation) String() string { return proto.CompactTextString(m) }
+func (*BlogTranslation) ProtoMessage()    {}
+func (*BlogTranslation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{3}
+}
+
+func (m *BlogTranslation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlogTranslation.Unmarshal(m, b)
+}
+func (m *BlogTranslation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlogTranslation.Marshal(b, m, deterministic)
+}
+func (m *BlogTranslation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlogTranslation.Merge(m, src)
+}
+func (m *BlogTranslation) XXX_Size() int {
+	return xxx_messageInfo_BlogTranslation.Size(m)
+}
+func (m *BlogTranslation) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlogTranslation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlogTranslation proto.InternalMessageInfo
+
+func (m *BlogTranslation) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *BlogTranslation) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *BlogTranslation) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+// Who can access a blog, by caller identity. Owners can do anything, editors
+// can read and update, viewers can read.
+type BlogAcl struct {
+	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Editors              []string `protobuf:"bytes,2,rep,name=editors,proto3" json:"editors,omitempty"`
+	Viewers              []string `protobuf:"bytes,3,rep,name=viewers,proto3" json:"viewers,omitempty"`
+	Public               bool     `protobuf:"varint,4,opt,name=public,proto3" json:"public,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlogAcl) Reset()         { *m = BlogAcl{} }
+func (m *BlogAcl) String() string { return proto.CompactTextString(m) }
+func (*BlogAcl) ProtoMessage()    {}
+func (*BlogAcl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{4}
+}
+
+func (m *BlogAcl) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlogAcl.Unmarshal(m, b)
+}
+func (m *BlogAcl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlogAcl.Marshal(b, m, deterministic)
+}
+func (m *BlogAcl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlogAcl.Merge(m, src)
+}
+func (m *BlogAcl) XXX_Size() int {
+	return xxx_messageInfo_BlogAcl.Size(m)
+}
+func (m *BlogAcl) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlogAcl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlogAcl proto.InternalMessageInfo
+
+func (m *BlogAcl) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *BlogAcl) GetEditors() []string {
+	if m != nil {
+		return m.Editors
+	}
+	return nil
+}
+
+func (m *BlogAcl) GetViewers() []string {
+	if m != nil {
+		return m.Viewers
+	}
+	return nil
+}
+
+func (m *BlogAcl) GetPublic() bool {
+	if m != nil {
+		return m.Public
+	}
+	return false
+}
+
 type CreateBlogRequest struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -98,7 +594,7 @@ func (m *CreateBlogRequest) Reset()         { *m = CreateBlogRequest{} }
 func (m *CreateBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateBlogRequest) ProtoMessage()    {}
 func (*CreateBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{1}
+	return fileDescriptor_a4b0406114889fe6, []int{5}
 }
 
 func (m *CreateBlogRequest) XXX_Unmarshal(b []byte) error {
@@ -127,7 +623,10 @@ func (m *CreateBlogRequest) GetBlog() *Blog {
 }
 
 type CreateBlogResponse struct {
-	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// set instead when moderation holds the blog for review, it is only stored
+	// once approved
+	QuarantineId         uint64   `protobuf:"varint,2,opt,name=quarantine_id,json=quarantineId,proto3" json:"quarantine_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -137,7 +636,7 @@ func (m *CreateBlogResponse) Reset()         { *m = CreateBlogResponse{} }
 func (m *CreateBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateBlogResponse) ProtoMessage()    {}
 func (*CreateBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{2}
+	return fileDescriptor_a4b0406114889fe6, []int{6}
 }
 
 func (m *CreateBlogResponse) XXX_Unmarshal(b []byte) error {
@@ -165,8 +664,24 @@ func (m *CreateBlogResponse) GetBlog() *Blog {
 	return nil
 }
 
+func (m *CreateBlogResponse) GetQuarantineId() uint64 {
+	if m != nil {
+		return m.QuarantineId
+	}
+	return 0
+}
+
 type ReadBlogRequest struct {
-	BlogId               uint64   `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	BlogId uint64 `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// preferred locales, best first. Each one also matches translations to
+	// its language alone, "pt-BR" falls back to "pt", and the original is
+	// returned when none match.
+	Locales []string `protobuf:"bytes,2,rep,name=locales,proto3" json:"locales,omitempty"`
+	// also return the previous and next posts of the series of the blog
+	WithNavigation bool `protobuf:"varint,3,opt,name=with_navigation,json=withNavigation,proto3" json:"with_navigation,omitempty"`
+	// unix nanoseconds, to read the blog as it was then, rebuilt from the
+	// changelog. Navigation is always the current one.
+	AsOf                 int64    `protobuf:"varint,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -176,7 +691,7 @@ func (m *ReadBlogRequest) Reset()         { *m = ReadBlogRequest{} }
 func (m *ReadBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadBlogRequest) ProtoMessage()    {}
 func (*ReadBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{3}
+	return fileDescriptor_a4b0406114889fe6, []int{7}
 }
 
 func (m *ReadBlogRequest) XXX_Unmarshal(b []byte) error {
@@ -204,18 +719,41 @@ func (m *ReadBlogRequest) GetBlogId() uint64 {
 	return 0
 }
 
+func (m *ReadBlogRequest) GetLocales() []string {
+	if m != nil {
+		return m.Locales
+	}
+	return nil
+}
+
+func (m *ReadBlogRequest) GetWithNavigation() bool {
+	if m != nil {
+		return m.WithNavigation
+	}
+	return false
+}
+
+func (m *ReadBlogRequest) GetAsOf() int64 {
+	if m != nil {
+		return m.AsOf
+	}
+	return 0
+}
+
 type ReadBlogResponse struct {
-	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// set with with_navigation when the blog is part of a series
+	Navigation           *SeriesNavigation `protobuf:"bytes,2,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ReadBlogResponse) Reset()         { *m = ReadBlogResponse{} }
 func (m *ReadBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadBlogResponse) ProtoMessage()    {}
 func (*ReadBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{4}
+	return fileDescriptor_a4b0406114889fe6, []int{8}
 }
 
 func (m *ReadBlogResponse) XXX_Unmarshal(b []byte) error {
@@ -243,9 +781,172 @@ func (m *ReadBlogResponse) GetBlog() *Blog {
 	return nil
 }
 
-type UpdateBlogRequest struct {
-	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+func (m *ReadBlogResponse) GetNavigation() *SeriesNavigation {
+	if m != nil {
+		return m.Navigation
+	}
+	return nil
+}
+
+// An ordered sequence of blogs, such as the parts of a tutorial. A blog is
+// part of one series at most.
+type Series struct {
+	Id          uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// who created it, the only caller that can change it when the server
+	// authenticates callers
+	Owner                string   `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	BlogIds              []uint64 `protobuf:"varint,5,rep,packed,name=blog_ids,json=blogIds,proto3" json:"blog_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Series) Reset()         { *m = Series{} }
+func (m *Series) String() string { return proto.CompactTextString(m) }
+func (*Series) ProtoMessage()    {}
+func (*Series) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{9}
+}
+
+func (m *Series) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Series.Unmarshal(m, b)
+}
+func (m *Series) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Series.Marshal(b, m, deterministic)
+}
+func (m *Series) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Series.Merge(m, src)
+}
+func (m *Series) XXX_Size() int {
+	return xxx_messageInfo_Series.Size(m)
+}
+func (m *Series) XXX_DiscardUnknown() {
+	xxx_messageInfo_Series.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Series proto.InternalMessageInfo
+
+func (m *Series) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Series) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Series) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Series) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Series) GetBlogIds() []uint64 {
+	if m != nil {
+		return m.BlogIds
+	}
+	return nil
+}
+
+// Where a blog is in its series. previous and next skip the blogs the caller
+// can't read.
+type SeriesNavigation struct {
+	SeriesId             uint64   `protobuf:"varint,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	SeriesTitle          string   `protobuf:"bytes,2,opt,name=series_title,json=seriesTitle,proto3" json:"series_title,omitempty"`
+	Position             int32    `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	Count                int32    `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Previous             *Blog    `protobuf:"bytes,5,opt,name=previous,proto3" json:"previous,omitempty"`
+	Next                 *Blog    `protobuf:"bytes,6,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SeriesNavigation) Reset()         { *m = SeriesNavigation{} }
+func (m *SeriesNavigation) String() string { return proto.CompactTextString(m) }
+func (*SeriesNavigation) ProtoMessage()    {}
+func (*SeriesNavigation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{10}
+}
+
+func (m *SeriesNavigation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SeriesNavigation.Unmarshal(m, b)
+}
+func (m *SeriesNavigation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SeriesNavigation.Marshal(b, m, deterministic)
+}
+func (m *SeriesNavigation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeriesNavigation.Merge(m, src)
+}
+func (m *SeriesNavigation) XXX_Size() int {
+	return xxx_messageInfo_SeriesNavigation.Size(m)
+}
+func (m *SeriesNavigation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeriesNavigation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SeriesNavigation proto.InternalMessageInfo
+
+func (m *SeriesNavigation) GetSeriesId() uint64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+func (m *SeriesNavigation) GetSeriesTitle() string {
+	if m != nil {
+		return m.SeriesTitle
+	}
+	return ""
+}
+
+func (m *SeriesNavigation) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *SeriesNavigation) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *SeriesNavigation) GetPrevious() *Blog {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
+func (m *SeriesNavigation) GetNext() *Blog {
+	if m != nil {
+		return m.Next
+	}
+	return nil
+}
+
+type UpdateBlogRequest struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -254,7 +955,7 @@ func (m *UpdateBlogRequest) Reset()         { *m = UpdateBlogRequest{} }
 func (m *UpdateBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateBlogRequest) ProtoMessage()    {}
 func (*UpdateBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{5}
+	return fileDescriptor_a4b0406114889fe6, []int{11}
 }
 
 func (m *UpdateBlogRequest) XXX_Unmarshal(b []byte) error {
@@ -284,6 +985,7 @@ func (m *UpdateBlogRequest) GetBlog() *Blog {
 
 type UpdateBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	QuarantineId         uint64   `protobuf:"varint,2,opt,name=quarantine_id,json=quarantineId,proto3" json:"quarantine_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -293,7 +995,7 @@ func (m *UpdateBlogResponse) Reset()         { *m = UpdateBlogResponse{} }
 func (m *UpdateBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateBlogResponse) ProtoMessage()    {}
 func (*UpdateBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{6}
+	return fileDescriptor_a4b0406114889fe6, []int{12}
 }
 
 func (m *UpdateBlogResponse) XXX_Unmarshal(b []byte) error {
@@ -321,6 +1023,13 @@ func (m *UpdateBlogResponse) GetBlog() *Blog {
 	return nil
 }
 
+func (m *UpdateBlogResponse) GetQuarantineId() uint64 {
+	if m != nil {
+		return m.QuarantineId
+	}
+	return 0
+}
+
 type DeleteBlogRequest struct {
 	BlogId               uint64   `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -332,7 +1041,7 @@ func (m *DeleteBlogRequest) Reset()         { *m = DeleteBlogRequest{} }
 func (m *DeleteBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteBlogRequest) ProtoMessage()    {}
 func (*DeleteBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{7}
+	return fileDescriptor_a4b0406114889fe6, []int{13}
 }
 
 func (m *DeleteBlogRequest) XXX_Unmarshal(b []byte) error {
@@ -371,7 +1080,7 @@ func (m *DeleteBlogResponse) Reset()         { *m = DeleteBlogResponse{} }
 func (m *DeleteBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteBlogResponse) ProtoMessage()    {}
 func (*DeleteBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{8}
+	return fileDescriptor_a4b0406114889fe6, []int{14}
 }
 
 func (m *DeleteBlogResponse) XXX_Unmarshal(b []byte) error {
@@ -400,6 +1109,8 @@ func (m *DeleteBlogResponse) GetBlogId() uint64 {
 }
 
 type ListBlogRequest struct {
+	Locales              []string `protobuf:"bytes,1,rep,name=locales,proto3" json:"locales,omitempty"`
+	AsOf                 int64    `protobuf:"varint,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -409,7 +1120,7 @@ func (m *ListBlogRequest) Reset()         { *m = ListBlogRequest{} }
 func (m *ListBlogRequest) String() string { return proto.CompactTextString(m) }
 func (*ListBlogRequest) ProtoMessage()    {}
 func (*ListBlogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{9}
+	return fileDescriptor_a4b0406114889fe6, []int{15}
 }
 
 func (m *ListBlogRequest) XXX_Unmarshal(b []byte) error {
@@ -430,6 +1141,20 @@ func (m *ListBlogRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ListBlogRequest proto.InternalMessageInfo
 
+func (m *ListBlogRequest) GetLocales() []string {
+	if m != nil {
+		return m.Locales
+	}
+	return nil
+}
+
+func (m *ListBlogRequest) GetAsOf() int64 {
+	if m != nil {
+		return m.AsOf
+	}
+	return 0
+}
+
 type ListBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -441,7 +1166,7 @@ func (m *ListBlogResponse) Reset()         { *m = ListBlogResponse{} }
 func (m *ListBlogResponse) String() string { return proto.CompactTextString(m) }
 func (*ListBlogResponse) ProtoMessage()    {}
 func (*ListBlogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a4b0406114889fe6, []int{10}
+	return fileDescriptor_a4b0406114889fe6, []int{16}
 }
 
 func (m *ListBlogResponse) XXX_Unmarshal(b []byte) error {
@@ -469,296 +1194,6706 @@ func (m *ListBlogResponse) GetBlog() *Blog {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Blog)(nil), "blog.Blog")
-	proto.RegisterType((*CreateBlogRequest)(nil), "blog.CreateBlogRequest")
-	proto.RegisterType((*CreateBlogResponse)(nil), "blog.CreateBlogResponse")
-	proto.RegisterType((*ReadBlogRequest)(nil), "blog.ReadBlogRequest")
-	proto.RegisterType((*ReadBlogResponse)(nil), "blog.ReadBlogResponse")
-	proto.RegisterType((*UpdateBlogRequest)(nil), "blog.UpdateBlogRequest")
-	proto.RegisterType((*UpdateBlogResponse)(nil), "blog.UpdateBlogResponse")
-	proto.RegisterType((*DeleteBlogRequest)(nil), "blog.DeleteBlogRequest")
-	proto.RegisterType((*DeleteBlogResponse)(nil), "blog.DeleteBlogResponse")
-	proto.RegisterType((*ListBlogRequest)(nil), "blog.ListBlogRequest")
-	proto.RegisterType((*ListBlogResponse)(nil), "blog.ListBlogResponse")
+type GetBlogAclRequest struct {
+	BlogId               uint64   `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("blog/blogpb/blog.proto", fileDescriptor_a4b0406114889fe6) }
-
-var fileDescriptor_a4b0406114889fe6 = []byte{
-	// 360 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x41, 0x4f, 0xf2, 0x40,
-	0x10, 0xa5, 0xfd, 0xfa, 0x41, 0x19, 0x12, 0xb1, 0x13, 0x85, 0x06, 0x13, 0x43, 0x7a, 0x22, 0x46,
-	0xd1, 0x80, 0x37, 0xe3, 0x01, 0xf4, 0x42, 0xe2, 0xa9, 0xc6, 0x8b, 0x17, 0x02, 0xec, 0x04, 0x9b,
-	0x34, 0x6c, 0x6d, 0x17, 0x7f, 0xa8, 0xbf, 0xc8, 0xec, 0xae, 0x4b, 0x6b, 0x1b, 0x22, 0x5e, 0xa0,
-	0x33, 0xf3, 0x66, 0xde, 0xcc, 0x7b, 0x59, 0xe8, 0x2c, 0x63, 0xbe, 0xbe, 0x96, 0x3f, 0xc9, 0x52,
-	0xfd, 0x0d, 0x93, 0x94, 0x0b, 0x8e, 0x8e, 0xfc, 0x0e, 0x56, 0xe0, 0x4c, 0x63, 0xbe, 0xc6, 0x23,
-	0xb0, 0x23, 0xe6, 0x5b, 0x7d, 0x6b, 0xe0, 0x84, 0x76, 0xc4, 0xf0, 0x0c, 0x9a, 0x8b, 0xad, 0x78,
-	0xe3, 0xe9, 0x3c, 0x62, 0xbe, 0xdd, 0xb7, 0x06, 0xcd, 0xd0, 0xd5, 0x89, 0x19, 0xc3, 0x13, 0xf8,
-	0x2f, 0x22, 0x11, 0x93, 0xff, 0x4f, 0x15, 0x74, 0x80, 0x3e, 0x34, 0x56, 0x7c, 0x23, 0x68, 0x23,
-	0x7c, 0x47, 0xe5, 0x4d, 0x18, 0x8c, 0xc1, 0x7b, 0x48, 0x69, 0x21, 0x48, 0x52, 0x85, 0xf4, 0xbe,
-	0xa5, 0x4c, 0xe0, 0x39, 0xa8, 0x0d, 0x14, 0x67, 0x6b, 0x04, 0x43, 0xb5, 0x9a, 0x02, 0xe8, 0xcd,
-	0x6e, 0x01, 0x8b, 0x4d, 0x59, 0xc2, 0x37, 0x19, 0xfd, 0xda, 0x75, 0x01, 0xed, 0x90, 0x16, 0xac,
-	0x48, 0xd4, 0x85, 0x86, 0x2c, 0xcd, 0x77, 0xf7, 0xd5, 0x65, 0x38, 0x63, 0xc1, 0x08, 0x8e, 0x73,
-	0xec, 0x81, 0xf3, 0xc7, 0xe0, 0xbd, 0x24, 0xec, 0xef, 0xa7, 0x14, 0x9b, 0x0e, 0xa4, 0xba, 0x04,
-	0xef, 0x91, 0x62, 0xfa, 0x49, 0xb5, 0xf7, 0x98, 0x2b, 0xc0, 0x22, 0xfa, 0x9b, 0x63, 0x2f, 0xdc,
-	0x83, 0xf6, 0x53, 0x94, 0x89, 0xc2, 0x68, 0x29, 0x47, 0x9e, 0x3a, 0x6c, 0xc7, 0xd1, 0xa7, 0x0d,
-	0x2d, 0x19, 0x3e, 0x53, 0xfa, 0x11, 0xad, 0x08, 0x27, 0x00, 0xb9, 0x69, 0xd8, 0xd5, 0xf8, 0x8a,
-	0xf7, 0x3d, 0xbf, 0x5a, 0xd0, 0x84, 0x41, 0x0d, 0xef, 0xc0, 0x35, 0xae, 0xe0, 0xa9, 0xc6, 0x95,
-	0x1c, 0xed, 0x75, 0xca, 0xe9, 0x5d, 0xf3, 0x04, 0x20, 0x57, 0xda, 0xf0, 0x57, 0x0c, 0x33, 0xfc,
-	0x55, 0x53, 0xf4, 0x88, 0x5c, 0x48, 0x33, 0xa2, 0x62, 0x84, 0x19, 0x51, 0xd5, 0x3c, 0xa8, 0xe1,
-	0x3d, 0xb8, 0x46, 0x49, 0x73, 0x42, 0x49, 0x6c, 0x73, 0x42, 0x59, 0xf0, 0xa0, 0x76, 0x63, 0x4d,
-	0xdd, 0xd7, 0xba, 0x7e, 0xae, 0xcb, 0xba, 0x7a, 0xaa, 0xe3, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x80, 0xea, 0x36, 0xdb, 0xc4, 0x03, 0x00, 0x00,
+func (m *GetBlogAclRequest) Reset()         { *m = GetBlogAclRequest{} }
+func (m *GetBlogAclRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlogAclRequest) ProtoMessage()    {}
+func (*GetBlogAclRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{17}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *GetBlogAclRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlogAclRequest.Unmarshal(m, b)
+}
+func (m *GetBlogAclRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlogAclRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlogAclRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlogAclRequest.Merge(m, src)
+}
+func (m *GetBlogAclRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlogAclRequest.Size(m)
+}
+func (m *GetBlogAclRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlogAclRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_GetBlogAclRequest proto.InternalMessageInfo
 
-// BlogServiceClient is the client API for BlogService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type BlogServiceClient interface {
-	CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error)
-	ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error)
-	UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
-	DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error)
-	ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error)
+func (m *GetBlogAclRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
 }
 
-type blogServiceClient struct {
-	cc *grpc.ClientConn
+type GetBlogAclResponse struct {
+	Acl                  *BlogAcl `protobuf:"bytes,1,opt,name=acl,proto3" json:"acl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewBlogServiceClient(cc *grpc.ClientConn) BlogServiceClient {
-	return &blogServiceClient{cc}
+func (m *GetBlogAclResponse) Reset()         { *m = GetBlogAclResponse{} }
+func (m *GetBlogAclResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlogAclResponse) ProtoMessage()    {}
+func (*GetBlogAclResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{18}
 }
 
-func (c *blogServiceClient) CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error) {
-	out := new(CreateBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/CreateBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetBlogAclResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlogAclResponse.Unmarshal(m, b)
 }
-
-func (c *blogServiceClient) ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error) {
-	out := new(ReadBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/ReadBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetBlogAclResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlogAclResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlogAclResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlogAclResponse.Merge(m, src)
+}
+func (m *GetBlogAclResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlogAclResponse.Size(m)
+}
+func (m *GetBlogAclResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlogAclResponse.DiscardUnknown(m)
 }
 
-func (c *blogServiceClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
-	out := new(UpdateBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/UpdateBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetBlogAclResponse proto.InternalMessageInfo
+
+func (m *GetBlogAclResponse) GetAcl() *BlogAcl {
+	if m != nil {
+		return m.Acl
 	}
-	return out, nil
+	return nil
 }
 
-func (c *blogServiceClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error) {
-	out := new(DeleteBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type SetBlogAclRequest struct {
+	BlogId               uint64   `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Acl                  *BlogAcl `protobuf:"bytes,2,opt,name=acl,proto3" json:"acl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[0], "/blog.BlogService/ListBlog", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &blogServiceListBlogClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+func (m *SetBlogAclRequest) Reset()         { *m = SetBlogAclRequest{} }
+func (m *SetBlogAclRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBlogAclRequest) ProtoMessage()    {}
+func (*SetBlogAclRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{19}
 }
 
-type BlogService_ListBlogClient interface {
-	Recv() (*ListBlogResponse, error)
-	grpc.ClientStream
+func (m *SetBlogAclRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBlogAclRequest.Unmarshal(m, b)
+}
+func (m *SetBlogAclRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBlogAclRequest.Marshal(b, m, deterministic)
+}
+func (m *SetBlogAclRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBlogAclRequest.Merge(m, src)
+}
+func (m *SetBlogAclRequest) XXX_Size() int {
+	return xxx_messageInfo_SetBlogAclRequest.Size(m)
+}
+func (m *SetBlogAclRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBlogAclRequest.DiscardUnknown(m)
 }
 
-type blogServiceListBlogClient struct {
-	grpc.ClientStream
+var xxx_messageInfo_SetBlogAclRequest proto.InternalMessageInfo
+
+func (m *SetBlogAclRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
 }
 
-func (x *blogServiceListBlogClient) Recv() (*ListBlogResponse, error) {
-	m := new(ListBlogResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *SetBlogAclRequest) GetAcl() *BlogAcl {
+	if m != nil {
+		return m.Acl
 	}
-	return m, nil
+	return nil
 }
 
-// BlogServiceServer is the server API for BlogService service.
-type BlogServiceServer interface {
-	CreateBlog(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error)
-	ReadBlog(context.Context, *ReadBlogRequest) (*ReadBlogResponse, error)
-	UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
-	DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
-	ListBlog(*ListBlogRequest, BlogService_ListBlogServer) error
+type SetBlogAclResponse struct {
+	Acl                  *BlogAcl `protobuf:"bytes,1,opt,name=acl,proto3" json:"acl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// UnimplementedBlogServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedBlogServiceServer struct {
+func (m *SetBlogAclResponse) Reset()         { *m = SetBlogAclResponse{} }
+func (m *SetBlogAclResponse) String() string { return proto.CompactTextString(m) }
+func (*SetBlogAclResponse) ProtoMessage()    {}
+func (*SetBlogAclResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{20}
 }
 
-func (*UnimplementedBlogServiceServer) CreateBlog(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateBlog not implemented")
+func (m *SetBlogAclResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBlogAclResponse.Unmarshal(m, b)
 }
-func (*UnimplementedBlogServiceServer) ReadBlog(ctx context.Context, req *ReadBlogRequest) (*ReadBlogResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReadBlog not implemented")
+func (m *SetBlogAclResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBlogAclResponse.Marshal(b, m, deterministic)
 }
-func (*UnimplementedBlogServiceServer) UpdateBlog(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateBlog not implemented")
+func (m *SetBlogAclResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBlogAclResponse.Merge(m, src)
+}
+func (m *SetBlogAclResponse) XXX_Size() int {
+	return xxx_messageInfo_SetBlogAclResponse.Size(m)
+}
+func (m *SetBlogAclResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBlogAclResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBlogAclResponse proto.InternalMessageInfo
+
+func (m *SetBlogAclResponse) GetAcl() *BlogAcl {
+	if m != nil {
+		return m.Acl
+	}
+	return nil
+}
+
+type ImportBlogRequest struct {
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Blog                 *Blog    `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportBlogRequest) Reset()         { *m = ImportBlogRequest{} }
+func (m *ImportBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportBlogRequest) ProtoMessage()    {}
+func (*ImportBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{21}
+}
+
+func (m *ImportBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBlogRequest.Unmarshal(m, b)
+}
+func (m *ImportBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBlogRequest.Merge(m, src)
+}
+func (m *ImportBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportBlogRequest.Size(m)
+}
+func (m *ImportBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBlogRequest proto.InternalMessageInfo
+
+func (m *ImportBlogRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ImportBlogRequest) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type ImportBlogResponse struct {
+	Source       string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Blog         *Blog  `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	QuarantineId uint64 `protobuf:"varint,3,opt,name=quarantine_id,json=quarantineId,proto3" json:"quarantine_id,omitempty"`
+	// the status code and message of the failure, 0 when it succeeded
+	Code                 int32    `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	Message              string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportBlogResponse) Reset()         { *m = ImportBlogResponse{} }
+func (m *ImportBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportBlogResponse) ProtoMessage()    {}
+func (*ImportBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{22}
+}
+
+func (m *ImportBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBlogResponse.Unmarshal(m, b)
+}
+func (m *ImportBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBlogResponse.Merge(m, src)
+}
+func (m *ImportBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportBlogResponse.Size(m)
+}
+func (m *ImportBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBlogResponse proto.InternalMessageInfo
+
+func (m *ImportBlogResponse) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ImportBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *ImportBlogResponse) GetQuarantineId() uint64 {
+	if m != nil {
+		return m.QuarantineId
+	}
+	return 0
+}
+
+func (m *ImportBlogResponse) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ImportBlogResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type DiffBlogRequest struct {
+	BlogId uint64 `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// unix nanoseconds, to_time 0 for now. The content is empty at a moment
+	// the blog didn't exist.
+	FromTime             int64    `protobuf:"varint,2,opt,name=from_time,json=fromTime,proto3" json:"from_time,omitempty"`
+	ToTime               int64    `protobuf:"varint,3,opt,name=to_time,json=toTime,proto3" json:"to_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiffBlogRequest) Reset()         { *m = DiffBlogRequest{} }
+func (m *DiffBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*DiffBlogRequest) ProtoMessage()    {}
+func (*DiffBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{23}
+}
+
+func (m *DiffBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffBlogRequest.Unmarshal(m, b)
+}
+func (m *DiffBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *DiffBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffBlogRequest.Merge(m, src)
+}
+func (m *DiffBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_DiffBlogRequest.Size(m)
+}
+func (m *DiffBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffBlogRequest proto.InternalMessageInfo
+
+func (m *DiffBlogRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *DiffBlogRequest) GetFromTime() int64 {
+	if m != nil {
+		return m.FromTime
+	}
+	return 0
+}
+
+func (m *DiffBlogRequest) GetToTime() int64 {
+	if m != nil {
+		return m.ToTime
+	}
+	return 0
+}
+
+type DiffLine struct {
+	Op   DiffLine_Op `protobuf:"varint,1,opt,name=op,proto3,enum=blog.DiffLine_Op" json:"op,omitempty"`
+	Text string      `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// line numbers starting at 1, 0 where the line isn't
+	FromLine             int32    `protobuf:"varint,3,opt,name=from_line,json=fromLine,proto3" json:"from_line,omitempty"`
+	ToLine               int32    `protobuf:"varint,4,opt,name=to_line,json=toLine,proto3" json:"to_line,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiffLine) Reset()         { *m = DiffLine{} }
+func (m *DiffLine) String() string { return proto.CompactTextString(m) }
+func (*DiffLine) ProtoMessage()    {}
+func (*DiffLine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{24}
+}
+
+func (m *DiffLine) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffLine.Unmarshal(m, b)
+}
+func (m *DiffLine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffLine.Marshal(b, m, deterministic)
+}
+func (m *DiffLine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffLine.Merge(m, src)
+}
+func (m *DiffLine) XXX_Size() int {
+	return xxx_messageInfo_DiffLine.Size(m)
+}
+func (m *DiffLine) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffLine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffLine proto.InternalMessageInfo
+
+func (m *DiffLine) GetOp() DiffLine_Op {
+	if m != nil {
+		return m.Op
+	}
+	return DiffLine_EQUAL
+}
+
+func (m *DiffLine) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *DiffLine) GetFromLine() int32 {
+	if m != nil {
+		return m.FromLine
+	}
+	return 0
+}
+
+func (m *DiffLine) GetToLine() int32 {
+	if m != nil {
+		return m.ToLine
+	}
+	return 0
+}
+
+type DiffBlogResponse struct {
+	From                 *Blog       `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *Blog       `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Lines                []*DiffLine `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DiffBlogResponse) Reset()         { *m = DiffBlogResponse{} }
+func (m *DiffBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*DiffBlogResponse) ProtoMessage()    {}
+func (*DiffBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{25}
+}
+
+func (m *DiffBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffBlogResponse.Unmarshal(m, b)
+}
+func (m *DiffBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *DiffBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffBlogResponse.Merge(m, src)
+}
+func (m *DiffBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_DiffBlogResponse.Size(m)
+}
+func (m *DiffBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffBlogResponse proto.InternalMessageInfo
+
+func (m *DiffBlogResponse) GetFrom() *Blog {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *DiffBlogResponse) GetTo() *Blog {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *DiffBlogResponse) GetLines() []*DiffLine {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+// Storage taken by the blogs owned by author_id, the owner in their ACL, or
+// by every blog. The bytes count the whole content of the blogs, uploaded
+// content included.
+type StorageUsage struct {
+	AuthorId             string   `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Posts                uint64   `protobuf:"varint,2,opt,name=posts,proto3" json:"posts,omitempty"`
+	Bytes                uint64   `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageUsage) Reset()         { *m = StorageUsage{} }
+func (m *StorageUsage) String() string { return proto.CompactTextString(m) }
+func (*StorageUsage) ProtoMessage()    {}
+func (*StorageUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{26}
+}
+
+func (m *StorageUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageUsage.Unmarshal(m, b)
+}
+func (m *StorageUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageUsage.Marshal(b, m, deterministic)
+}
+func (m *StorageUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageUsage.Merge(m, src)
+}
+func (m *StorageUsage) XXX_Size() int {
+	return xxx_messageInfo_StorageUsage.Size(m)
+}
+func (m *StorageUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageUsage proto.InternalMessageInfo
+
+func (m *StorageUsage) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *StorageUsage) GetPosts() uint64 {
+	if m != nil {
+		return m.Posts
+	}
+	return 0
+}
+
+func (m *StorageUsage) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+// Limits on the storage the blogs take, 0 for no limit. Writes that would go
+// past one fail with RESOURCE_EXHAUSTED and a QuotaFailure detail.
+type StorageQuota struct {
+	MaxPostsPerAuthor    uint64   `protobuf:"varint,1,opt,name=max_posts_per_author,json=maxPostsPerAuthor,proto3" json:"max_posts_per_author,omitempty"`
+	MaxBytesPerAuthor    uint64   `protobuf:"varint,2,opt,name=max_bytes_per_author,json=maxBytesPerAuthor,proto3" json:"max_bytes_per_author,omitempty"`
+	MaxTotalBytes        uint64   `protobuf:"varint,3,opt,name=max_total_bytes,json=maxTotalBytes,proto3" json:"max_total_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageQuota) Reset()         { *m = StorageQuota{} }
+func (m *StorageQuota) String() string { return proto.CompactTextString(m) }
+func (*StorageQuota) ProtoMessage()    {}
+func (*StorageQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{27}
+}
+
+func (m *StorageQuota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageQuota.Unmarshal(m, b)
+}
+func (m *StorageQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageQuota.Marshal(b, m, deterministic)
+}
+func (m *StorageQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageQuota.Merge(m, src)
+}
+func (m *StorageQuota) XXX_Size() int {
+	return xxx_messageInfo_StorageQuota.Size(m)
+}
+func (m *StorageQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageQuota proto.InternalMessageInfo
+
+func (m *StorageQuota) GetMaxPostsPerAuthor() uint64 {
+	if m != nil {
+		return m.MaxPostsPerAuthor
+	}
+	return 0
+}
+
+func (m *StorageQuota) GetMaxBytesPerAuthor() uint64 {
+	if m != nil {
+		return m.MaxBytesPerAuthor
+	}
+	return 0
+}
+
+func (m *StorageQuota) GetMaxTotalBytes() uint64 {
+	if m != nil {
+		return m.MaxTotalBytes
+	}
+	return 0
+}
+
+type GetUsageRequest struct {
+	AuthorId             string   `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetUsageRequest) Reset()         { *m = GetUsageRequest{} }
+func (m *GetUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUsageRequest) ProtoMessage()    {}
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{28}
+}
+
+func (m *GetUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUsageRequest.Unmarshal(m, b)
+}
+func (m *GetUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUsageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUsageRequest.Merge(m, src)
+}
+func (m *GetUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetUsageRequest.Size(m)
+}
+func (m *GetUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUsageRequest proto.InternalMessageInfo
+
+func (m *GetUsageRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+type GetUsageResponse struct {
+	Author               *StorageUsage `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	Total                *StorageUsage `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	Quota                *StorageQuota `protobuf:"bytes,3,opt,name=quota,proto3" json:"quota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetUsageResponse) Reset()         { *m = GetUsageResponse{} }
+func (m *GetUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetUsageResponse) ProtoMessage()    {}
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{29}
+}
+
+func (m *GetUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUsageResponse.Unmarshal(m, b)
+}
+func (m *GetUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUsageResponse.Marshal(b, m, deterministic)
+}
+func (m *GetUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUsageResponse.Merge(m, src)
+}
+func (m *GetUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetUsageResponse.Size(m)
+}
+func (m *GetUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUsageResponse proto.InternalMessageInfo
+
+func (m *GetUsageResponse) GetAuthor() *StorageUsage {
+	if m != nil {
+		return m.Author
+	}
+	return nil
+}
+
+func (m *GetUsageResponse) GetTotal() *StorageUsage {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *GetUsageResponse) GetQuota() *StorageQuota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+type StreamBlogContentRequest struct {
+	BlogId               uint64   `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Offset               uint64   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamBlogContentRequest) Reset()         { *m = StreamBlogContentRequest{} }
+func (m *StreamBlogContentRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamBlogContentRequest) ProtoMessage()    {}
+func (*StreamBlogContentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{30}
+}
+
+func (m *StreamBlogContentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamBlogContentRequest.Unmarshal(m, b)
+}
+func (m *StreamBlogContentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamBlogContentRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamBlogContentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamBlogContentRequest.Merge(m, src)
+}
+func (m *StreamBlogContentRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamBlogContentRequest.Size(m)
+}
+func (m *StreamBlogContentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamBlogContentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamBlogContentRequest proto.InternalMessageInfo
+
+func (m *StreamBlogContentRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *StreamBlogContentRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type StreamBlogContentResponse struct {
+	Offset               uint64   `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	ContentSize          uint64   `protobuf:"varint,3,opt,name=content_size,json=contentSize,proto3" json:"content_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamBlogContentResponse) Reset()         { *m = StreamBlogContentResponse{} }
+func (m *StreamBlogContentResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamBlogContentResponse) ProtoMessage()    {}
+func (*StreamBlogContentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{31}
+}
+
+func (m *StreamBlogContentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamBlogContentResponse.Unmarshal(m, b)
+}
+func (m *StreamBlogContentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamBlogContentResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamBlogContentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamBlogContentResponse.Merge(m, src)
+}
+func (m *StreamBlogContentResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamBlogContentResponse.Size(m)
+}
+func (m *StreamBlogContentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamBlogContentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamBlogContentResponse proto.InternalMessageInfo
+
+func (m *StreamBlogContentResponse) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *StreamBlogContentResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *StreamBlogContentResponse) GetContentSize() uint64 {
+	if m != nil {
+		return m.ContentSize
+	}
+	return 0
+}
+
+// blog_id and offset are only read from the first message of the stream.
+// Offset 0 starts a new upload, any other must be where the upload of the
+// blog stopped: a wrong one fails with FAILED_PRECONDITION and the expected
+// one in the x-upload-offset trailer.
+type WriteBlogContentRequest struct {
+	BlogId uint64 `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data   []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// the upload ends with this stream, and the content of the blog is
+	// replaced with it
+	Complete             bool     `protobuf:"varint,4,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteBlogContentRequest) Reset()         { *m = WriteBlogContentRequest{} }
+func (m *WriteBlogContentRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteBlogContentRequest) ProtoMessage()    {}
+func (*WriteBlogContentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{32}
+}
+
+func (m *WriteBlogContentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteBlogContentRequest.Unmarshal(m, b)
+}
+func (m *WriteBlogContentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteBlogContentRequest.Marshal(b, m, deterministic)
+}
+func (m *WriteBlogContentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteBlogContentRequest.Merge(m, src)
+}
+func (m *WriteBlogContentRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteBlogContentRequest.Size(m)
+}
+func (m *WriteBlogContentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteBlogContentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteBlogContentRequest proto.InternalMessageInfo
+
+func (m *WriteBlogContentRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *WriteBlogContentRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *WriteBlogContentRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *WriteBlogContentRequest) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+type WriteBlogContentResponse struct {
+	Offset               uint64   `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Blog                 *Blog    `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteBlogContentResponse) Reset()         { *m = WriteBlogContentResponse{} }
+func (m *WriteBlogContentResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteBlogContentResponse) ProtoMessage()    {}
+func (*WriteBlogContentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{33}
+}
+
+func (m *WriteBlogContentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteBlogContentResponse.Unmarshal(m, b)
+}
+func (m *WriteBlogContentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteBlogContentResponse.Marshal(b, m, deterministic)
+}
+func (m *WriteBlogContentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteBlogContentResponse.Merge(m, src)
+}
+func (m *WriteBlogContentResponse) XXX_Size() int {
+	return xxx_messageInfo_WriteBlogContentResponse.Size(m)
+}
+func (m *WriteBlogContentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteBlogContentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteBlogContentResponse proto.InternalMessageInfo
+
+func (m *WriteBlogContentResponse) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *WriteBlogContentResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type CreateSeriesRequest struct {
+	Series               *Series  `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateSeriesRequest) Reset()         { *m = CreateSeriesRequest{} }
+func (m *CreateSeriesRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateSeriesRequest) ProtoMessage()    {}
+func (*CreateSeriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{34}
+}
+
+func (m *CreateSeriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSeriesRequest.Unmarshal(m, b)
+}
+func (m *CreateSeriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSeriesRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateSeriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSeriesRequest.Merge(m, src)
+}
+func (m *CreateSeriesRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateSeriesRequest.Size(m)
+}
+func (m *CreateSeriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSeriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSeriesRequest proto.InternalMessageInfo
+
+func (m *CreateSeriesRequest) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+type CreateSeriesResponse struct {
+	Series               *Series  `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateSeriesResponse) Reset()         { *m = CreateSeriesResponse{} }
+func (m *CreateSeriesResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateSeriesResponse) ProtoMessage()    {}
+func (*CreateSeriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{35}
+}
+
+func (m *CreateSeriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSeriesResponse.Unmarshal(m, b)
+}
+func (m *CreateSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSeriesResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSeriesResponse.Merge(m, src)
+}
+func (m *CreateSeriesResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateSeriesResponse.Size(m)
+}
+func (m *CreateSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSeriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSeriesResponse proto.InternalMessageInfo
+
+func (m *CreateSeriesResponse) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+type GetSeriesRequest struct {
+	SeriesId             uint64   `protobuf:"varint,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	Locales              []string `protobuf:"bytes,2,rep,name=locales,proto3" json:"locales,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSeriesRequest) Reset()         { *m = GetSeriesRequest{} }
+func (m *GetSeriesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSeriesRequest) ProtoMessage()    {}
+func (*GetSeriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{36}
+}
+
+func (m *GetSeriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSeriesRequest.Unmarshal(m, b)
+}
+func (m *GetSeriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSeriesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSeriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSeriesRequest.Merge(m, src)
+}
+func (m *GetSeriesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSeriesRequest.Size(m)
+}
+func (m *GetSeriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSeriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSeriesRequest proto.InternalMessageInfo
+
+func (m *GetSeriesRequest) GetSeriesId() uint64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+func (m *GetSeriesRequest) GetLocales() []string {
+	if m != nil {
+		return m.Locales
+	}
+	return nil
+}
+
+type GetSeriesResponse struct {
+	Series               *Series  `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	Blogs                []*Blog  `protobuf:"bytes,2,rep,name=blogs,proto3" json:"blogs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSeriesResponse) Reset()         { *m = GetSeriesResponse{} }
+func (m *GetSeriesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSeriesResponse) ProtoMessage()    {}
+func (*GetSeriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{37}
+}
+
+func (m *GetSeriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSeriesResponse.Unmarshal(m, b)
+}
+func (m *GetSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSeriesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSeriesResponse.Merge(m, src)
+}
+func (m *GetSeriesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSeriesResponse.Size(m)
+}
+func (m *GetSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSeriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSeriesResponse proto.InternalMessageInfo
+
+func (m *GetSeriesResponse) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+func (m *GetSeriesResponse) GetBlogs() []*Blog {
+	if m != nil {
+		return m.Blogs
+	}
+	return nil
+}
+
+type DeleteSeriesRequest struct {
+	SeriesId             uint64   `protobuf:"varint,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSeriesRequest) Reset()         { *m = DeleteSeriesRequest{} }
+func (m *DeleteSeriesRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteSeriesRequest) ProtoMessage()    {}
+func (*DeleteSeriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{38}
+}
+
+func (m *DeleteSeriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSeriesRequest.Unmarshal(m, b)
+}
+func (m *DeleteSeriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSeriesRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteSeriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSeriesRequest.Merge(m, src)
+}
+func (m *DeleteSeriesRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteSeriesRequest.Size(m)
+}
+func (m *DeleteSeriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSeriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSeriesRequest proto.InternalMessageInfo
+
+func (m *DeleteSeriesRequest) GetSeriesId() uint64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+type DeleteSeriesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSeriesResponse) Reset()         { *m = DeleteSeriesResponse{} }
+func (m *DeleteSeriesResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteSeriesResponse) ProtoMessage()    {}
+func (*DeleteSeriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{39}
+}
+
+func (m *DeleteSeriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSeriesResponse.Unmarshal(m, b)
+}
+func (m *DeleteSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSeriesResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSeriesResponse.Merge(m, src)
+}
+func (m *DeleteSeriesResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteSeriesResponse.Size(m)
+}
+func (m *DeleteSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSeriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSeriesResponse proto.InternalMessageInfo
+
+type AddToSeriesRequest struct {
+	SeriesId uint64 `protobuf:"varint,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	BlogId   uint64 `protobuf:"varint,2,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// where to insert the blog, starting at 1. 0 or past the end appends it.
+	Position             int32    `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddToSeriesRequest) Reset()         { *m = AddToSeriesRequest{} }
+func (m *AddToSeriesRequest) String() string { return proto.CompactTextString(m) }
+func (*AddToSeriesRequest) ProtoMessage()    {}
+func (*AddToSeriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{40}
+}
+
+func (m *AddToSeriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddToSeriesRequest.Unmarshal(m, b)
+}
+func (m *AddToSeriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddToSeriesRequest.Marshal(b, m, deterministic)
+}
+func (m *AddToSeriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddToSeriesRequest.Merge(m, src)
+}
+func (m *AddToSeriesRequest) XXX_Size() int {
+	return xxx_messageInfo_AddToSeriesRequest.Size(m)
+}
+func (m *AddToSeriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddToSeriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddToSeriesRequest proto.InternalMessageInfo
+
+func (m *AddToSeriesRequest) GetSeriesId() uint64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+func (m *AddToSeriesRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *AddToSeriesRequest) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+type AddToSeriesResponse struct {
+	Series               *Series  `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddToSeriesResponse) Reset()         { *m = AddToSeriesResponse{} }
+func (m *AddToSeriesResponse) String() string { return proto.CompactTextString(m) }
+func (*AddToSeriesResponse) ProtoMessage()    {}
+func (*AddToSeriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{41}
+}
+
+func (m *AddToSeriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddToSeriesResponse.Unmarshal(m, b)
+}
+func (m *AddToSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddToSeriesResponse.Marshal(b, m, deterministic)
+}
+func (m *AddToSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddToSeriesResponse.Merge(m, src)
+}
+func (m *AddToSeriesResponse) XXX_Size() int {
+	return xxx_messageInfo_AddToSeriesResponse.Size(m)
+}
+func (m *AddToSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddToSeriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddToSeriesResponse proto.InternalMessageInfo
+
+func (m *AddToSeriesResponse) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+type MoveInSeriesRequest struct {
+	SeriesId             uint64   `protobuf:"varint,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	BlogId               uint64   `protobuf:"varint,2,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Position             int32    `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveInSeriesRequest) Reset()         { *m = MoveInSeriesRequest{} }
+func (m *MoveInSeriesRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveInSeriesRequest) ProtoMessage()    {}
+func (*MoveInSeriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{42}
+}
+
+func (m *MoveInSeriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveInSeriesRequest.Unmarshal(m, b)
+}
+func (m *MoveInSeriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveInSeriesRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveInSeriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveInSeriesRequest.Merge(m, src)
+}
+func (m *MoveInSeriesRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveInSeriesRequest.Size(m)
+}
+func (m *MoveInSeriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveInSeriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveInSeriesRequest proto.InternalMessageInfo
+
+func (m *MoveInSeriesRequest) GetSeriesId() uint64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+func (m *MoveInSeriesRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *MoveInSeriesRequest) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+type MoveInSeriesResponse struct {
+	Series               *Series  `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveInSeriesResponse) Reset()         { *m = MoveInSeriesResponse{} }
+func (m *MoveInSeriesResponse) String() string { return proto.CompactTextString(m) }
+func (*MoveInSeriesResponse) ProtoMessage()    {}
+func (*MoveInSeriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{43}
+}
+
+func (m *MoveInSeriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveInSeriesResponse.Unmarshal(m, b)
+}
+func (m *MoveInSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveInSeriesResponse.Marshal(b, m, deterministic)
+}
+func (m *MoveInSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveInSeriesResponse.Merge(m, src)
+}
+func (m *MoveInSeriesResponse) XXX_Size() int {
+	return xxx_messageInfo_MoveInSeriesResponse.Size(m)
+}
+func (m *MoveInSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveInSeriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveInSeriesResponse proto.InternalMessageInfo
+
+func (m *MoveInSeriesResponse) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+type RemoveFromSeriesRequest struct {
+	SeriesId             uint64   `protobuf:"varint,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	BlogId               uint64   `protobuf:"varint,2,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveFromSeriesRequest) Reset()         { *m = RemoveFromSeriesRequest{} }
+func (m *RemoveFromSeriesRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveFromSeriesRequest) ProtoMessage()    {}
+func (*RemoveFromSeriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{44}
+}
+
+func (m *RemoveFromSeriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveFromSeriesRequest.Unmarshal(m, b)
+}
+func (m *RemoveFromSeriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveFromSeriesRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveFromSeriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveFromSeriesRequest.Merge(m, src)
+}
+func (m *RemoveFromSeriesRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveFromSeriesRequest.Size(m)
+}
+func (m *RemoveFromSeriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveFromSeriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveFromSeriesRequest proto.InternalMessageInfo
+
+func (m *RemoveFromSeriesRequest) GetSeriesId() uint64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+func (m *RemoveFromSeriesRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+type RemoveFromSeriesResponse struct {
+	Series               *Series  `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveFromSeriesResponse) Reset()         { *m = RemoveFromSeriesResponse{} }
+func (m *RemoveFromSeriesResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveFromSeriesResponse) ProtoMessage()    {}
+func (*RemoveFromSeriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{45}
+}
+
+func (m *RemoveFromSeriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveFromSeriesResponse.Unmarshal(m, b)
+}
+func (m *RemoveFromSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveFromSeriesResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveFromSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveFromSeriesResponse.Merge(m, src)
+}
+func (m *RemoveFromSeriesResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveFromSeriesResponse.Size(m)
+}
+func (m *RemoveFromSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveFromSeriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveFromSeriesResponse proto.InternalMessageInfo
+
+func (m *RemoveFromSeriesResponse) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+type UpsertBlogTranslationRequest struct {
+	BlogId               uint64           `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Translation          *BlogTranslation `protobuf:"bytes,2,opt,name=translation,proto3" json:"translation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *UpsertBlogTranslationRequest) Reset()         { *m = UpsertBlogTranslationRequest{} }
+func (m *UpsertBlogTranslationRequest) String() string { return proto.CompactTextString(m) }
+func (*UpsertBlogTranslationRequest) ProtoMessage()    {}
+func (*UpsertBlogTranslationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{46}
+}
+
+func (m *UpsertBlogTranslationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpsertBlogTranslationRequest.Unmarshal(m, b)
+}
+func (m *UpsertBlogTranslationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpsertBlogTranslationRequest.Marshal(b, m, deterministic)
+}
+func (m *UpsertBlogTranslationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpsertBlogTranslationRequest.Merge(m, src)
+}
+func (m *UpsertBlogTranslationRequest) XXX_Size() int {
+	return xxx_messageInfo_UpsertBlogTranslationRequest.Size(m)
+}
+func (m *UpsertBlogTranslationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpsertBlogTranslationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpsertBlogTranslationRequest proto.InternalMessageInfo
+
+func (m *UpsertBlogTranslationRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *UpsertBlogTranslationRequest) GetTranslation() *BlogTranslation {
+	if m != nil {
+		return m.Translation
+	}
+	return nil
+}
+
+type UpsertBlogTranslationResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpsertBlogTranslationResponse) Reset()         { *m = UpsertBlogTranslationResponse{} }
+func (m *UpsertBlogTranslationResponse) String() string { return proto.CompactTextString(m) }
+func (*UpsertBlogTranslationResponse) ProtoMessage()    {}
+func (*UpsertBlogTranslationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{47}
+}
+
+func (m *UpsertBlogTranslationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpsertBlogTranslationResponse.Unmarshal(m, b)
+}
+func (m *UpsertBlogTranslationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpsertBlogTranslationResponse.Marshal(b, m, deterministic)
+}
+func (m *UpsertBlogTranslationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpsertBlogTranslationResponse.Merge(m, src)
+}
+func (m *UpsertBlogTranslationResponse) XXX_Size() int {
+	return xxx_messageInfo_UpsertBlogTranslationResponse.Size(m)
+}
+func (m *UpsertBlogTranslationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpsertBlogTranslationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpsertBlogTranslationResponse proto.InternalMessageInfo
+
+func (m *UpsertBlogTranslationResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type DeleteBlogTranslationRequest struct {
+	BlogId               uint64   `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Locale               string   `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBlogTranslationRequest) Reset()         { *m = DeleteBlogTranslationRequest{} }
+func (m *DeleteBlogTranslationRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteBlogTranslationRequest) ProtoMessage()    {}
+func (*DeleteBlogTranslationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{48}
+}
+
+func (m *DeleteBlogTranslationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBlogTranslationRequest.Unmarshal(m, b)
+}
+func (m *DeleteBlogTranslationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBlogTranslationRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteBlogTranslationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBlogTranslationRequest.Merge(m, src)
+}
+func (m *DeleteBlogTranslationRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteBlogTranslationRequest.Size(m)
+}
+func (m *DeleteBlogTranslationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBlogTranslationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBlogTranslationRequest proto.InternalMessageInfo
+
+func (m *DeleteBlogTranslationRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *DeleteBlogTranslationRequest) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+type DeleteBlogTranslationResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBlogTranslationResponse) Reset()         { *m = DeleteBlogTranslationResponse{} }
+func (m *DeleteBlogTranslationResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteBlogTranslationResponse) ProtoMessage()    {}
+func (*DeleteBlogTranslationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{49}
+}
+
+func (m *DeleteBlogTranslationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBlogTranslationResponse.Unmarshal(m, b)
+}
+func (m *DeleteBlogTranslationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBlogTranslationResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteBlogTranslationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBlogTranslationResponse.Merge(m, src)
+}
+func (m *DeleteBlogTranslationResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteBlogTranslationResponse.Size(m)
+}
+func (m *DeleteBlogTranslationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBlogTranslationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBlogTranslationResponse proto.InternalMessageInfo
+
+type RelatedBlogsRequest struct {
+	BlogId               uint64   `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RelatedBlogsRequest) Reset()         { *m = RelatedBlogsRequest{} }
+func (m *RelatedBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*RelatedBlogsRequest) ProtoMessage()    {}
+func (*RelatedBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{50}
+}
+
+func (m *RelatedBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RelatedBlogsRequest.Unmarshal(m, b)
+}
+func (m *RelatedBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RelatedBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *RelatedBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelatedBlogsRequest.Merge(m, src)
+}
+func (m *RelatedBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_RelatedBlogsRequest.Size(m)
+}
+func (m *RelatedBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelatedBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RelatedBlogsRequest proto.InternalMessageInfo
+
+func (m *RelatedBlogsRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *RelatedBlogsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type RelatedBlog struct {
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// TF-IDF cosine similarity of the title and content with the requested
+	// blog, between 0 and 1
+	Score                float64  `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RelatedBlog) Reset()         { *m = RelatedBlog{} }
+func (m *RelatedBlog) String() string { return proto.CompactTextString(m) }
+func (*RelatedBlog) ProtoMessage()    {}
+func (*RelatedBlog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{51}
+}
+
+func (m *RelatedBlog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RelatedBlog.Unmarshal(m, b)
+}
+func (m *RelatedBlog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RelatedBlog.Marshal(b, m, deterministic)
+}
+func (m *RelatedBlog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelatedBlog.Merge(m, src)
+}
+func (m *RelatedBlog) XXX_Size() int {
+	return xxx_messageInfo_RelatedBlog.Size(m)
+}
+func (m *RelatedBlog) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelatedBlog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RelatedBlog proto.InternalMessageInfo
+
+func (m *RelatedBlog) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *RelatedBlog) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type RelatedBlogsResponse struct {
+	Blogs                []*RelatedBlog `protobuf:"bytes,1,rep,name=blogs,proto3" json:"blogs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RelatedBlogsResponse) Reset()         { *m = RelatedBlogsResponse{} }
+func (m *RelatedBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*RelatedBlogsResponse) ProtoMessage()    {}
+func (*RelatedBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{52}
+}
+
+func (m *RelatedBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RelatedBlogsResponse.Unmarshal(m, b)
+}
+func (m *RelatedBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RelatedBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *RelatedBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelatedBlogsResponse.Merge(m, src)
+}
+func (m *RelatedBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_RelatedBlogsResponse.Size(m)
+}
+func (m *RelatedBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelatedBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RelatedBlogsResponse proto.InternalMessageInfo
+
+func (m *RelatedBlogsResponse) GetBlogs() []*RelatedBlog {
+	if m != nil {
+		return m.Blogs
+	}
+	return nil
+}
+
+type LikeBlogRequest struct {
+	BlogId uint64 `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// who likes the blog, ignored when the server authenticates callers
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LikeBlogRequest) Reset()         { *m = LikeBlogRequest{} }
+func (m *LikeBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*LikeBlogRequest) ProtoMessage()    {}
+func (*LikeBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{53}
+}
+
+func (m *LikeBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LikeBlogRequest.Unmarshal(m, b)
+}
+func (m *LikeBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LikeBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *LikeBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LikeBlogRequest.Merge(m, src)
+}
+func (m *LikeBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_LikeBlogRequest.Size(m)
+}
+func (m *LikeBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LikeBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LikeBlogRequest proto.InternalMessageInfo
+
+func (m *LikeBlogRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *LikeBlogRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type LikeBlogResponse struct {
+	Likes                uint64   `protobuf:"varint,1,opt,name=likes,proto3" json:"likes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LikeBlogResponse) Reset()         { *m = LikeBlogResponse{} }
+func (m *LikeBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*LikeBlogResponse) ProtoMessage()    {}
+func (*LikeBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{54}
+}
+
+func (m *LikeBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LikeBlogResponse.Unmarshal(m, b)
+}
+func (m *LikeBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LikeBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *LikeBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LikeBlogResponse.Merge(m, src)
+}
+func (m *LikeBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_LikeBlogResponse.Size(m)
+}
+func (m *LikeBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LikeBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LikeBlogResponse proto.InternalMessageInfo
+
+func (m *LikeBlogResponse) GetLikes() uint64 {
+	if m != nil {
+		return m.Likes
+	}
+	return 0
+}
+
+type UnlikeBlogRequest struct {
+	BlogId               uint64   `protobuf:"varint,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlikeBlogRequest) Reset()         { *m = UnlikeBlogRequest{} }
+func (m *UnlikeBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlikeBlogRequest) ProtoMessage()    {}
+func (*UnlikeBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{55}
+}
+
+func (m *UnlikeBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlikeBlogRequest.Unmarshal(m, b)
+}
+func (m *UnlikeBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlikeBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *UnlikeBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlikeBlogRequest.Merge(m, src)
+}
+func (m *UnlikeBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlikeBlogRequest.Size(m)
+}
+func (m *UnlikeBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlikeBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlikeBlogRequest proto.InternalMessageInfo
+
+func (m *UnlikeBlogRequest) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *UnlikeBlogRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type UnlikeBlogResponse struct {
+	Likes                uint64   `protobuf:"varint,1,opt,name=likes,proto3" json:"likes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlikeBlogResponse) Reset()         { *m = UnlikeBlogResponse{} }
+func (m *UnlikeBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*UnlikeBlogResponse) ProtoMessage()    {}
+func (*UnlikeBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{56}
+}
+
+func (m *UnlikeBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlikeBlogResponse.Unmarshal(m, b)
+}
+func (m *UnlikeBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlikeBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *UnlikeBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlikeBlogResponse.Merge(m, src)
+}
+func (m *UnlikeBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_UnlikeBlogResponse.Size(m)
+}
+func (m *UnlikeBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlikeBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlikeBlogResponse proto.InternalMessageInfo
+
+func (m *UnlikeBlogResponse) GetLikes() uint64 {
+	if m != nil {
+		return m.Likes
+	}
+	return 0
+}
+
+type TopBlogsRequest struct {
+	Metric TopBlogsRequest_Metric `protobuf:"varint,1,opt,name=metric,proto3,enum=blog.TopBlogsRequest_Metric" json:"metric,omitempty"`
+	// only count the views and likes of the last window_seconds, all of them
+	// when 0. Views are counted by the hour.
+	WindowSeconds        int64    `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TopBlogsRequest) Reset()         { *m = TopBlogsRequest{} }
+func (m *TopBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*TopBlogsRequest) ProtoMessage()    {}
+func (*TopBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{57}
+}
+
+func (m *TopBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopBlogsRequest.Unmarshal(m, b)
+}
+func (m *TopBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *TopBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopBlogsRequest.Merge(m, src)
+}
+func (m *TopBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_TopBlogsRequest.Size(m)
+}
+func (m *TopBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopBlogsRequest proto.InternalMessageInfo
+
+func (m *TopBlogsRequest) GetMetric() TopBlogsRequest_Metric {
+	if m != nil {
+		return m.Metric
+	}
+	return TopBlogsRequest_VIEWS
+}
+
+func (m *TopBlogsRequest) GetWindowSeconds() int64 {
+	if m != nil {
+		return m.WindowSeconds
+	}
+	return 0
+}
+
+func (m *TopBlogsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type TopBlog struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	Count                uint64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TopBlog) Reset()         { *m = TopBlog{} }
+func (m *TopBlog) String() string { return proto.CompactTextString(m) }
+func (*TopBlog) ProtoMessage()    {}
+func (*TopBlog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{58}
+}
+
+func (m *TopBlog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopBlog.Unmarshal(m, b)
+}
+func (m *TopBlog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopBlog.Marshal(b, m, deterministic)
+}
+func (m *TopBlog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopBlog.Merge(m, src)
+}
+func (m *TopBlog) XXX_Size() int {
+	return xxx_messageInfo_TopBlog.Size(m)
+}
+func (m *TopBlog) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopBlog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopBlog proto.InternalMessageInfo
+
+func (m *TopBlog) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *TopBlog) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type TopBlogsResponse struct {
+	Blogs                []*TopBlog `protobuf:"bytes,1,rep,name=blogs,proto3" json:"blogs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *TopBlogsResponse) Reset()         { *m = TopBlogsResponse{} }
+func (m *TopBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*TopBlogsResponse) ProtoMessage()    {}
+func (*TopBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{59}
+}
+
+func (m *TopBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopBlogsResponse.Unmarshal(m, b)
+}
+func (m *TopBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *TopBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopBlogsResponse.Merge(m, src)
+}
+func (m *TopBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_TopBlogsResponse.Size(m)
+}
+func (m *TopBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopBlogsResponse proto.InternalMessageInfo
+
+func (m *TopBlogsResponse) GetBlogs() []*TopBlog {
+	if m != nil {
+		return m.Blogs
+	}
+	return nil
+}
+
+type CompactDatabaseRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompactDatabaseRequest) Reset()         { *m = CompactDatabaseRequest{} }
+func (m *CompactDatabaseRequest) String() string { return proto.CompactTextString(m) }
+func (*CompactDatabaseRequest) ProtoMessage()    {}
+func (*CompactDatabaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{60}
+}
+
+func (m *CompactDatabaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactDatabaseRequest.Unmarshal(m, b)
+}
+func (m *CompactDatabaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactDatabaseRequest.Marshal(b, m, deterministic)
+}
+func (m *CompactDatabaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactDatabaseRequest.Merge(m, src)
+}
+func (m *CompactDatabaseRequest) XXX_Size() int {
+	return xxx_messageInfo_CompactDatabaseRequest.Size(m)
+}
+func (m *CompactDatabaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactDatabaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactDatabaseRequest proto.InternalMessageInfo
+
+type CompactDatabaseResponse struct {
+	SizeBefore           int64    `protobuf:"varint,1,opt,name=size_before,json=sizeBefore,proto3" json:"size_before,omitempty"`
+	SizeAfter            int64    `protobuf:"varint,2,opt,name=size_after,json=sizeAfter,proto3" json:"size_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompactDatabaseResponse) Reset()         { *m = CompactDatabaseResponse{} }
+func (m *CompactDatabaseResponse) String() string { return proto.CompactTextString(m) }
+func (*CompactDatabaseResponse) ProtoMessage()    {}
+func (*CompactDatabaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{61}
+}
+
+func (m *CompactDatabaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactDatabaseResponse.Unmarshal(m, b)
+}
+func (m *CompactDatabaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactDatabaseResponse.Marshal(b, m, deterministic)
+}
+func (m *CompactDatabaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactDatabaseResponse.Merge(m, src)
+}
+func (m *CompactDatabaseResponse) XXX_Size() int {
+	return xxx_messageInfo_CompactDatabaseResponse.Size(m)
+}
+func (m *CompactDatabaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactDatabaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactDatabaseResponse proto.InternalMessageInfo
+
+func (m *CompactDatabaseResponse) GetSizeBefore() int64 {
+	if m != nil {
+		return m.SizeBefore
+	}
+	return 0
+}
+
+func (m *CompactDatabaseResponse) GetSizeAfter() int64 {
+	if m != nil {
+		return m.SizeAfter
+	}
+	return 0
+}
+
+type DatabaseStatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DatabaseStatsRequest) Reset()         { *m = DatabaseStatsRequest{} }
+func (m *DatabaseStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*DatabaseStatsRequest) ProtoMessage()    {}
+func (*DatabaseStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{62}
+}
+
+func (m *DatabaseStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatabaseStatsRequest.Unmarshal(m, b)
+}
+func (m *DatabaseStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatabaseStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *DatabaseStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatabaseStatsRequest.Merge(m, src)
+}
+func (m *DatabaseStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_DatabaseStatsRequest.Size(m)
+}
+func (m *DatabaseStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatabaseStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatabaseStatsRequest proto.InternalMessageInfo
+
+type BucketStats struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KeyN                 int64    `protobuf:"varint,2,opt,name=key_n,json=keyN,proto3" json:"key_n,omitempty"`
+	Depth                int64    `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	BranchPageN          int64    `protobuf:"varint,4,opt,name=branch_page_n,json=branchPageN,proto3" json:"branch_page_n,omitempty"`
+	BranchOverflowN      int64    `protobuf:"varint,5,opt,name=branch_overflow_n,json=branchOverflowN,proto3" json:"branch_overflow_n,omitempty"`
+	LeafPageN            int64    `protobuf:"varint,6,opt,name=leaf_page_n,json=leafPageN,proto3" json:"leaf_page_n,omitempty"`
+	LeafOverflowN        int64    `protobuf:"varint,7,opt,name=leaf_overflow_n,json=leafOverflowN,proto3" json:"leaf_overflow_n,omitempty"`
+	BranchAlloc          int64    `protobuf:"varint,8,opt,name=branch_alloc,json=branchAlloc,proto3" json:"branch_alloc,omitempty"`
+	BranchInuse          int64    `protobuf:"varint,9,opt,name=branch_inuse,json=branchInuse,proto3" json:"branch_inuse,omitempty"`
+	LeafAlloc            int64    `protobuf:"varint,10,opt,name=leaf_alloc,json=leafAlloc,proto3" json:"leaf_alloc,omitempty"`
+	LeafInuse            int64    `protobuf:"varint,11,opt,name=leaf_inuse,json=leafInuse,proto3" json:"leaf_inuse,omitempty"`
+	BucketN              int64    `protobuf:"varint,12,opt,name=bucket_n,json=bucketN,proto3" json:"bucket_n,omitempty"`
+	InlineBucketN        int64    `protobuf:"varint,13,opt,name=inline_bucket_n,json=inlineBucketN,proto3" json:"inline_bucket_n,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BucketStats) Reset()         { *m = BucketStats{} }
+func (m *BucketStats) String() string { return proto.CompactTextString(m) }
+func (*BucketStats) ProtoMessage()    {}
+func (*BucketStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{63}
+}
+
+func (m *BucketStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketStats.Unmarshal(m, b)
+}
+func (m *BucketStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketStats.Marshal(b, m, deterministic)
+}
+func (m *BucketStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketStats.Merge(m, src)
+}
+func (m *BucketStats) XXX_Size() int {
+	return xxx_messageInfo_BucketStats.Size(m)
+}
+func (m *BucketStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketStats proto.InternalMessageInfo
+
+func (m *BucketStats) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *BucketStats) GetKeyN() int64 {
+	if m != nil {
+		return m.KeyN
+	}
+	return 0
+}
+
+func (m *BucketStats) GetDepth() int64 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *BucketStats) GetBranchPageN() int64 {
+	if m != nil {
+		return m.BranchPageN
+	}
+	return 0
+}
+
+func (m *BucketStats) GetBranchOverflowN() int64 {
+	if m != nil {
+		return m.BranchOverflowN
+	}
+	return 0
+}
+
+func (m *BucketStats) GetLeafPageN() int64 {
+	if m != nil {
+		return m.LeafPageN
+	}
+	return 0
+}
+
+func (m *BucketStats) GetLeafOverflowN() int64 {
+	if m != nil {
+		return m.LeafOverflowN
+	}
+	return 0
+}
+
+func (m *BucketStats) GetBranchAlloc() int64 {
+	if m != nil {
+		return m.BranchAlloc
+	}
+	return 0
+}
+
+func (m *BucketStats) GetBranchInuse() int64 {
+	if m != nil {
+		return m.BranchInuse
+	}
+	return 0
+}
+
+func (m *BucketStats) GetLeafAlloc() int64 {
+	if m != nil {
+		return m.LeafAlloc
+	}
+	return 0
+}
+
+func (m *BucketStats) GetLeafInuse() int64 {
+	if m != nil {
+		return m.LeafInuse
+	}
+	return 0
+}
+
+func (m *BucketStats) GetBucketN() int64 {
+	if m != nil {
+		return m.BucketN
+	}
+	return 0
+}
+
+func (m *BucketStats) GetInlineBucketN() int64 {
+	if m != nil {
+		return m.InlineBucketN
+	}
+	return 0
+}
+
+type DatabaseStatsResponse struct {
+	FileSize      int64          `protobuf:"varint,1,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	PageSize      int64          `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	DataSize      int64          `protobuf:"varint,3,opt,name=data_size,json=dataSize,proto3" json:"data_size,omitempty"`
+	FreePageN     int64          `protobuf:"varint,4,opt,name=free_page_n,json=freePageN,proto3" json:"free_page_n,omitempty"`
+	PendingPageN  int64          `protobuf:"varint,5,opt,name=pending_page_n,json=pendingPageN,proto3" json:"pending_page_n,omitempty"`
+	FreeAlloc     int64          `protobuf:"varint,6,opt,name=free_alloc,json=freeAlloc,proto3" json:"free_alloc,omitempty"`
+	FreelistInuse int64          `protobuf:"varint,7,opt,name=freelist_inuse,json=freelistInuse,proto3" json:"freelist_inuse,omitempty"`
+	TxN           int64          `protobuf:"varint,8,opt,name=tx_n,json=txN,proto3" json:"tx_n,omitempty"`
+	OpenTxN       int64          `protobuf:"varint,9,opt,name=open_tx_n,json=openTxN,proto3" json:"open_tx_n,omitempty"`
+	Buckets       []*BucketStats `protobuf:"bytes,10,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	// across the main database and the shards
+	Compression          []*CompressionStats `protobuf:"bytes,11,rep,name=compression,proto3" json:"compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DatabaseStatsResponse) Reset()         { *m = DatabaseStatsResponse{} }
+func (m *DatabaseStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*DatabaseStatsResponse) ProtoMessage()    {}
+func (*DatabaseStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{64}
+}
+
+func (m *DatabaseStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatabaseStatsResponse.Unmarshal(m, b)
+}
+func (m *DatabaseStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatabaseStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *DatabaseStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatabaseStatsResponse.Merge(m, src)
+}
+func (m *DatabaseStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_DatabaseStatsResponse.Size(m)
+}
+func (m *DatabaseStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatabaseStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatabaseStatsResponse proto.InternalMessageInfo
+
+func (m *DatabaseStatsResponse) GetFileSize() int64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetPageSize() int64 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetDataSize() int64 {
+	if m != nil {
+		return m.DataSize
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetFreePageN() int64 {
+	if m != nil {
+		return m.FreePageN
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetPendingPageN() int64 {
+	if m != nil {
+		return m.PendingPageN
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetFreeAlloc() int64 {
+	if m != nil {
+		return m.FreeAlloc
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetFreelistInuse() int64 {
+	if m != nil {
+		return m.FreelistInuse
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetTxN() int64 {
+	if m != nil {
+		return m.TxN
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetOpenTxN() int64 {
+	if m != nil {
+		return m.OpenTxN
+	}
+	return 0
+}
+
+func (m *DatabaseStatsResponse) GetBuckets() []*BucketStats {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *DatabaseStatsResponse) GetCompression() []*CompressionStats {
+	if m != nil {
+		return m.Compression
+	}
+	return nil
+}
+
+type CompressionStats struct {
+	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Values               int64    `protobuf:"varint,2,opt,name=values,proto3" json:"values,omitempty"`
+	CompressedValues     int64    `protobuf:"varint,3,opt,name=compressed_values,json=compressedValues,proto3" json:"compressed_values,omitempty"`
+	StoredBytes          int64    `protobuf:"varint,4,opt,name=stored_bytes,json=storedBytes,proto3" json:"stored_bytes,omitempty"`
+	RawBytes             int64    `protobuf:"varint,5,opt,name=raw_bytes,json=rawBytes,proto3" json:"raw_bytes,omitempty"`
+	Ratio                float64  `protobuf:"fixed64,6,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompressionStats) Reset()         { *m = CompressionStats{} }
+func (m *CompressionStats) String() string { return proto.CompactTextString(m) }
+func (*CompressionStats) ProtoMessage()    {}
+func (*CompressionStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{65}
+}
+
+func (m *CompressionStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompressionStats.Unmarshal(m, b)
+}
+func (m *CompressionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompressionStats.Marshal(b, m, deterministic)
+}
+func (m *CompressionStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompressionStats.Merge(m, src)
+}
+func (m *CompressionStats) XXX_Size() int {
+	return xxx_messageInfo_CompressionStats.Size(m)
+}
+func (m *CompressionStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompressionStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompressionStats proto.InternalMessageInfo
+
+func (m *CompressionStats) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *CompressionStats) GetValues() int64 {
+	if m != nil {
+		return m.Values
+	}
+	return 0
+}
+
+func (m *CompressionStats) GetCompressedValues() int64 {
+	if m != nil {
+		return m.CompressedValues
+	}
+	return 0
+}
+
+func (m *CompressionStats) GetStoredBytes() int64 {
+	if m != nil {
+		return m.StoredBytes
+	}
+	return 0
+}
+
+func (m *CompressionStats) GetRawBytes() int64 {
+	if m != nil {
+		return m.RawBytes
+	}
+	return 0
+}
+
+func (m *CompressionStats) GetRatio() float64 {
+	if m != nil {
+		return m.Ratio
+	}
+	return 0
+}
+
+// A write held by moderation until an admin resolves it
+type QuarantinedBlog struct {
+	Id        uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Blog      *Blog    `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	Update    bool     `protobuf:"varint,3,opt,name=update,proto3" json:"update,omitempty"`
+	Reasons   []string `protobuf:"bytes,4,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	Timestamp int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// who sent the write, whose rights are checked again when it is approved
+	Caller               string   `protobuf:"bytes,6,opt,name=caller,proto3" json:"caller,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuarantinedBlog) Reset()         { *m = QuarantinedBlog{} }
+func (m *QuarantinedBlog) String() string { return proto.CompactTextString(m) }
+func (*QuarantinedBlog) ProtoMessage()    {}
+func (*QuarantinedBlog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{66}
+}
+
+func (m *QuarantinedBlog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuarantinedBlog.Unmarshal(m, b)
+}
+func (m *QuarantinedBlog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuarantinedBlog.Marshal(b, m, deterministic)
+}
+func (m *QuarantinedBlog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantinedBlog.Merge(m, src)
+}
+func (m *QuarantinedBlog) XXX_Size() int {
+	return xxx_messageInfo_QuarantinedBlog.Size(m)
+}
+func (m *QuarantinedBlog) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantinedBlog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantinedBlog proto.InternalMessageInfo
+
+func (m *QuarantinedBlog) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *QuarantinedBlog) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *QuarantinedBlog) GetUpdate() bool {
+	if m != nil {
+		return m.Update
+	}
+	return false
+}
+
+func (m *QuarantinedBlog) GetReasons() []string {
+	if m != nil {
+		return m.Reasons
+	}
+	return nil
+}
+
+func (m *QuarantinedBlog) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *QuarantinedBlog) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+type ListQuarantineRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListQuarantineRequest) Reset()         { *m = ListQuarantineRequest{} }
+func (m *ListQuarantineRequest) String() string { return proto.CompactTextString(m) }
+func (*ListQuarantineRequest) ProtoMessage()    {}
+func (*ListQuarantineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{67}
+}
+
+func (m *ListQuarantineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListQuarantineRequest.Unmarshal(m, b)
+}
+func (m *ListQuarantineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListQuarantineRequest.Marshal(b, m, deterministic)
+}
+func (m *ListQuarantineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListQuarantineRequest.Merge(m, src)
+}
+func (m *ListQuarantineRequest) XXX_Size() int {
+	return xxx_messageInfo_ListQuarantineRequest.Size(m)
+}
+func (m *ListQuarantineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListQuarantineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListQuarantineRequest proto.InternalMessageInfo
+
+type ListQuarantineResponse struct {
+	Blogs                []*QuarantinedBlog `protobuf:"bytes,1,rep,name=blogs,proto3" json:"blogs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListQuarantineResponse) Reset()         { *m = ListQuarantineResponse{} }
+func (m *ListQuarantineResponse) String() string { return proto.CompactTextString(m) }
+func (*ListQuarantineResponse) ProtoMessage()    {}
+func (*ListQuarantineResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{68}
+}
+
+func (m *ListQuarantineResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListQuarantineResponse.Unmarshal(m, b)
+}
+func (m *ListQuarantineResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListQuarantineResponse.Marshal(b, m, deterministic)
+}
+func (m *ListQuarantineResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListQuarantineResponse.Merge(m, src)
+}
+func (m *ListQuarantineResponse) XXX_Size() int {
+	return xxx_messageInfo_ListQuarantineResponse.Size(m)
+}
+func (m *ListQuarantineResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListQuarantineResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListQuarantineResponse proto.InternalMessageInfo
+
+func (m *ListQuarantineResponse) GetBlogs() []*QuarantinedBlog {
+	if m != nil {
+		return m.Blogs
+	}
+	return nil
+}
+
+type ResolveQuarantineRequest struct {
+	QuarantineId         uint64   `protobuf:"varint,1,opt,name=quarantine_id,json=quarantineId,proto3" json:"quarantine_id,omitempty"`
+	Approve              bool     `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolveQuarantineRequest) Reset()         { *m = ResolveQuarantineRequest{} }
+func (m *ResolveQuarantineRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolveQuarantineRequest) ProtoMessage()    {}
+func (*ResolveQuarantineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{69}
+}
+
+func (m *ResolveQuarantineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveQuarantineRequest.Unmarshal(m, b)
+}
+func (m *ResolveQuarantineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveQuarantineRequest.Marshal(b, m, deterministic)
+}
+func (m *ResolveQuarantineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveQuarantineRequest.Merge(m, src)
+}
+func (m *ResolveQuarantineRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolveQuarantineRequest.Size(m)
+}
+func (m *ResolveQuarantineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveQuarantineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveQuarantineRequest proto.InternalMessageInfo
+
+func (m *ResolveQuarantineRequest) GetQuarantineId() uint64 {
+	if m != nil {
+		return m.QuarantineId
+	}
+	return 0
+}
+
+func (m *ResolveQuarantineRequest) GetApprove() bool {
+	if m != nil {
+		return m.Approve
+	}
+	return false
+}
+
+type ResolveQuarantineResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolveQuarantineResponse) Reset()         { *m = ResolveQuarantineResponse{} }
+func (m *ResolveQuarantineResponse) String() string { return proto.CompactTextString(m) }
+func (*ResolveQuarantineResponse) ProtoMessage()    {}
+func (*ResolveQuarantineResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{70}
+}
+
+func (m *ResolveQuarantineResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveQuarantineResponse.Unmarshal(m, b)
+}
+func (m *ResolveQuarantineResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveQuarantineResponse.Marshal(b, m, deterministic)
+}
+func (m *ResolveQuarantineResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveQuarantineResponse.Merge(m, src)
+}
+func (m *ResolveQuarantineResponse) XXX_Size() int {
+	return xxx_messageInfo_ResolveQuarantineResponse.Size(m)
+}
+func (m *ResolveQuarantineResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveQuarantineResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveQuarantineResponse proto.InternalMessageInfo
+
+func (m *ResolveQuarantineResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type CacheStatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CacheStatsRequest) Reset()         { *m = CacheStatsRequest{} }
+func (m *CacheStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*CacheStatsRequest) ProtoMessage()    {}
+func (*CacheStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{71}
+}
+
+func (m *CacheStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheStatsRequest.Unmarshal(m, b)
+}
+func (m *CacheStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *CacheStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheStatsRequest.Merge(m, src)
+}
+func (m *CacheStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_CacheStatsRequest.Size(m)
+}
+func (m *CacheStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheStatsRequest proto.InternalMessageInfo
+
+type CacheStatsResponse struct {
+	Entries              int64    `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	Bytes                int64    `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	MaxEntries           int64    `protobuf:"varint,3,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	MaxBytes             int64    `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	Hits                 uint64   `protobuf:"varint,5,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses               uint64   `protobuf:"varint,6,opt,name=misses,proto3" json:"misses,omitempty"`
+	Evictions            uint64   `protobuf:"varint,7,opt,name=evictions,proto3" json:"evictions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CacheStatsResponse) Reset()         { *m = CacheStatsResponse{} }
+func (m *CacheStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*CacheStatsResponse) ProtoMessage()    {}
+func (*CacheStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{72}
+}
+
+func (m *CacheStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheStatsResponse.Unmarshal(m, b)
+}
+func (m *CacheStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *CacheStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheStatsResponse.Merge(m, src)
+}
+func (m *CacheStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_CacheStatsResponse.Size(m)
+}
+func (m *CacheStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheStatsResponse proto.InternalMessageInfo
+
+func (m *CacheStatsResponse) GetEntries() int64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+func (m *CacheStatsResponse) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *CacheStatsResponse) GetMaxEntries() int64 {
+	if m != nil {
+		return m.MaxEntries
+	}
+	return 0
+}
+
+func (m *CacheStatsResponse) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *CacheStatsResponse) GetHits() uint64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *CacheStatsResponse) GetMisses() uint64 {
+	if m != nil {
+		return m.Misses
+	}
+	return 0
+}
+
+func (m *CacheStatsResponse) GetEvictions() uint64 {
+	if m != nil {
+		return m.Evictions
+	}
+	return 0
+}
+
+type RotateEncryptionKeyRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateEncryptionKeyRequest) Reset()         { *m = RotateEncryptionKeyRequest{} }
+func (m *RotateEncryptionKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateEncryptionKeyRequest) ProtoMessage()    {}
+func (*RotateEncryptionKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{73}
+}
+
+func (m *RotateEncryptionKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateEncryptionKeyRequest.Unmarshal(m, b)
+}
+func (m *RotateEncryptionKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateEncryptionKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *RotateEncryptionKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateEncryptionKeyRequest.Merge(m, src)
+}
+func (m *RotateEncryptionKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RotateEncryptionKeyRequest.Size(m)
+}
+func (m *RotateEncryptionKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateEncryptionKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateEncryptionKeyRequest proto.InternalMessageInfo
+
+type RotateEncryptionKeyResponse struct {
+	Reencrypted          uint64   `protobuf:"varint,1,opt,name=reencrypted,proto3" json:"reencrypted,omitempty"`
+	RetiredKeys          uint64   `protobuf:"varint,2,opt,name=retired_keys,json=retiredKeys,proto3" json:"retired_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateEncryptionKeyResponse) Reset()         { *m = RotateEncryptionKeyResponse{} }
+func (m *RotateEncryptionKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateEncryptionKeyResponse) ProtoMessage()    {}
+func (*RotateEncryptionKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{74}
+}
+
+func (m *RotateEncryptionKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateEncryptionKeyResponse.Unmarshal(m, b)
+}
+func (m *RotateEncryptionKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateEncryptionKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *RotateEncryptionKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateEncryptionKeyResponse.Merge(m, src)
+}
+func (m *RotateEncryptionKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_RotateEncryptionKeyResponse.Size(m)
+}
+func (m *RotateEncryptionKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateEncryptionKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateEncryptionKeyResponse proto.InternalMessageInfo
+
+func (m *RotateEncryptionKeyResponse) GetReencrypted() uint64 {
+	if m != nil {
+		return m.Reencrypted
+	}
+	return 0
+}
+
+func (m *RotateEncryptionKeyResponse) GetRetiredKeys() uint64 {
+	if m != nil {
+		return m.RetiredKeys
+	}
+	return 0
+}
+
+// One write to the blog store, as recorded in the changelog
+type ChangeEntry struct {
+	Seq       uint64         `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Timestamp int64          `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Op        ChangeEntry_Op `protobuf:"varint,3,opt,name=op,proto3,enum=blog.ChangeEntry_Op" json:"op,omitempty"`
+	BlogId    uint64         `protobuf:"varint,4,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Blog      *Blog          `protobuf:"bytes,5,opt,name=blog,proto3" json:"blog,omitempty"`
+	SeriesId  uint64         `protobuf:"varint,6,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	Series    *Series        `protobuf:"bytes,7,opt,name=series,proto3" json:"series,omitempty"`
+	// for PUT_CHUNK and DELETE_CHUNKS, the content_version of the chunks
+	ContentVersion       uint64   `protobuf:"varint,8,opt,name=content_version,json=contentVersion,proto3" json:"content_version,omitempty"`
+	ChunkIndex           uint64   `protobuf:"varint,9,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	Chunk                []byte   `protobuf:"bytes,10,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Views                uint64   `protobuf:"varint,11,opt,name=views,proto3" json:"views,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangeEntry) Reset()         { *m = ChangeEntry{} }
+func (m *ChangeEntry) String() string { return proto.CompactTextString(m) }
+func (*ChangeEntry) ProtoMessage()    {}
+func (*ChangeEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{75}
+}
+
+func (m *ChangeEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangeEntry.Unmarshal(m, b)
+}
+func (m *ChangeEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangeEntry.Marshal(b, m, deterministic)
+}
+func (m *ChangeEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeEntry.Merge(m, src)
+}
+func (m *ChangeEntry) XXX_Size() int {
+	return xxx_messageInfo_ChangeEntry.Size(m)
+}
+func (m *ChangeEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangeEntry proto.InternalMessageInfo
+
+func (m *ChangeEntry) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *ChangeEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ChangeEntry) GetOp() ChangeEntry_Op {
+	if m != nil {
+		return m.Op
+	}
+	return ChangeEntry_PUT
+}
+
+func (m *ChangeEntry) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *ChangeEntry) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *ChangeEntry) GetSeriesId() uint64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+func (m *ChangeEntry) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+func (m *ChangeEntry) GetContentVersion() uint64 {
+	if m != nil {
+		return m.ContentVersion
+	}
+	return 0
+}
+
+func (m *ChangeEntry) GetChunkIndex() uint64 {
+	if m != nil {
+		return m.ChunkIndex
+	}
+	return 0
+}
+
+func (m *ChangeEntry) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+func (m *ChangeEntry) GetViews() uint64 {
+	if m != nil {
+		return m.Views
+	}
+	return 0
+}
+
+type StreamChangesRequest struct {
+	FromSeq              uint64   `protobuf:"varint,1,opt,name=from_seq,json=fromSeq,proto3" json:"from_seq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamChangesRequest) Reset()         { *m = StreamChangesRequest{} }
+func (m *StreamChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamChangesRequest) ProtoMessage()    {}
+func (*StreamChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{76}
+}
+
+func (m *StreamChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamChangesRequest.Unmarshal(m, b)
+}
+func (m *StreamChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamChangesRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamChangesRequest.Merge(m, src)
+}
+func (m *StreamChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamChangesRequest.Size(m)
+}
+func (m *StreamChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamChangesRequest proto.InternalMessageInfo
+
+func (m *StreamChangesRequest) GetFromSeq() uint64 {
+	if m != nil {
+		return m.FromSeq
+	}
+	return 0
+}
+
+type StreamChangesResponse struct {
+	Entry                *ChangeEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	LeaderSeq            uint64       `protobuf:"varint,2,opt,name=leader_seq,json=leaderSeq,proto3" json:"leader_seq,omitempty"`
+	LeaderTime           int64        `protobuf:"varint,3,opt,name=leader_time,json=leaderTime,proto3" json:"leader_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StreamChangesResponse) Reset()         { *m = StreamChangesResponse{} }
+func (m *StreamChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamChangesResponse) ProtoMessage()    {}
+func (*StreamChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{77}
+}
+
+func (m *StreamChangesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamChangesResponse.Unmarshal(m, b)
+}
+func (m *StreamChangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamChangesResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamChangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamChangesResponse.Merge(m, src)
+}
+func (m *StreamChangesResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamChangesResponse.Size(m)
+}
+func (m *StreamChangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamChangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamChangesResponse proto.InternalMessageInfo
+
+func (m *StreamChangesResponse) GetEntry() *ChangeEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (m *StreamChangesResponse) GetLeaderSeq() uint64 {
+	if m != nil {
+		return m.LeaderSeq
+	}
+	return 0
+}
+
+func (m *StreamChangesResponse) GetLeaderTime() int64 {
+	if m != nil {
+		return m.LeaderTime
+	}
+	return 0
+}
+
+type ReplicationStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplicationStatusRequest) Reset()         { *m = ReplicationStatusRequest{} }
+func (m *ReplicationStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplicationStatusRequest) ProtoMessage()    {}
+func (*ReplicationStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{78}
+}
+
+func (m *ReplicationStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicationStatusRequest.Unmarshal(m, b)
+}
+func (m *ReplicationStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicationStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *ReplicationStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicationStatusRequest.Merge(m, src)
+}
+func (m *ReplicationStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplicationStatusRequest.Size(m)
+}
+func (m *ReplicationStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicationStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicationStatusRequest proto.InternalMessageInfo
+
+type ReplicationStatusResponse struct {
+	Role                 ReplicationStatusResponse_Role `protobuf:"varint,1,opt,name=role,proto3,enum=blog.ReplicationStatusResponse_Role" json:"role,omitempty"`
+	LeaderAddr           string                         `protobuf:"bytes,2,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
+	Connected            bool                           `protobuf:"varint,3,opt,name=connected,proto3" json:"connected,omitempty"`
+	AppliedSeq           uint64                         `protobuf:"varint,4,opt,name=applied_seq,json=appliedSeq,proto3" json:"applied_seq,omitempty"`
+	LeaderSeq            uint64                         `protobuf:"varint,5,opt,name=leader_seq,json=leaderSeq,proto3" json:"leader_seq,omitempty"`
+	LagEntries           uint64                         `protobuf:"varint,6,opt,name=lag_entries,json=lagEntries,proto3" json:"lag_entries,omitempty"`
+	ApplyDelaySeconds    float64                        `protobuf:"fixed64,7,opt,name=apply_delay_seconds,json=applyDelaySeconds,proto3" json:"apply_delay_seconds,omitempty"`
+	SecondsSinceContact  float64                        `protobuf:"fixed64,8,opt,name=seconds_since_contact,json=secondsSinceContact,proto3" json:"seconds_since_contact,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ReplicationStatusResponse) Reset()         { *m = ReplicationStatusResponse{} }
+func (m *ReplicationStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplicationStatusResponse) ProtoMessage()    {}
+func (*ReplicationStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{79}
+}
+
+func (m *ReplicationStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicationStatusResponse.Unmarshal(m, b)
+}
+func (m *ReplicationStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicationStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *ReplicationStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicationStatusResponse.Merge(m, src)
+}
+func (m *ReplicationStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplicationStatusResponse.Size(m)
+}
+func (m *ReplicationStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicationStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicationStatusResponse proto.InternalMessageInfo
+
+func (m *ReplicationStatusResponse) GetRole() ReplicationStatusResponse_Role {
+	if m != nil {
+		return m.Role
+	}
+	return ReplicationStatusResponse_LEADER
+}
+
+func (m *ReplicationStatusResponse) GetLeaderAddr() string {
+	if m != nil {
+		return m.LeaderAddr
+	}
+	return ""
+}
+
+func (m *ReplicationStatusResponse) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *ReplicationStatusResponse) GetAppliedSeq() uint64 {
+	if m != nil {
+		return m.AppliedSeq
+	}
+	return 0
+}
+
+func (m *ReplicationStatusResponse) GetLeaderSeq() uint64 {
+	if m != nil {
+		return m.LeaderSeq
+	}
+	return 0
+}
+
+func (m *ReplicationStatusResponse) GetLagEntries() uint64 {
+	if m != nil {
+		return m.LagEntries
+	}
+	return 0
+}
+
+func (m *ReplicationStatusResponse) GetApplyDelaySeconds() float64 {
+	if m != nil {
+		return m.ApplyDelaySeconds
+	}
+	return 0
+}
+
+func (m *ReplicationStatusResponse) GetSecondsSinceContact() float64 {
+	if m != nil {
+		return m.SecondsSinceContact
+	}
+	return 0
+}
+
+// A write to the blog store. In cluster mode commands are committed through
+// the Raft log and applied by every node.
+type BlogCommand struct {
+	Op     BlogCommand_Op `protobuf:"varint,1,opt,name=op,proto3,enum=blog.BlogCommand_Op" json:"op,omitempty"`
+	Blog   *Blog          `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	BlogId uint64         `protobuf:"varint,3,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Acl    *BlogAcl       `protobuf:"bytes,4,opt,name=acl,proto3" json:"acl,omitempty"`
+	// identity of the caller, checked against the ACL of the blog when set.
+	// For LIKE and UNLIKE it is the user whose like it is.
+	Caller string `protobuf:"bytes,5,opt,name=caller,proto3" json:"caller,omitempty"`
+	Views  uint64 `protobuf:"varint,6,opt,name=views,proto3" json:"views,omitempty"`
+	// unix nanoseconds the write was made at, recorded in the changelog and by
+	// LIKE and VIEW. Set by the leader in a cluster, the local time is used
+	// when unset.
+	Timestamp int64 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// for UPSERT_TRANSLATION, only the locale is used by DELETE_TRANSLATION
+	Translation *BlogTranslation `protobuf:"bytes,8,opt,name=translation,proto3" json:"translation,omitempty"`
+	Series      *Series          `protobuf:"bytes,9,opt,name=series,proto3" json:"series,omitempty"`
+	SeriesId    uint64           `protobuf:"varint,10,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	Position    int32            `protobuf:"varint,11,opt,name=position,proto3" json:"position,omitempty"`
+	// for APPEND_CONTENT, where data goes in the uploaded content. For
+	// COMMIT_CONTENT, the size the upload must have reached.
+	Offset uint64 `protobuf:"varint,12,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data   []byte `protobuf:"bytes,13,opt,name=data,proto3" json:"data,omitempty"`
+	// limits of the server that received the write, checked by the writes
+	// that make the blogs bigger
+	Quota *StorageQuota `protobuf:"bytes,14,opt,name=quota,proto3" json:"quota,omitempty"`
+	// in sharded mode, what the other shards hold, for the author of the blog
+	// and in total
+	AuthorUsageElsewhere *StorageUsage `protobuf:"bytes,15,opt,name=author_usage_elsewhere,json=authorUsageElsewhere,proto3" json:"author_usage_elsewhere,omitempty"`
+	TotalUsageElsewhere  *StorageUsage `protobuf:"bytes,16,opt,name=total_usage_elsewhere,json=totalUsageElsewhere,proto3" json:"total_usage_elsewhere,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BlogCommand) Reset()         { *m = BlogCommand{} }
+func (m *BlogCommand) String() string { return proto.CompactTextString(m) }
+func (*BlogCommand) ProtoMessage()    {}
+func (*BlogCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{80}
+}
+
+func (m *BlogCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlogCommand.Unmarshal(m, b)
+}
+func (m *BlogCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlogCommand.Marshal(b, m, deterministic)
+}
+func (m *BlogCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlogCommand.Merge(m, src)
+}
+func (m *BlogCommand) XXX_Size() int {
+	return xxx_messageInfo_BlogCommand.Size(m)
+}
+func (m *BlogCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlogCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlogCommand proto.InternalMessageInfo
+
+func (m *BlogCommand) GetOp() BlogCommand_Op {
+	if m != nil {
+		return m.Op
+	}
+	return BlogCommand_CREATE
+}
+
+func (m *BlogCommand) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *BlogCommand) GetBlogId() uint64 {
+	if m != nil {
+		return m.BlogId
+	}
+	return 0
+}
+
+func (m *BlogCommand) GetAcl() *BlogAcl {
+	if m != nil {
+		return m.Acl
+	}
+	return nil
+}
+
+func (m *BlogCommand) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+func (m *BlogCommand) GetViews() uint64 {
+	if m != nil {
+		return m.Views
+	}
+	return 0
+}
+
+func (m *BlogCommand) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *BlogCommand) GetTranslation() *BlogTranslation {
+	if m != nil {
+		return m.Translation
+	}
+	return nil
+}
+
+func (m *BlogCommand) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+func (m *BlogCommand) GetSeriesId() uint64 {
+	if m != nil {
+		return m.SeriesId
+	}
+	return 0
+}
+
+func (m *BlogCommand) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *BlogCommand) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *BlogCommand) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *BlogCommand) GetQuota() *StorageQuota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+func (m *BlogCommand) GetAuthorUsageElsewhere() *StorageUsage {
+	if m != nil {
+		return m.AuthorUsageElsewhere
+	}
+	return nil
+}
+
+func (m *BlogCommand) GetTotalUsageElsewhere() *StorageUsage {
+	if m != nil {
+		return m.TotalUsageElsewhere
+	}
+	return nil
+}
+
+type AddVoterRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddVoterRequest) Reset()         { *m = AddVoterRequest{} }
+func (m *AddVoterRequest) String() string { return proto.CompactTextString(m) }
+func (*AddVoterRequest) ProtoMessage()    {}
+func (*AddVoterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{81}
+}
+
+func (m *AddVoterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddVoterRequest.Unmarshal(m, b)
+}
+func (m *AddVoterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddVoterRequest.Marshal(b, m, deterministic)
+}
+func (m *AddVoterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddVoterRequest.Merge(m, src)
+}
+func (m *AddVoterRequest) XXX_Size() int {
+	return xxx_messageInfo_AddVoterRequest.Size(m)
+}
+func (m *AddVoterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddVoterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddVoterRequest proto.InternalMessageInfo
+
+func (m *AddVoterRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AddVoterRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type AddVoterResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddVoterResponse) Reset()         { *m = AddVoterResponse{} }
+func (m *AddVoterResponse) String() string { return proto.CompactTextString(m) }
+func (*AddVoterResponse) ProtoMessage()    {}
+func (*AddVoterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{82}
+}
+
+func (m *AddVoterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddVoterResponse.Unmarshal(m, b)
+}
+func (m *AddVoterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddVoterResponse.Marshal(b, m, deterministic)
+}
+func (m *AddVoterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddVoterResponse.Merge(m, src)
+}
+func (m *AddVoterResponse) XXX_Size() int {
+	return xxx_messageInfo_AddVoterResponse.Size(m)
+}
+func (m *AddVoterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddVoterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddVoterResponse proto.InternalMessageInfo
+
+type RemoveServerRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveServerRequest) Reset()         { *m = RemoveServerRequest{} }
+func (m *RemoveServerRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveServerRequest) ProtoMessage()    {}
+func (*RemoveServerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{83}
+}
+
+func (m *RemoveServerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveServerRequest.Unmarshal(m, b)
+}
+func (m *RemoveServerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveServerRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveServerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveServerRequest.Merge(m, src)
+}
+func (m *RemoveServerRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveServerRequest.Size(m)
+}
+func (m *RemoveServerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveServerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveServerRequest proto.InternalMessageInfo
+
+func (m *RemoveServerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RemoveServerResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveServerResponse) Reset()         { *m = RemoveServerResponse{} }
+func (m *RemoveServerResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveServerResponse) ProtoMessage()    {}
+func (*RemoveServerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{84}
+}
+
+func (m *RemoveServerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveServerResponse.Unmarshal(m, b)
+}
+func (m *RemoveServerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveServerResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveServerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveServerResponse.Merge(m, src)
+}
+func (m *RemoveServerResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveServerResponse.Size(m)
+}
+func (m *RemoveServerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveServerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveServerResponse proto.InternalMessageInfo
+
+type ClusterStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClusterStatusRequest) Reset()         { *m = ClusterStatusRequest{} }
+func (m *ClusterStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ClusterStatusRequest) ProtoMessage()    {}
+func (*ClusterStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{85}
+}
+
+func (m *ClusterStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClusterStatusRequest.Unmarshal(m, b)
+}
+func (m *ClusterStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClusterStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *ClusterStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterStatusRequest.Merge(m, src)
+}
+func (m *ClusterStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ClusterStatusRequest.Size(m)
+}
+func (m *ClusterStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterStatusRequest proto.InternalMessageInfo
+
+type ClusterServer struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Voter                bool     `protobuf:"varint,3,opt,name=voter,proto3" json:"voter,omitempty"`
+	Leader               bool     `protobuf:"varint,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClusterServer) Reset()         { *m = ClusterServer{} }
+func (m *ClusterServer) String() string { return proto.CompactTextString(m) }
+func (*ClusterServer) ProtoMessage()    {}
+func (*ClusterServer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{86}
+}
+
+func (m *ClusterServer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClusterServer.Unmarshal(m, b)
+}
+func (m *ClusterServer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClusterServer.Marshal(b, m, deterministic)
+}
+func (m *ClusterServer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterServer.Merge(m, src)
+}
+func (m *ClusterServer) XXX_Size() int {
+	return xxx_messageInfo_ClusterServer.Size(m)
+}
+func (m *ClusterServer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterServer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterServer proto.InternalMessageInfo
+
+func (m *ClusterServer) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ClusterServer) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ClusterServer) GetVoter() bool {
+	if m != nil {
+		return m.Voter
+	}
+	return false
+}
+
+func (m *ClusterServer) GetLeader() bool {
+	if m != nil {
+		return m.Leader
+	}
+	return false
+}
+
+type ClusterStatusResponse struct {
+	Id                   string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State                string           `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	LeaderAddress        string           `protobuf:"bytes,3,opt,name=leader_address,json=leaderAddress,proto3" json:"leader_address,omitempty"`
+	Term                 uint64           `protobuf:"varint,4,opt,name=term,proto3" json:"term,omitempty"`
+	LastIndex            uint64           `protobuf:"varint,5,opt,name=last_index,json=lastIndex,proto3" json:"last_index,omitempty"`
+	AppliedIndex         uint64           `protobuf:"varint,6,opt,name=applied_index,json=appliedIndex,proto3" json:"applied_index,omitempty"`
+	Servers              []*ClusterServer `protobuf:"bytes,7,rep,name=servers,proto3" json:"servers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ClusterStatusResponse) Reset()         { *m = ClusterStatusResponse{} }
+func (m *ClusterStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ClusterStatusResponse) ProtoMessage()    {}
+func (*ClusterStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{87}
+}
+
+func (m *ClusterStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClusterStatusResponse.Unmarshal(m, b)
+}
+func (m *ClusterStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClusterStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *ClusterStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterStatusResponse.Merge(m, src)
+}
+func (m *ClusterStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ClusterStatusResponse.Size(m)
+}
+func (m *ClusterStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterStatusResponse proto.InternalMessageInfo
+
+func (m *ClusterStatusResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ClusterStatusResponse) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ClusterStatusResponse) GetLeaderAddress() string {
+	if m != nil {
+		return m.LeaderAddress
+	}
+	return ""
+}
+
+func (m *ClusterStatusResponse) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *ClusterStatusResponse) GetLastIndex() uint64 {
+	if m != nil {
+		return m.LastIndex
+	}
+	return 0
+}
+
+func (m *ClusterStatusResponse) GetAppliedIndex() uint64 {
+	if m != nil {
+		return m.AppliedIndex
+	}
+	return 0
+}
+
+func (m *ClusterStatusResponse) GetServers() []*ClusterServer {
+	if m != nil {
+		return m.Servers
+	}
+	return nil
+}
+
+type RaftLog struct {
+	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Type                 uint32   `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Extensions           []byte   `protobuf:"bytes,5,opt,name=extensions,proto3" json:"extensions,omitempty"`
+	AppendedAt           int64    `protobuf:"varint,6,opt,name=appended_at,json=appendedAt,proto3" json:"appended_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftLog) Reset()         { *m = RaftLog{} }
+func (m *RaftLog) String() string { return proto.CompactTextString(m) }
+func (*RaftLog) ProtoMessage()    {}
+func (*RaftLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{88}
+}
+
+func (m *RaftLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftLog.Unmarshal(m, b)
+}
+func (m *RaftLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftLog.Marshal(b, m, deterministic)
+}
+func (m *RaftLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftLog.Merge(m, src)
+}
+func (m *RaftLog) XXX_Size() int {
+	return xxx_messageInfo_RaftLog.Size(m)
+}
+func (m *RaftLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftLog proto.InternalMessageInfo
+
+func (m *RaftLog) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *RaftLog) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RaftLog) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *RaftLog) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *RaftLog) GetExtensions() []byte {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+func (m *RaftLog) GetAppendedAt() int64 {
+	if m != nil {
+		return m.AppendedAt
+	}
+	return 0
+}
+
+type RaftAppendEntriesRequest struct {
+	ProtocolVersion      int64      `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Term                 uint64     `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Leader               []byte     `protobuf:"bytes,3,opt,name=leader,proto3" json:"leader,omitempty"`
+	PrevLogEntry         uint64     `protobuf:"varint,4,opt,name=prev_log_entry,json=prevLogEntry,proto3" json:"prev_log_entry,omitempty"`
+	PrevLogTerm          uint64     `protobuf:"varint,5,opt,name=prev_log_term,json=prevLogTerm,proto3" json:"prev_log_term,omitempty"`
+	Entries              []*RaftLog `protobuf:"bytes,6,rep,name=entries,proto3" json:"entries,omitempty"`
+	LeaderCommitIndex    uint64     `protobuf:"varint,7,opt,name=leader_commit_index,json=leaderCommitIndex,proto3" json:"leader_commit_index,omitempty"`
+	Id                   []byte     `protobuf:"bytes,8,opt,name=id,proto3" json:"id,omitempty"`
+	Addr                 []byte     `protobuf:"bytes,9,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RaftAppendEntriesRequest) Reset()         { *m = RaftAppendEntriesRequest{} }
+func (m *RaftAppendEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*RaftAppendEntriesRequest) ProtoMessage()    {}
+func (*RaftAppendEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{89}
+}
+
+func (m *RaftAppendEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftAppendEntriesRequest.Unmarshal(m, b)
+}
+func (m *RaftAppendEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftAppendEntriesRequest.Marshal(b, m, deterministic)
+}
+func (m *RaftAppendEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftAppendEntriesRequest.Merge(m, src)
+}
+func (m *RaftAppendEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_RaftAppendEntriesRequest.Size(m)
+}
+func (m *RaftAppendEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftAppendEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftAppendEntriesRequest proto.InternalMessageInfo
+
+func (m *RaftAppendEntriesRequest) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RaftAppendEntriesRequest) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RaftAppendEntriesRequest) GetLeader() []byte {
+	if m != nil {
+		return m.Leader
+	}
+	return nil
+}
+
+func (m *RaftAppendEntriesRequest) GetPrevLogEntry() uint64 {
+	if m != nil {
+		return m.PrevLogEntry
+	}
+	return 0
+}
+
+func (m *RaftAppendEntriesRequest) GetPrevLogTerm() uint64 {
+	if m != nil {
+		return m.PrevLogTerm
+	}
+	return 0
+}
+
+func (m *RaftAppendEntriesRequest) GetEntries() []*RaftLog {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *RaftAppendEntriesRequest) GetLeaderCommitIndex() uint64 {
+	if m != nil {
+		return m.LeaderCommitIndex
+	}
+	return 0
+}
+
+func (m *RaftAppendEntriesRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RaftAppendEntriesRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type RaftAppendEntriesResponse struct {
+	ProtocolVersion      int64    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	LastLog              uint64   `protobuf:"varint,3,opt,name=last_log,json=lastLog,proto3" json:"last_log,omitempty"`
+	Success              bool     `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	NoRetryBackoff       bool     `protobuf:"varint,5,opt,name=no_retry_backoff,json=noRetryBackoff,proto3" json:"no_retry_backoff,omitempty"`
+	Id                   []byte   `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,7,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftAppendEntriesResponse) Reset()         { *m = RaftAppendEntriesResponse{} }
+func (m *RaftAppendEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*RaftAppendEntriesResponse) ProtoMessage()    {}
+func (*RaftAppendEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{90}
+}
+
+func (m *RaftAppendEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftAppendEntriesResponse.Unmarshal(m, b)
+}
+func (m *RaftAppendEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftAppendEntriesResponse.Marshal(b, m, deterministic)
+}
+func (m *RaftAppendEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftAppendEntriesResponse.Merge(m, src)
+}
+func (m *RaftAppendEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_RaftAppendEntriesResponse.Size(m)
+}
+func (m *RaftAppendEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftAppendEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftAppendEntriesResponse proto.InternalMessageInfo
+
+func (m *RaftAppendEntriesResponse) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RaftAppendEntriesResponse) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RaftAppendEntriesResponse) GetLastLog() uint64 {
+	if m != nil {
+		return m.LastLog
+	}
+	return 0
+}
+
+func (m *RaftAppendEntriesResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *RaftAppendEntriesResponse) GetNoRetryBackoff() bool {
+	if m != nil {
+		return m.NoRetryBackoff
+	}
+	return false
+}
+
+func (m *RaftAppendEntriesResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RaftAppendEntriesResponse) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type RaftRequestVoteRequest struct {
+	ProtocolVersion      int64    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Candidate            []byte   `protobuf:"bytes,3,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	LastLogIndex         uint64   `protobuf:"varint,4,opt,name=last_log_index,json=lastLogIndex,proto3" json:"last_log_index,omitempty"`
+	LastLogTerm          uint64   `protobuf:"varint,5,opt,name=last_log_term,json=lastLogTerm,proto3" json:"last_log_term,omitempty"`
+	LeadershipTransfer   bool     `protobuf:"varint,6,opt,name=leadership_transfer,json=leadershipTransfer,proto3" json:"leadership_transfer,omitempty"`
+	Id                   []byte   `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,8,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftRequestVoteRequest) Reset()         { *m = RaftRequestVoteRequest{} }
+func (m *RaftRequestVoteRequest) String() string { return proto.CompactTextString(m) }
+func (*RaftRequestVoteRequest) ProtoMessage()    {}
+func (*RaftRequestVoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{91}
+}
+
+func (m *RaftRequestVoteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftRequestVoteRequest.Unmarshal(m, b)
+}
+func (m *RaftRequestVoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftRequestVoteRequest.Marshal(b, m, deterministic)
+}
+func (m *RaftRequestVoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftRequestVoteRequest.Merge(m, src)
+}
+func (m *RaftRequestVoteRequest) XXX_Size() int {
+	return xxx_messageInfo_RaftRequestVoteRequest.Size(m)
+}
+func (m *RaftRequestVoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftRequestVoteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftRequestVoteRequest proto.InternalMessageInfo
+
+func (m *RaftRequestVoteRequest) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RaftRequestVoteRequest) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RaftRequestVoteRequest) GetCandidate() []byte {
+	if m != nil {
+		return m.Candidate
+	}
+	return nil
+}
+
+func (m *RaftRequestVoteRequest) GetLastLogIndex() uint64 {
+	if m != nil {
+		return m.LastLogIndex
+	}
+	return 0
+}
+
+func (m *RaftRequestVoteRequest) GetLastLogTerm() uint64 {
+	if m != nil {
+		return m.LastLogTerm
+	}
+	return 0
+}
+
+func (m *RaftRequestVoteRequest) GetLeadershipTransfer() bool {
+	if m != nil {
+		return m.LeadershipTransfer
+	}
+	return false
+}
+
+func (m *RaftRequestVoteRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RaftRequestVoteRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type RaftRequestVoteResponse struct {
+	ProtocolVersion      int64    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Peers                []byte   `protobuf:"bytes,3,opt,name=peers,proto3" json:"peers,omitempty"`
+	Granted              bool     `protobuf:"varint,4,opt,name=granted,proto3" json:"granted,omitempty"`
+	Id                   []byte   `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,6,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftRequestVoteResponse) Reset()         { *m = RaftRequestVoteResponse{} }
+func (m *RaftRequestVoteResponse) String() string { return proto.CompactTextString(m) }
+func (*RaftRequestVoteResponse) ProtoMessage()    {}
+func (*RaftRequestVoteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{92}
+}
+
+func (m *RaftRequestVoteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftRequestVoteResponse.Unmarshal(m, b)
+}
+func (m *RaftRequestVoteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftRequestVoteResponse.Marshal(b, m, deterministic)
+}
+func (m *RaftRequestVoteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftRequestVoteResponse.Merge(m, src)
+}
+func (m *RaftRequestVoteResponse) XXX_Size() int {
+	return xxx_messageInfo_RaftRequestVoteResponse.Size(m)
+}
+func (m *RaftRequestVoteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftRequestVoteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftRequestVoteResponse proto.InternalMessageInfo
+
+func (m *RaftRequestVoteResponse) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RaftRequestVoteResponse) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RaftRequestVoteResponse) GetPeers() []byte {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *RaftRequestVoteResponse) GetGranted() bool {
+	if m != nil {
+		return m.Granted
+	}
+	return false
+}
+
+func (m *RaftRequestVoteResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RaftRequestVoteResponse) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+// The first message of an InstallSnapshot stream carries the request, the
+// following ones only carry data.
+type RaftInstallSnapshotRequest struct {
+	ProtocolVersion      int64    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	SnapshotVersion      int64    `protobuf:"varint,2,opt,name=snapshot_version,json=snapshotVersion,proto3" json:"snapshot_version,omitempty"`
+	Term                 uint64   `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	Leader               []byte   `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	LastLogIndex         uint64   `protobuf:"varint,5,opt,name=last_log_index,json=lastLogIndex,proto3" json:"last_log_index,omitempty"`
+	LastLogTerm          uint64   `protobuf:"varint,6,opt,name=last_log_term,json=lastLogTerm,proto3" json:"last_log_term,omitempty"`
+	Peers                []byte   `protobuf:"bytes,7,opt,name=peers,proto3" json:"peers,omitempty"`
+	Configuration        []byte   `protobuf:"bytes,8,opt,name=configuration,proto3" json:"configuration,omitempty"`
+	ConfigurationIndex   uint64   `protobuf:"varint,9,opt,name=configuration_index,json=configurationIndex,proto3" json:"configuration_index,omitempty"`
+	Size                 int64    `protobuf:"varint,10,opt,name=size,proto3" json:"size,omitempty"`
+	Data                 []byte   `protobuf:"bytes,11,opt,name=data,proto3" json:"data,omitempty"`
+	Id                   []byte   `protobuf:"bytes,12,opt,name=id,proto3" json:"id,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,13,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftInstallSnapshotRequest) Reset()         { *m = RaftInstallSnapshotRequest{} }
+func (m *RaftInstallSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*RaftInstallSnapshotRequest) ProtoMessage()    {}
+func (*RaftInstallSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{93}
+}
+
+func (m *RaftInstallSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftInstallSnapshotRequest.Unmarshal(m, b)
+}
+func (m *RaftInstallSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftInstallSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *RaftInstallSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftInstallSnapshotRequest.Merge(m, src)
+}
+func (m *RaftInstallSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_RaftInstallSnapshotRequest.Size(m)
+}
+func (m *RaftInstallSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftInstallSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftInstallSnapshotRequest proto.InternalMessageInfo
+
+func (m *RaftInstallSnapshotRequest) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotRequest) GetSnapshotVersion() int64 {
+	if m != nil {
+		return m.SnapshotVersion
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotRequest) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotRequest) GetLeader() []byte {
+	if m != nil {
+		return m.Leader
+	}
+	return nil
+}
+
+func (m *RaftInstallSnapshotRequest) GetLastLogIndex() uint64 {
+	if m != nil {
+		return m.LastLogIndex
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotRequest) GetLastLogTerm() uint64 {
+	if m != nil {
+		return m.LastLogTerm
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotRequest) GetPeers() []byte {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *RaftInstallSnapshotRequest) GetConfiguration() []byte {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+func (m *RaftInstallSnapshotRequest) GetConfigurationIndex() uint64 {
+	if m != nil {
+		return m.ConfigurationIndex
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *RaftInstallSnapshotRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RaftInstallSnapshotRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type RaftInstallSnapshotResponse struct {
+	ProtocolVersion      int64    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Success              bool     `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Id                   []byte   `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,5,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftInstallSnapshotResponse) Reset()         { *m = RaftInstallSnapshotResponse{} }
+func (m *RaftInstallSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*RaftInstallSnapshotResponse) ProtoMessage()    {}
+func (*RaftInstallSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{94}
+}
+
+func (m *RaftInstallSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftInstallSnapshotResponse.Unmarshal(m, b)
+}
+func (m *RaftInstallSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftInstallSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *RaftInstallSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftInstallSnapshotResponse.Merge(m, src)
+}
+func (m *RaftInstallSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_RaftInstallSnapshotResponse.Size(m)
+}
+func (m *RaftInstallSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftInstallSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftInstallSnapshotResponse proto.InternalMessageInfo
+
+func (m *RaftInstallSnapshotResponse) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotResponse) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RaftInstallSnapshotResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *RaftInstallSnapshotResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RaftInstallSnapshotResponse) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type RaftTimeoutNowRequest struct {
+	ProtocolVersion      int64    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Id                   []byte   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftTimeoutNowRequest) Reset()         { *m = RaftTimeoutNowRequest{} }
+func (m *RaftTimeoutNowRequest) String() string { return proto.CompactTextString(m) }
+func (*RaftTimeoutNowRequest) ProtoMessage()    {}
+func (*RaftTimeoutNowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{95}
+}
+
+func (m *RaftTimeoutNowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftTimeoutNowRequest.Unmarshal(m, b)
+}
+func (m *RaftTimeoutNowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftTimeoutNowRequest.Marshal(b, m, deterministic)
+}
+func (m *RaftTimeoutNowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftTimeoutNowRequest.Merge(m, src)
+}
+func (m *RaftTimeoutNowRequest) XXX_Size() int {
+	return xxx_messageInfo_RaftTimeoutNowRequest.Size(m)
+}
+func (m *RaftTimeoutNowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftTimeoutNowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftTimeoutNowRequest proto.InternalMessageInfo
+
+func (m *RaftTimeoutNowRequest) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RaftTimeoutNowRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RaftTimeoutNowRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type RaftTimeoutNowResponse struct {
+	ProtocolVersion      int64    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Id                   []byte   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftTimeoutNowResponse) Reset()         { *m = RaftTimeoutNowResponse{} }
+func (m *RaftTimeoutNowResponse) String() string { return proto.CompactTextString(m) }
+func (*RaftTimeoutNowResponse) ProtoMessage()    {}
+func (*RaftTimeoutNowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{96}
+}
+
+func (m *RaftTimeoutNowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftTimeoutNowResponse.Unmarshal(m, b)
+}
+func (m *RaftTimeoutNowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftTimeoutNowResponse.Marshal(b, m, deterministic)
+}
+func (m *RaftTimeoutNowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftTimeoutNowResponse.Merge(m, src)
+}
+func (m *RaftTimeoutNowResponse) XXX_Size() int {
+	return xxx_messageInfo_RaftTimeoutNowResponse.Size(m)
+}
+func (m *RaftTimeoutNowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftTimeoutNowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftTimeoutNowResponse proto.InternalMessageInfo
+
+func (m *RaftTimeoutNowResponse) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RaftTimeoutNowResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RaftTimeoutNowResponse) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("blog.DiffLine_Op", DiffLine_Op_name, DiffLine_Op_value)
+	proto.RegisterEnum("blog.TopBlogsRequest_Metric", TopBlogsRequest_Metric_name, TopBlogsRequest_Metric_value)
+	proto.RegisterEnum("blog.ChangeEntry_Op", ChangeEntry_Op_name, ChangeEntry_Op_value)
+	proto.RegisterEnum("blog.ReplicationStatusResponse_Role", ReplicationStatusResponse_Role_name, ReplicationStatusResponse_Role_value)
+	proto.RegisterEnum("blog.BlogCommand_Op", BlogCommand_Op_name, BlogCommand_Op_value)
+	proto.RegisterType((*Blog)(nil), "blog.Blog")
+	proto.RegisterType((*BlogChunk)(nil), "blog.BlogChunk")
+	proto.RegisterType((*ContentUpload)(nil), "blog.ContentUpload")
+	proto.RegisterType((*BlogTranslation)(nil), "blog.BlogTranslation")
+	proto.RegisterType((*BlogAcl)(nil), "blog.BlogAcl")
+	proto.RegisterType((*CreateBlogRequest)(nil), "blog.CreateBlogRequest")
+	proto.RegisterType((*CreateBlogResponse)(nil), "blog.CreateBlogResponse")
+	proto.RegisterType((*ReadBlogRequest)(nil), "blog.ReadBlogRequest")
+	proto.RegisterType((*ReadBlogResponse)(nil), "blog.ReadBlogResponse")
+	proto.RegisterType((*Series)(nil), "blog.Series")
+	proto.RegisterType((*SeriesNavigation)(nil), "blog.SeriesNavigation")
+	proto.RegisterType((*UpdateBlogRequest)(nil), "blog.UpdateBlogRequest")
+	proto.RegisterType((*UpdateBlogResponse)(nil), "blog.UpdateBlogResponse")
+	proto.RegisterType((*DeleteBlogRequest)(nil), "blog.DeleteBlogRequest")
+	proto.RegisterType((*DeleteBlogResponse)(nil), "blog.DeleteBlogResponse")
+	proto.RegisterType((*ListBlogRequest)(nil), "blog.ListBlogRequest")
+	proto.RegisterType((*ListBlogResponse)(nil), "blog.ListBlogResponse")
+	proto.RegisterType((*GetBlogAclRequest)(nil), "blog.GetBlogAclRequest")
+	proto.RegisterType((*GetBlogAclResponse)(nil), "blog.GetBlogAclResponse")
+	proto.RegisterType((*SetBlogAclRequest)(nil), "blog.SetBlogAclRequest")
+	proto.RegisterType((*SetBlogAclResponse)(nil), "blog.SetBlogAclResponse")
+	proto.RegisterType((*ImportBlogRequest)(nil), "blog.ImportBlogRequest")
+	proto.RegisterType((*ImportBlogResponse)(nil), "blog.ImportBlogResponse")
+	proto.RegisterType((*DiffBlogRequest)(nil), "blog.DiffBlogRequest")
+	proto.RegisterType((*DiffLine)(nil), "blog.DiffLine")
+	proto.RegisterType((*DiffBlogResponse)(nil), "blog.DiffBlogResponse")
+	proto.RegisterType((*StorageUsage)(nil), "blog.StorageUsage")
+	proto.RegisterType((*StorageQuota)(nil), "blog.StorageQuota")
+	proto.RegisterType((*GetUsageRequest)(nil), "blog.GetUsageRequest")
+	proto.RegisterType((*GetUsageResponse)(nil), "blog.GetUsageResponse")
+	proto.RegisterType((*StreamBlogContentRequest)(nil), "blog.StreamBlogContentRequest")
+	proto.RegisterType((*StreamBlogContentResponse)(nil), "blog.StreamBlogContentResponse")
+	proto.RegisterType((*WriteBlogContentRequest)(nil), "blog.WriteBlogContentRequest")
+	proto.RegisterType((*WriteBlogContentResponse)(nil), "blog.WriteBlogContentResponse")
+	proto.RegisterType((*CreateSeriesRequest)(nil), "blog.CreateSeriesRequest")
+	proto.RegisterType((*CreateSeriesResponse)(nil), "blog.CreateSeriesResponse")
+	proto.RegisterType((*GetSeriesRequest)(nil), "blog.GetSeriesRequest")
+	proto.RegisterType((*GetSeriesResponse)(nil), "blog.GetSeriesResponse")
+	proto.RegisterType((*DeleteSeriesRequest)(nil), "blog.DeleteSeriesRequest")
+	proto.RegisterType((*DeleteSeriesResponse)(nil), "blog.DeleteSeriesResponse")
+	proto.RegisterType((*AddToSeriesRequest)(nil), "blog.AddToSeriesRequest")
+	proto.RegisterType((*AddToSeriesResponse)(nil), "blog.AddToSeriesResponse")
+	proto.RegisterType((*MoveInSeriesRequest)(nil), "blog.MoveInSeriesRequest")
+	proto.RegisterType((*MoveInSeriesResponse)(nil), "blog.MoveInSeriesResponse")
+	proto.RegisterType((*RemoveFromSeriesRequest)(nil), "blog.RemoveFromSeriesRequest")
+	proto.RegisterType((*RemoveFromSeriesResponse)(nil), "blog.RemoveFromSeriesResponse")
+	proto.RegisterType((*UpsertBlogTranslationRequest)(nil), "blog.UpsertBlogTranslationRequest")
+	proto.RegisterType((*UpsertBlogTranslationResponse)(nil), "blog.UpsertBlogTranslationResponse")
+	proto.RegisterType((*DeleteBlogTranslationRequest)(nil), "blog.DeleteBlogTranslationRequest")
+	proto.RegisterType((*DeleteBlogTranslationResponse)(nil), "blog.DeleteBlogTranslationResponse")
+	proto.RegisterType((*RelatedBlogsRequest)(nil), "blog.RelatedBlogsRequest")
+	proto.RegisterType((*RelatedBlog)(nil), "blog.RelatedBlog")
+	proto.RegisterType((*RelatedBlogsResponse)(nil), "blog.RelatedBlogsResponse")
+	proto.RegisterType((*LikeBlogRequest)(nil), "blog.LikeBlogRequest")
+	proto.RegisterType((*LikeBlogResponse)(nil), "blog.LikeBlogResponse")
+	proto.RegisterType((*UnlikeBlogRequest)(nil), "blog.UnlikeBlogRequest")
+	proto.RegisterType((*UnlikeBlogResponse)(nil), "blog.UnlikeBlogResponse")
+	proto.RegisterType((*TopBlogsRequest)(nil), "blog.TopBlogsRequest")
+	proto.RegisterType((*TopBlog)(nil), "blog.TopBlog")
+	proto.RegisterType((*TopBlogsResponse)(nil), "blog.TopBlogsResponse")
+	proto.RegisterType((*CompactDatabaseRequest)(nil), "blog.CompactDatabaseRequest")
+	proto.RegisterType((*CompactDatabaseResponse)(nil), "blog.CompactDatabaseResponse")
+	proto.RegisterType((*DatabaseStatsRequest)(nil), "blog.DatabaseStatsRequest")
+	proto.RegisterType((*BucketStats)(nil), "blog.BucketStats")
+	proto.RegisterType((*DatabaseStatsResponse)(nil), "blog.DatabaseStatsResponse")
+	proto.RegisterType((*CompressionStats)(nil), "blog.CompressionStats")
+	proto.RegisterType((*QuarantinedBlog)(nil), "blog.QuarantinedBlog")
+	proto.RegisterType((*ListQuarantineRequest)(nil), "blog.ListQuarantineRequest")
+	proto.RegisterType((*ListQuarantineResponse)(nil), "blog.ListQuarantineResponse")
+	proto.RegisterType((*ResolveQuarantineRequest)(nil), "blog.ResolveQuarantineRequest")
+	proto.RegisterType((*ResolveQuarantineResponse)(nil), "blog.ResolveQuarantineResponse")
+	proto.RegisterType((*CacheStatsRequest)(nil), "blog.CacheStatsRequest")
+	proto.RegisterType((*CacheStatsResponse)(nil), "blog.CacheStatsResponse")
+	proto.RegisterType((*RotateEncryptionKeyRequest)(nil), "blog.RotateEncryptionKeyRequest")
+	proto.RegisterType((*RotateEncryptionKeyResponse)(nil), "blog.RotateEncryptionKeyResponse")
+	proto.RegisterType((*ChangeEntry)(nil), "blog.ChangeEntry")
+	proto.RegisterType((*StreamChangesRequest)(nil), "blog.StreamChangesRequest")
+	proto.RegisterType((*StreamChangesResponse)(nil), "blog.StreamChangesResponse")
+	proto.RegisterType((*ReplicationStatusRequest)(nil), "blog.ReplicationStatusRequest")
+	proto.RegisterType((*ReplicationStatusResponse)(nil), "blog.ReplicationStatusResponse")
+	proto.RegisterType((*BlogCommand)(nil), "blog.BlogCommand")
+	proto.RegisterType((*AddVoterRequest)(nil), "blog.AddVoterRequest")
+	proto.RegisterType((*AddVoterResponse)(nil), "blog.AddVoterResponse")
+	proto.RegisterType((*RemoveServerRequest)(nil), "blog.RemoveServerRequest")
+	proto.RegisterType((*RemoveServerResponse)(nil), "blog.RemoveServerResponse")
+	proto.RegisterType((*ClusterStatusRequest)(nil), "blog.ClusterStatusRequest")
+	proto.RegisterType((*ClusterServer)(nil), "blog.ClusterServer")
+	proto.RegisterType((*ClusterStatusResponse)(nil), "blog.ClusterStatusResponse")
+	proto.RegisterType((*RaftLog)(nil), "blog.RaftLog")
+	proto.RegisterType((*RaftAppendEntriesRequest)(nil), "blog.RaftAppendEntriesRequest")
+	proto.RegisterType((*RaftAppendEntriesResponse)(nil), "blog.RaftAppendEntriesResponse")
+	proto.RegisterType((*RaftRequestVoteRequest)(nil), "blog.RaftRequestVoteRequest")
+	proto.RegisterType((*RaftRequestVoteResponse)(nil), "blog.RaftRequestVoteResponse")
+	proto.RegisterType((*RaftInstallSnapshotRequest)(nil), "blog.RaftInstallSnapshotRequest")
+	proto.RegisterType((*RaftInstallSnapshotResponse)(nil), "blog.RaftInstallSnapshotResponse")
+	proto.RegisterType((*RaftTimeoutNowRequest)(nil), "blog.RaftTimeoutNowRequest")
+	proto.RegisterType((*RaftTimeoutNowResponse)(nil), "blog.RaftTimeoutNowResponse")
+}
+
+func init() { proto.RegisterFile("blog/blogpb/blog.proto", fileDescriptor_a4b0406114889fe6) }
+
+var fileDescriptor_a4b0406114889fe6 = []byte{
+	// 4607 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3b, 0x4d, 0x6f, 0x24, 0x49,
+	0x56, 0x93, 0xf5, 0x5d, 0xaf, 0x5c, 0x76, 0x39, 0xec, 0x76, 0x57, 0xa7, 0xfb, 0xc3, 0x93, 0xd3,
+	0x3b, 0xd3, 0x3b, 0xc3, 0xf4, 0x2c, 0x5e, 0x96, 0x5d, 0xd4, 0x0b, 0xb3, 0xd5, 0x76, 0xcd, 0xac,
+	0x69, 0xb7, 0xdd, 0x93, 0x55, 0xee, 0x61, 0x16, 0xa4, 0x24, 0x5d, 0x19, 0x65, 0xe7, 0xba, 0x2a,
+	0xb3, 0x3a, 0x33, 0xcb, 0x1f, 0x73, 0x02, 0x7e, 0x01, 0x5a, 0x71, 0x40, 0x08, 0x09, 0x90, 0xb8,
+	0x20, 0xf8, 0x01, 0x88, 0x0b, 0x27, 0x6e, 0x2b, 0x71, 0xe5, 0xc2, 0x85, 0x13, 0x12, 0x12, 0x67,
+	0x24, 0x2e, 0xe8, 0xc5, 0x47, 0x66, 0xe4, 0x47, 0xd9, 0xee, 0xd9, 0xe1, 0x62, 0x67, 0xbc, 0xf7,
+	0xe2, 0xc5, 0x8b, 0xf7, 0x5e, 0xbc, 0x78, 0xf1, 0x22, 0x0a, 0x36, 0x8e, 0x27, 0xfe, 0xc9, 0x27,
+	0xf8, 0x67, 0x76, 0xcc, 0xfe, 0x3d, 0x9d, 0x05, 0x7e, 0xe4, 0x93, 0x0a, 0x7e, 0x1b, 0xff, 0x59,
+	0x86, 0xca, 0xf3, 0x89, 0x7f, 0x42, 0x96, 0xa1, 0xe4, 0x3a, 0x5d, 0x6d, 0x4b, 0x7b, 0x52, 0x31,
+	0x4b, 0xae, 0x43, 0x36, 0xa1, 0x69, 0xcf, 0xa3, 0x53, 0x3f, 0xb0, 0x5c, 0xa7, 0x5b, 0xda, 0xd2,
+	0x9e, 0x34, 0xcd, 0x06, 0x07, 0xec, 0x39, 0x64, 0x1d, 0xaa, 0x91, 0x1b, 0x4d, 0x68, 0xb7, 0xcc,
+	0x10, 0xbc, 0x41, 0xba, 0x50, 0x1f, 0xf9, 0x5e, 0x44, 0xbd, 0xa8, 0x5b, 0x61, 0x70, 0xd9, 0x24,
+	0x8f, 0xa0, 0x6c, 0x8f, 0x26, 0xdd, 0xea, 0x96, 0xf6, 0xa4, 0xb5, 0xdd, 0x7e, 0xca, 0xa4, 0xc0,
+	0x51, 0x7b, 0xa3, 0x89, 0x89, 0x18, 0x64, 0x38, 0x71, 0xcf, 0x68, 0xd8, 0xad, 0x31, 0x01, 0x78,
+	0x03, 0xa1, 0xe7, 0x2e, 0xbd, 0x08, 0xbb, 0x75, 0x0e, 0x65, 0x0d, 0xf2, 0x01, 0xac, 0xf8, 0x81,
+	0x7b, 0xe2, 0x7a, 0xf6, 0xc4, 0x9a, 0xf8, 0x23, 0x7b, 0x42, 0xbb, 0x0d, 0x36, 0xdc, 0xb2, 0x04,
+	0xef, 0x33, 0x28, 0xd9, 0x80, 0x9a, 0xc0, 0x37, 0x19, 0x5e, 0xb4, 0xc8, 0xc7, 0x40, 0xa2, 0xc0,
+	0xf6, 0xc2, 0x89, 0x1d, 0x51, 0x47, 0xb0, 0x08, 0xbb, 0xb0, 0x55, 0x7e, 0xd2, 0x34, 0x57, 0x13,
+	0x0c, 0xe7, 0x12, 0x92, 0xdf, 0x82, 0x25, 0x09, 0x74, 0x7d, 0x2f, 0xec, 0xb6, 0xb6, 0xca, 0x4f,
+	0x5a, 0xdb, 0x77, 0x92, 0x59, 0x0c, 0x13, 0xac, 0x99, 0x22, 0x25, 0x1f, 0xc1, 0xaa, 0x50, 0x81,
+	0x15, 0x05, 0x73, 0x6f, 0x84, 0x6c, 0xbb, 0x4b, 0x5b, 0xda, 0x93, 0x86, 0xd9, 0x11, 0x88, 0xa1,
+	0x84, 0x93, 0x77, 0x61, 0x49, 0x12, 0x87, 0xee, 0xd7, 0xb4, 0xdb, 0x66, 0x93, 0x6e, 0x09, 0xd8,
+	0xc0, 0xfd, 0x9a, 0xe2, 0xd4, 0x25, 0xc9, 0x39, 0x0d, 0x42, 0xd7, 0xf7, 0xba, 0xcb, 0x8c, 0x6a,
+	0x59, 0x80, 0x5f, 0x73, 0x28, 0x21, 0x50, 0x89, 0xec, 0x93, 0xb0, 0xbb, 0xc2, 0x26, 0xc5, 0xbe,
+	0x8d, 0x47, 0xd0, 0x44, 0x69, 0x77, 0x4e, 0xe7, 0xde, 0x19, 0x12, 0x38, 0x76, 0x64, 0x33, 0x83,
+	0x2f, 0x99, 0xec, 0xdb, 0xe8, 0x41, 0x7b, 0x87, 0xb3, 0x39, 0x9a, 0x4d, 0x7c, 0xdb, 0x41, 0x83,
+	0xca, 0x61, 0xb8, 0x63, 0xc8, 0x26, 0xaa, 0xd6, 0x1f, 0x8f, 0x43, 0x1a, 0x31, 0xd7, 0xa8, 0x98,
+	0xa2, 0x65, 0x7c, 0x05, 0x2b, 0x19, 0x8d, 0x28, 0x56, 0xd0, 0x52, 0x56, 0x88, 0x7d, 0xa8, 0xb4,
+	0xc0, 0x87, 0xca, 0x29, 0x1f, 0x32, 0xce, 0xa0, 0x2e, 0x5c, 0x06, 0xbb, 0xfa, 0x17, 0x1e, 0x0d,
+	0x04, 0x47, 0xde, 0xc0, 0xae, 0xd4, 0x71, 0x23, 0x3f, 0x08, 0xbb, 0x25, 0x36, 0x6d, 0xd9, 0x64,
+	0xf3, 0x70, 0xe9, 0x05, 0x0d, 0xc2, 0x6e, 0x99, 0x63, 0x44, 0x13, 0x85, 0x9b, 0xcd, 0x8f, 0x27,
+	0xee, 0x88, 0x79, 0x6c, 0xc3, 0x14, 0x2d, 0xe3, 0xfb, 0xb0, 0xba, 0x13, 0x50, 0x3b, 0xa2, 0x38,
+	0xa4, 0x49, 0xdf, 0xcc, 0x69, 0x18, 0x91, 0x87, 0xc0, 0xd6, 0x0c, 0x1b, 0xb5, 0xb5, 0x0d, 0x89,
+	0x03, 0x98, 0x7c, 0x2d, 0x7d, 0x05, 0x44, 0xed, 0x14, 0xce, 0x7c, 0x2f, 0xa4, 0x37, 0xf5, 0x22,
+	0xef, 0x41, 0xfb, 0xcd, 0xdc, 0x0e, 0x6c, 0x2f, 0x72, 0x3d, 0x2a, 0x17, 0x5b, 0xc5, 0x5c, 0x4a,
+	0x80, 0x7b, 0x8e, 0xf1, 0x27, 0x1a, 0xac, 0x98, 0xd4, 0x76, 0x54, 0x71, 0xee, 0x42, 0x1d, 0x19,
+	0x58, 0xf1, 0xb2, 0xad, 0x61, 0x73, 0x8f, 0x99, 0x4d, 0x3a, 0xb5, 0x50, 0x84, 0x68, 0xa2, 0xff,
+	0x5c, 0xb8, 0xd1, 0xa9, 0xe5, 0xd9, 0xe7, 0xee, 0x09, 0x33, 0x0f, 0xd3, 0x72, 0xc3, 0x5c, 0x46,
+	0xf0, 0x41, 0x0c, 0x25, 0x6b, 0x50, 0xb5, 0x43, 0xcb, 0x1f, 0x33, 0xb5, 0x94, 0xcd, 0x8a, 0x1d,
+	0x1e, 0x8e, 0x8d, 0x9f, 0x43, 0x27, 0x91, 0xe1, 0x96, 0xb3, 0xfb, 0x4d, 0x00, 0x65, 0xb0, 0x12,
+	0xa3, 0xda, 0xe0, 0x54, 0x03, 0x1a, 0xb8, 0x34, 0x4c, 0x06, 0x35, 0x15, 0x4a, 0xe3, 0x8f, 0x35,
+	0xa8, 0x71, 0x82, 0x5c, 0x64, 0x2a, 0x76, 0x9c, 0x2d, 0x68, 0x39, 0x34, 0x1c, 0x05, 0xee, 0x2c,
+	0x9e, 0x56, 0xd3, 0x54, 0x41, 0x89, 0xd7, 0x54, 0x54, 0xaf, 0xb9, 0x07, 0x0d, 0xa1, 0xc5, 0xb0,
+	0x5b, 0xdd, 0x2a, 0xa3, 0x93, 0x73, 0x35, 0x86, 0xc6, 0x2f, 0x35, 0xe8, 0x64, 0x85, 0xc4, 0xb8,
+	0x18, 0x32, 0x58, 0xa2, 0xf7, 0x06, 0x07, 0xec, 0xb1, 0x25, 0x2c, 0x90, 0xaa, 0x84, 0x2d, 0x0e,
+	0x1b, 0x32, 0x39, 0x75, 0x68, 0xcc, 0xfc, 0xd0, 0x8d, 0x85, 0xac, 0x9a, 0x71, 0x1b, 0x25, 0x1c,
+	0xf9, 0x73, 0x11, 0x3e, 0xab, 0x26, 0x6f, 0x90, 0xf7, 0xa1, 0x31, 0x0b, 0xe8, 0xb9, 0xeb, 0xcf,
+	0xc3, 0x6e, 0x35, 0xa7, 0xe6, 0x18, 0x87, 0xa6, 0xf0, 0xe8, 0x65, 0xd4, 0xad, 0xe5, 0x68, 0x18,
+	0x1c, 0x7d, 0xfa, 0x68, 0xe6, 0xbc, 0xbd, 0x4f, 0xab, 0x9d, 0xbe, 0x4d, 0x9f, 0xfe, 0x35, 0x58,
+	0xdd, 0xa5, 0x13, 0x1a, 0xd1, 0xdb, 0x38, 0xb5, 0xf1, 0x31, 0x10, 0x95, 0x5a, 0x08, 0xb2, 0x90,
+	0xfc, 0x27, 0xb0, 0xb2, 0xef, 0x86, 0x91, 0xca, 0x5a, 0x59, 0x16, 0x5a, 0x7a, 0x59, 0xc4, 0xde,
+	0x5e, 0x52, 0xbc, 0x7d, 0x1b, 0x3a, 0x09, 0x87, 0xdb, 0xcd, 0x1b, 0xa7, 0xf4, 0x39, 0x8d, 0xe4,
+	0xce, 0x76, 0xd3, 0x94, 0x7e, 0x00, 0x44, 0xa5, 0x16, 0x63, 0x88, 0xbd, 0x52, 0x5b, 0xb4, 0x57,
+	0x1a, 0x2f, 0x61, 0x75, 0x70, 0xeb, 0x41, 0x24, 0xbb, 0xd2, 0x42, 0x76, 0x3f, 0x00, 0x32, 0xf8,
+	0x06, 0x52, 0xbc, 0x80, 0xd5, 0xbd, 0xe9, 0xcc, 0x0f, 0x52, 0x2a, 0xde, 0x80, 0x5a, 0xe8, 0xcf,
+	0x83, 0x51, 0x1c, 0xeb, 0x79, 0x2b, 0xd6, 0x5b, 0x69, 0x81, 0xde, 0xfe, 0x4a, 0x03, 0xa2, 0x72,
+	0x13, 0x42, 0x7c, 0x43, 0x76, 0x79, 0xf7, 0x2b, 0xe7, 0xdd, 0x0f, 0x77, 0xc0, 0x91, 0xef, 0x50,
+	0xb1, 0xd6, 0xd8, 0x37, 0xba, 0xc8, 0x94, 0x86, 0xa1, 0x7d, 0x42, 0xd9, 0x4a, 0x6b, 0x9a, 0xb2,
+	0x69, 0xfc, 0x21, 0xac, 0xec, 0xba, 0xe3, 0xf1, 0xad, 0xe2, 0xef, 0x26, 0x34, 0xc7, 0x81, 0x3f,
+	0xb5, 0x22, 0x77, 0x4a, 0x85, 0x4b, 0x35, 0x10, 0x30, 0x74, 0xa7, 0xcc, 0x63, 0x23, 0x9f, 0xa3,
+	0xca, 0x0c, 0x55, 0x8b, 0x7c, 0x44, 0x18, 0x7f, 0xad, 0x41, 0x03, 0x87, 0xd8, 0x77, 0x3d, 0x4a,
+	0xde, 0x85, 0x92, 0x3f, 0x63, 0x6c, 0x97, 0xb7, 0x57, 0xf9, 0xfc, 0x24, 0xee, 0xe9, 0xe1, 0xcc,
+	0x2c, 0xf9, 0x33, 0xb6, 0xc5, 0xe3, 0x72, 0xe7, 0x31, 0x86, 0x7d, 0xc7, 0x23, 0x4f, 0x5c, 0x8f,
+	0xca, 0xe8, 0x82, 0x00, 0xc6, 0x93, 0x8f, 0xcc, 0x50, 0x7c, 0xce, 0xb5, 0xc8, 0x47, 0x84, 0xf1,
+	0x01, 0x94, 0x0e, 0x67, 0xa4, 0x09, 0xd5, 0xfe, 0x17, 0x47, 0xbd, 0xfd, 0xce, 0x3b, 0x04, 0xa0,
+	0xb6, 0xdb, 0xdf, 0xef, 0x0f, 0xfb, 0x1d, 0x0d, 0xbf, 0xf7, 0x0e, 0x06, 0x7d, 0x73, 0xd8, 0x29,
+	0x19, 0x11, 0x74, 0x12, 0x25, 0x24, 0x4b, 0x02, 0x47, 0x28, 0x5a, 0x12, 0x08, 0x27, 0x3a, 0x94,
+	0x22, 0xbf, 0xc0, 0x52, 0xa5, 0xc8, 0x27, 0x8f, 0x31, 0xeb, 0xf3, 0x28, 0xdf, 0x95, 0x5b, 0xdb,
+	0xcb, 0xe9, 0x89, 0x9a, 0x1c, 0x69, 0x7c, 0x09, 0x4b, 0x83, 0xc8, 0x0f, 0xec, 0x13, 0x7a, 0x84,
+	0xa6, 0x48, 0x67, 0xa6, 0x5a, 0x3e, 0x33, 0x9d, 0xf9, 0x61, 0x14, 0x8a, 0x88, 0xc3, 0x1b, 0x08,
+	0x3d, 0xbe, 0x8a, 0xd8, 0x40, 0x0c, 0xca, 0x1a, 0xc6, 0x9f, 0x6b, 0x31, 0xe7, 0x2f, 0xe6, 0x7e,
+	0x64, 0x93, 0x4f, 0x60, 0x7d, 0x6a, 0x5f, 0x5a, 0xac, 0x8f, 0x35, 0xa3, 0x81, 0xc5, 0xd9, 0x0a,
+	0xf3, 0xae, 0x4e, 0xed, 0xcb, 0x57, 0x88, 0x7a, 0x45, 0x83, 0x1e, 0x43, 0xc8, 0x0e, 0x8c, 0x9d,
+	0xda, 0xa1, 0x14, 0x77, 0x78, 0x8e, 0xa8, 0xa4, 0xc3, 0xfb, 0xb0, 0x82, 0x1d, 0x22, 0x3f, 0xb2,
+	0x27, 0x96, 0x2a, 0x52, 0x7b, 0x6a, 0x5f, 0x0e, 0x11, 0xca, 0x3a, 0x18, 0x4f, 0x61, 0xe5, 0x73,
+	0x1a, 0xb1, 0xf9, 0x4a, 0x77, 0xbb, 0x6e, 0xda, 0xc6, 0x2f, 0x34, 0xe8, 0x24, 0x1d, 0x84, 0x69,
+	0x3e, 0x84, 0x9a, 0x32, 0x81, 0xd6, 0x36, 0x11, 0xfb, 0xae, 0xa2, 0x4c, 0x53, 0x50, 0x90, 0x27,
+	0x50, 0x65, 0x42, 0x75, 0x4b, 0x0b, 0x49, 0x39, 0x01, 0x52, 0xbe, 0x41, 0x6d, 0x75, 0xcb, 0x05,
+	0x94, 0x4c, 0x8f, 0x26, 0x27, 0x30, 0x5e, 0x40, 0x77, 0x10, 0x05, 0xd4, 0x9e, 0xb2, 0xb4, 0x93,
+	0xa7, 0x71, 0x37, 0x2e, 0x9e, 0x45, 0x99, 0xe5, 0xcf, 0xe1, 0x5e, 0x01, 0xb3, 0x24, 0x50, 0x88,
+	0x4e, 0x9a, 0xda, 0x29, 0xce, 0x72, 0x4b, 0x49, 0x96, 0x9b, 0x4b, 0xb3, 0xcb, 0xb9, 0x34, 0xdb,
+	0xf8, 0x1a, 0xee, 0x7e, 0x19, 0xb8, 0x11, 0x4d, 0x0d, 0xf5, 0xcd, 0xe4, 0x8e, 0x45, 0x28, 0x2b,
+	0x22, 0xe8, 0xd0, 0x18, 0xf9, 0xd3, 0xd9, 0x84, 0x46, 0x54, 0xe4, 0x9d, 0x71, 0xdb, 0x30, 0xa1,
+	0x9b, 0x1f, 0xfb, 0x86, 0x69, 0xde, 0x14, 0x5e, 0x9f, 0xc1, 0x1a, 0x4f, 0x4c, 0x79, 0x36, 0x23,
+	0xe7, 0xf2, 0x18, 0x6a, 0x3c, 0x33, 0x11, 0xfe, 0xb1, 0xa4, 0xe6, 0x65, 0xa6, 0xc0, 0x19, 0x3f,
+	0x86, 0xf5, 0x74, 0x67, 0x21, 0xcc, 0xed, 0x7a, 0xef, 0x31, 0xbf, 0x4c, 0x8f, 0x7b, 0x6d, 0x0a,
+	0xb5, 0x30, 0x79, 0x35, 0x7e, 0x1f, 0x56, 0x15, 0x56, 0x6f, 0x23, 0x05, 0xd9, 0x82, 0x2a, 0x82,
+	0x39, 0xcb, 0xb4, 0x86, 0x38, 0xc2, 0xd8, 0x86, 0x35, 0x9e, 0x5e, 0xdc, 0x5e, 0x54, 0x63, 0x03,
+	0xd6, 0xd3, 0x7d, 0xb8, 0x4c, 0xc6, 0x18, 0x48, 0xcf, 0x71, 0x86, 0xfe, 0x5b, 0xcc, 0x5a, 0x71,
+	0xab, 0x52, 0xca, 0xad, 0xae, 0x49, 0x17, 0xd1, 0xac, 0xa9, 0x71, 0xde, 0xca, 0x30, 0x27, 0xb0,
+	0xf6, 0xd2, 0x3f, 0xa7, 0x7b, 0xde, 0xff, 0xb7, 0x94, 0x3f, 0x86, 0xf5, 0xf4, 0x40, 0x6f, 0x25,
+	0xe6, 0x21, 0xdc, 0x35, 0xe9, 0xd4, 0x3f, 0xa7, 0x9f, 0x05, 0xfe, 0xf4, 0x5b, 0x10, 0xd5, 0xf8,
+	0x09, 0x74, 0xf3, 0x0c, 0xdf, 0x4a, 0xa4, 0x19, 0xdc, 0x3f, 0x9a, 0x85, 0x34, 0x88, 0x32, 0x27,
+	0xdd, 0x1b, 0x43, 0xc4, 0x0f, 0xa1, 0xa5, 0x54, 0x07, 0xc4, 0x6a, 0x5d, 0x50, 0x47, 0x50, 0x29,
+	0x8d, 0x4f, 0xe1, 0xc1, 0x82, 0x11, 0x6f, 0x99, 0x97, 0x1e, 0xc2, 0xfd, 0x24, 0x79, 0x7e, 0x1b,
+	0x91, 0x93, 0xc3, 0x7b, 0x49, 0x3d, 0xbc, 0x1b, 0x8f, 0xe0, 0xc1, 0x02, 0x86, 0x62, 0x0d, 0xec,
+	0xc2, 0x9a, 0x49, 0x59, 0x19, 0x05, 0x29, 0xc2, 0x1b, 0x07, 0x62, 0x05, 0xa0, 0xa9, 0xcb, 0xa3,
+	0x67, 0xd5, 0xe4, 0x0d, 0x63, 0x07, 0x5a, 0x0a, 0x97, 0x1b, 0x8f, 0x1d, 0xeb, 0x50, 0x0d, 0x47,
+	0x7e, 0xc0, 0x85, 0xd5, 0x4c, 0xde, 0x30, 0x3e, 0x85, 0xf5, 0xb4, 0x28, 0x42, 0x69, 0x1f, 0xc8,
+	0xa0, 0xa0, 0xb1, 0xa0, 0x20, 0xd2, 0x2c, 0x85, 0x54, 0xc6, 0x86, 0x1d, 0x3c, 0x4b, 0x9c, 0xdd,
+	0xea, 0x98, 0x82, 0x88, 0x79, 0x48, 0x95, 0xa2, 0x59, 0x0d, 0x9b, 0x7b, 0x8e, 0xf1, 0x04, 0x3a,
+	0x09, 0x13, 0x21, 0x41, 0x5c, 0xf5, 0xd2, 0x94, 0xaa, 0x97, 0xd1, 0x87, 0xd5, 0x23, 0x6f, 0xf2,
+	0x2b, 0x0f, 0xf8, 0x21, 0x10, 0x95, 0xcd, 0xb5, 0x43, 0xfe, 0xad, 0x06, 0x2b, 0x43, 0x7f, 0x96,
+	0x32, 0xd5, 0x6f, 0x40, 0x6d, 0x4a, 0xa3, 0xc0, 0x1d, 0x89, 0x34, 0xf4, 0x3e, 0xd7, 0x4f, 0x86,
+	0xec, 0xe9, 0x4b, 0x46, 0x63, 0x0a, 0x5a, 0xf2, 0x1d, 0x58, 0xbe, 0x70, 0x3d, 0xc7, 0xbf, 0xb0,
+	0x42, 0x3a, 0xf2, 0x3d, 0x27, 0x14, 0x09, 0x70, 0x9b, 0x43, 0x07, 0x1c, 0x98, 0x98, 0xbb, 0xac,
+	0x9a, 0xfb, 0x21, 0xd4, 0x38, 0x3b, 0x4c, 0x46, 0x5f, 0xef, 0xf5, 0xbf, 0x1c, 0x74, 0xde, 0xc1,
+	0xcf, 0xfd, 0xbd, 0x17, 0xfd, 0x41, 0x47, 0x33, 0x3e, 0x85, 0xba, 0x18, 0xfe, 0x36, 0xae, 0xc0,
+	0x8f, 0xd2, 0x22, 0x0f, 0x64, 0x0d, 0xe3, 0x87, 0xd0, 0x49, 0xe4, 0x17, 0x1a, 0x79, 0x2f, 0xed,
+	0x06, 0xed, 0xd4, 0x34, 0xa5, 0x0b, 0x74, 0x61, 0x63, 0xc7, 0x9f, 0xce, 0xec, 0x51, 0xb4, 0x6b,
+	0x47, 0xf6, 0xb1, 0x1d, 0xca, 0xb4, 0xcc, 0xf8, 0x0a, 0xee, 0xe6, 0x30, 0xf1, 0x19, 0xaa, 0x85,
+	0x19, 0x86, 0x75, 0x4c, 0xc7, 0xe8, 0x94, 0x1a, 0x53, 0x04, 0x20, 0xe8, 0x39, 0x83, 0x90, 0x07,
+	0xc0, 0x5a, 0x96, 0x3d, 0x8e, 0x68, 0x20, 0x14, 0xd5, 0x44, 0x48, 0x0f, 0x01, 0x6c, 0x7f, 0x11,
+	0x3c, 0x07, 0x91, 0x1d, 0x49, 0x95, 0x1b, 0x7f, 0x5f, 0x86, 0xd6, 0xf3, 0xf9, 0xe8, 0x8c, 0x46,
+	0x0c, 0x8c, 0x29, 0x86, 0x67, 0x4f, 0xe5, 0x21, 0x89, 0x7d, 0xe3, 0x91, 0xf6, 0x8c, 0x5e, 0x59,
+	0x9e, 0x3c, 0xd2, 0x9e, 0xd1, 0xab, 0x03, 0x54, 0x8a, 0x43, 0x67, 0xd1, 0xa9, 0x38, 0x79, 0xf0,
+	0x06, 0x31, 0xa0, 0x7d, 0x1c, 0xd8, 0xde, 0xe8, 0xd4, 0x9a, 0xd9, 0x27, 0xd4, 0xf2, 0x44, 0xcd,
+	0xa7, 0xc5, 0x81, 0xaf, 0xec, 0x13, 0x7a, 0x40, 0x3e, 0x84, 0x55, 0x41, 0xe3, 0x9f, 0xd3, 0x60,
+	0x3c, 0xf1, 0x2f, 0x2c, 0x8f, 0x1d, 0x91, 0xca, 0xe6, 0x0a, 0x47, 0x1c, 0x0a, 0xf8, 0x01, 0x79,
+	0x08, 0xad, 0x09, 0xb5, 0xc7, 0x92, 0x5b, 0x8d, 0x4f, 0x0b, 0x41, 0x9c, 0xd7, 0xfb, 0xb0, 0xc2,
+	0xf0, 0x0a, 0xa7, 0x3a, 0xf7, 0x11, 0x04, 0x27, 0x7c, 0xde, 0x85, 0x25, 0x31, 0xa6, 0x3d, 0x99,
+	0xf8, 0xa3, 0x6e, 0x43, 0x15, 0xab, 0x87, 0x20, 0x85, 0xc4, 0xf5, 0xe6, 0x21, 0xaf, 0xf3, 0xc6,
+	0x24, 0x7b, 0x08, 0x42, 0x1d, 0xb3, 0xd1, 0x38, 0x0f, 0x48, 0x84, 0xe1, 0x1c, 0x24, 0x9a, 0xf7,
+	0x6f, 0x25, 0x68, 0xde, 0x1b, 0xab, 0x43, 0x4c, 0xd3, 0x96, 0xc7, 0xea, 0xb6, 0x65, 0xb3, 0xce,
+	0xdb, 0x6c, 0x1a, 0xae, 0x87, 0x27, 0x14, 0x2b, 0xa6, 0x68, 0xf3, 0x69, 0x70, 0x30, 0xb7, 0xd0,
+	0x81, 0xf1, 0xa7, 0x65, 0xb8, 0x93, 0x31, 0xa3, 0xf0, 0x0f, 0x3c, 0xad, 0xb9, 0x13, 0xca, 0xd3,
+	0x50, 0x4d, 0x9c, 0x13, 0xdd, 0x09, 0x65, 0xa5, 0xde, 0x4d, 0x68, 0x32, 0x05, 0x32, 0xa4, 0x38,
+	0x44, 0x22, 0x40, 0x22, 0x31, 0x91, 0x4c, 0x12, 0xd8, 0xb2, 0xd9, 0x40, 0x00, 0x43, 0x3e, 0x84,
+	0xd6, 0x38, 0xa0, 0x34, 0x6d, 0xcd, 0x26, 0x82, 0xb8, 0xfe, 0x1f, 0xc3, 0xf2, 0x8c, 0x7a, 0x8e,
+	0xeb, 0x9d, 0x48, 0x12, 0x6e, 0xc8, 0x25, 0x01, 0xe5, 0x54, 0x0f, 0x00, 0x18, 0x17, 0xae, 0xb7,
+	0x5a, 0xc2, 0x84, 0xeb, 0xed, 0x3b, 0xb0, 0x8c, 0x8d, 0x89, 0x1b, 0x46, 0x42, 0x77, 0xc2, 0x86,
+	0x12, 0xca, 0xf5, 0xb7, 0x0a, 0x95, 0xe8, 0xd2, 0xf2, 0x84, 0xed, 0xca, 0xd1, 0xe5, 0x01, 0xd1,
+	0xa1, 0xe9, 0xcf, 0xa8, 0x67, 0x31, 0x38, 0x37, 0x58, 0x1d, 0x01, 0xc3, 0xcb, 0x03, 0xf2, 0x11,
+	0x08, 0xf5, 0xf2, 0x72, 0x7c, 0x1c, 0x94, 0x15, 0x6f, 0x97, 0x06, 0x08, 0xc9, 0x8f, 0xa0, 0x85,
+	0x59, 0x73, 0x40, 0x43, 0x56, 0xa1, 0xe6, 0x65, 0x79, 0x51, 0x5b, 0xdc, 0x49, 0x10, 0xbc, 0x97,
+	0x4a, 0x6a, 0xfc, 0x8b, 0x06, 0x9d, 0x2c, 0x05, 0x6e, 0x75, 0x9c, 0xb3, 0x2c, 0x36, 0xf0, 0x16,
+	0xc2, 0xcf, 0xed, 0xc9, 0x9c, 0xca, 0x48, 0x26, 0x5a, 0xbc, 0xb6, 0xcf, 0x79, 0x50, 0xc7, 0x12,
+	0x24, 0xdc, 0x16, 0x9d, 0x04, 0xf1, 0x9a, 0x13, 0x63, 0x61, 0x30, 0xf2, 0x03, 0xea, 0x88, 0x43,
+	0x9f, 0x58, 0x62, 0x1c, 0xc6, 0x8e, 0x7c, 0x68, 0xd3, 0xc0, 0xbe, 0x10, 0x78, 0x6e, 0x91, 0x46,
+	0x60, 0x5f, 0x70, 0xe4, 0x3a, 0x54, 0x03, 0xdc, 0x60, 0x99, 0x21, 0x34, 0x93, 0x37, 0x8c, 0xbf,
+	0xd3, 0x60, 0xe5, 0x8b, 0xb8, 0xa6, 0xe1, 0x14, 0xde, 0xe3, 0xdc, 0x54, 0x2b, 0xd9, 0x80, 0xda,
+	0x9c, 0x15, 0xf8, 0x44, 0x25, 0x58, 0xb4, 0x30, 0x0f, 0x0f, 0xa8, 0x1d, 0xfa, 0x1e, 0x0a, 0xcb,
+	0xf2, 0x70, 0xd1, 0x24, 0xf7, 0xa1, 0x89, 0xe5, 0x8b, 0x30, 0xb2, 0xa7, 0x33, 0x21, 0x68, 0x02,
+	0x40, 0x7e, 0x23, 0x7b, 0x32, 0xa1, 0x01, 0x13, 0xb5, 0x69, 0x8a, 0x96, 0x71, 0x17, 0xee, 0x60,
+	0x39, 0x2d, 0x11, 0x57, 0x46, 0xb3, 0x3e, 0x6c, 0x64, 0x11, 0x62, 0x7d, 0x7c, 0x94, 0x8e, 0xcc,
+	0x22, 0x53, 0xca, 0x4c, 0x58, 0x46, 0xe8, 0xaf, 0x30, 0xaf, 0x0b, 0xfd, 0xc9, 0x39, 0xcd, 0x0d,
+	0x91, 0xaf, 0x07, 0x69, 0x05, 0xf5, 0xa0, 0x2e, 0xd4, 0xed, 0xd9, 0x2c, 0xf0, 0xcf, 0xf9, 0x72,
+	0x6b, 0x98, 0xb2, 0x69, 0x3c, 0x83, 0x7b, 0x05, 0xac, 0x6f, 0x99, 0x7a, 0xad, 0xc1, 0xea, 0x8e,
+	0x3d, 0x3a, 0x4d, 0x47, 0xf0, 0x5f, 0x6a, 0x40, 0x54, 0xa8, 0xe0, 0x85, 0x37, 0x18, 0x5e, 0x14,
+	0x27, 0xa0, 0x65, 0x53, 0x36, 0x93, 0x02, 0x06, 0xf7, 0x41, 0xde, 0xc0, 0x0d, 0x06, 0xab, 0x09,
+	0xb2, 0x0f, 0x77, 0x3e, 0x98, 0xda, 0x97, 0x7d, 0xd1, 0x6d, 0x13, 0x9a, 0x71, 0x7d, 0x42, 0xf8,
+	0x5c, 0x43, 0x16, 0x25, 0x70, 0xdb, 0x38, 0x75, 0x23, 0xee, 0x6b, 0x15, 0x93, 0x7d, 0xa3, 0xf5,
+	0xa6, 0x6e, 0x18, 0xc6, 0x17, 0x71, 0xa2, 0x85, 0x36, 0xa7, 0xe7, 0xee, 0x88, 0x5f, 0x80, 0xf1,
+	0xdb, 0xb8, 0x04, 0x60, 0xdc, 0x07, 0xdd, 0xf4, 0x23, 0x3b, 0xa2, 0x7d, 0x6f, 0x14, 0x5c, 0xb1,
+	0x6a, 0xfb, 0x0b, 0x7a, 0x25, 0x27, 0x7b, 0x0c, 0x9b, 0x85, 0x58, 0x31, 0xe9, 0x2d, 0x68, 0x05,
+	0x94, 0x72, 0x14, 0x95, 0xa6, 0x51, 0x41, 0xb8, 0x78, 0x02, 0x1a, 0xb9, 0xb8, 0x7a, 0xce, 0xe8,
+	0x95, 0x2c, 0xed, 0xb4, 0x04, 0xec, 0x05, 0xbd, 0x0a, 0x8d, 0x7f, 0x2e, 0x43, 0x6b, 0xe7, 0xd4,
+	0xf6, 0x4e, 0x28, 0x4e, 0xfd, 0x8a, 0x74, 0xa0, 0x1c, 0xd2, 0x37, 0x82, 0x19, 0x7e, 0xa6, 0xbd,
+	0xb6, 0x94, 0xf5, 0xda, 0xc7, 0xac, 0xde, 0x56, 0x66, 0x89, 0xce, 0xba, 0x08, 0x21, 0x09, 0x3b,
+	0x59, 0x72, 0x53, 0x92, 0xb0, 0x4a, 0x2a, 0x09, 0x93, 0x4e, 0x50, 0x5d, 0xb0, 0xc8, 0x52, 0x47,
+	0x95, 0x5a, 0xe6, 0xa8, 0x92, 0x9c, 0x3a, 0xea, 0xd7, 0x1c, 0x61, 0x0b, 0xae, 0xfe, 0x1a, 0x85,
+	0x57, 0x7f, 0x8f, 0xa0, 0x35, 0xc2, 0x2b, 0x3e, 0xcb, 0xf5, 0x1c, 0x7a, 0xc9, 0x22, 0x6c, 0xc5,
+	0x04, 0x06, 0xda, 0x43, 0x08, 0x4b, 0x8d, 0xb0, 0xc5, 0x36, 0xc3, 0x25, 0x93, 0x37, 0x92, 0xbb,
+	0xd6, 0x96, 0x72, 0xd7, 0x6a, 0x9c, 0xb2, 0xd2, 0x60, 0x1d, 0xca, 0xaf, 0x8e, 0x86, 0x99, 0xc2,
+	0xe0, 0x32, 0xc0, 0xab, 0xa3, 0xa1, 0x35, 0xe8, 0x9b, 0x7b, 0xfd, 0x41, 0xa7, 0x44, 0x56, 0xa1,
+	0xcd, 0x71, 0x12, 0x54, 0x26, 0x6d, 0x68, 0x22, 0xc9, 0xce, 0x4f, 0x8f, 0x0e, 0x5e, 0x74, 0x2a,
+	0x0a, 0x05, 0x83, 0x0c, 0x3a, 0x55, 0xd2, 0x80, 0x0a, 0xe6, 0x79, 0x9d, 0x9a, 0xf1, 0xeb, 0xb0,
+	0xce, 0xeb, 0x3b, 0x5c, 0xef, 0x71, 0x1a, 0x7a, 0x0f, 0x58, 0x05, 0xd3, 0x4a, 0xcc, 0x59, 0x1f,
+	0xb3, 0x93, 0xdb, 0x1b, 0xe3, 0x8f, 0x34, 0xb8, 0x93, 0xe9, 0x93, 0xa4, 0xf6, 0xb8, 0x28, 0xae,
+	0xc4, 0xaa, 0x5c, 0xcd, 0x59, 0xd4, 0xe4, 0x78, 0xb1, 0xfd, 0x3b, 0x34, 0x60, 0xfc, 0xb9, 0x63,
+	0x35, 0x39, 0x64, 0x40, 0xdf, 0xa0, 0x2e, 0x05, 0x5a, 0x29, 0xd8, 0x8a, 0x1e, 0xac, 0x68, 0xab,
+	0x63, 0xd4, 0x99, 0x4d, 0xdc, 0x11, 0x3b, 0xfd, 0xe0, 0x6a, 0x9e, 0xc7, 0x8b, 0xfc, 0x7f, 0x4b,
+	0x70, 0xaf, 0x00, 0x29, 0x44, 0xfc, 0x11, 0x54, 0x02, 0x5f, 0x5c, 0x8a, 0x2e, 0x6f, 0x3f, 0x96,
+	0x87, 0x8f, 0x05, 0xe4, 0x4f, 0x4d, 0x7f, 0x42, 0x4d, 0xd6, 0x43, 0x11, 0xca, 0x76, 0x9c, 0x40,
+	0x64, 0xfd, 0x42, 0xa8, 0x9e, 0xe3, 0x04, 0xe8, 0xea, 0x23, 0xdf, 0xf3, 0xe8, 0x08, 0xd7, 0x13,
+	0x8f, 0xea, 0x09, 0x00, 0xbb, 0xdb, 0xb3, 0xd9, 0xc4, 0xa5, 0x0e, 0x9b, 0x33, 0x77, 0x64, 0x10,
+	0x20, 0x9c, 0x74, 0x5a, 0x27, 0xd5, 0x22, 0x9d, 0xd8, 0x27, 0x71, 0xd0, 0xe1, 0xde, 0x0c, 0x13,
+	0xfb, 0x44, 0x06, 0x9d, 0xa7, 0xb0, 0x86, 0xdc, 0xae, 0x2c, 0x87, 0x4e, 0xec, 0xab, 0xf8, 0x1c,
+	0x50, 0x67, 0x3b, 0xd7, 0x2a, 0x43, 0xed, 0x22, 0x46, 0x9e, 0x05, 0xb6, 0xe1, 0x8e, 0xa0, 0xb1,
+	0x42, 0xd7, 0x1b, 0x51, 0x0b, 0x1d, 0xda, 0x1e, 0x45, 0xcc, 0xbf, 0x35, 0x73, 0x4d, 0x20, 0x07,
+	0x88, 0xdb, 0xe1, 0x28, 0x63, 0x0b, 0x2a, 0xa8, 0x11, 0x74, 0xc8, 0xfd, 0x7e, 0x6f, 0xb7, 0x6f,
+	0x76, 0xde, 0x21, 0x4b, 0xd0, 0xf8, 0xec, 0x70, 0x7f, 0xff, 0xf0, 0xcb, 0xbe, 0xd9, 0xd1, 0x8c,
+	0xff, 0xae, 0x41, 0x8b, 0xd7, 0xd0, 0xa6, 0x53, 0xdb, 0x73, 0xc4, 0x0a, 0xd7, 0xd4, 0x15, 0xae,
+	0xa0, 0xe5, 0x0a, 0xbf, 0x69, 0xb7, 0x54, 0x22, 0x40, 0xb9, 0xe8, 0x9a, 0xa5, 0xb2, 0xf0, 0x85,
+	0x43, 0xb2, 0x2f, 0x56, 0xd5, 0x7d, 0x31, 0x59, 0x77, 0x35, 0xf5, 0x8d, 0x43, 0x2a, 0x5a, 0xd5,
+	0xb3, 0xd1, 0x2a, 0x53, 0x48, 0x68, 0xdc, 0xb6, 0x90, 0xa0, 0x84, 0x9a, 0xe6, 0x35, 0xa1, 0x26,
+	0x15, 0xad, 0x20, 0x13, 0xad, 0xd4, 0x52, 0x4f, 0x2b, 0x73, 0x7f, 0x99, 0xd4, 0x27, 0x97, 0x0a,
+	0x6b, 0xa0, 0x6d, 0xa5, 0x06, 0x1a, 0x97, 0x91, 0x97, 0x6f, 0x28, 0x23, 0x93, 0x9f, 0xc2, 0x86,
+	0x28, 0x7c, 0xcf, 0x43, 0xcc, 0x57, 0xe9, 0x24, 0xa4, 0x17, 0xa7, 0x34, 0xa0, 0xdd, 0x95, 0x85,
+	0xb5, 0xea, 0x75, 0xde, 0x83, 0x35, 0xfa, 0x92, 0x9e, 0x7c, 0x06, 0x77, 0x78, 0xe5, 0x3d, 0xcb,
+	0xa8, 0xb3, 0x90, 0xd1, 0x1a, 0xeb, 0x90, 0xe6, 0x63, 0xfc, 0xa2, 0xc4, 0xc2, 0x22, 0x40, 0x6d,
+	0xc7, 0xec, 0xf7, 0x86, 0x7d, 0x1e, 0x19, 0x8f, 0x5e, 0xed, 0xf6, 0xe4, 0x95, 0x89, 0x88, 0x92,
+	0x25, 0xd2, 0x82, 0xfa, 0xa0, 0x3f, 0xb4, 0x7a, 0x3b, 0xfb, 0x9d, 0x32, 0x46, 0x3b, 0x3c, 0xca,
+	0x76, 0x2a, 0x8c, 0xfc, 0x80, 0x7d, 0x2b, 0x31, 0x90, 0x6c, 0x00, 0x39, 0x7a, 0x85, 0x77, 0x2d,
+	0xd6, 0xd0, 0xec, 0x1d, 0x0c, 0xf6, 0x7b, 0xc3, 0xbd, 0xc3, 0x83, 0x4e, 0x1d, 0xe1, 0x22, 0x70,
+	0xaa, 0xf0, 0x06, 0x06, 0x54, 0x2e, 0x80, 0x0c, 0xb9, 0xcd, 0x7c, 0x14, 0x06, 0x04, 0xf5, 0x76,
+	0x77, 0xad, 0xe1, 0xa1, 0x04, 0xb5, 0x08, 0x81, 0xe5, 0x97, 0x87, 0xaf, 0xfb, 0xd6, 0xde, 0x81,
+	0x84, 0x2d, 0xe1, 0x20, 0x66, 0x9f, 0x41, 0x3f, 0x33, 0x0f, 0x5f, 0x4a, 0x78, 0x1b, 0x69, 0x7b,
+	0xaf, 0x5e, 0xf5, 0x0f, 0x76, 0xad, 0x9d, 0xc3, 0x83, 0x61, 0xff, 0x60, 0xd8, 0x59, 0x46, 0xd8,
+	0xce, 0xe1, 0xcb, 0x97, 0x7b, 0xc3, 0x18, 0xb6, 0x62, 0x3c, 0x83, 0x95, 0x9e, 0xe3, 0xbc, 0xf6,
+	0x23, 0x1a, 0xc8, 0xd8, 0x9d, 0xe4, 0xa2, 0x4d, 0x96, 0x8b, 0x62, 0x8a, 0xe5, 0x38, 0x01, 0x0d,
+	0x43, 0x11, 0xb5, 0x64, 0xd3, 0x20, 0xd0, 0x49, 0x3a, 0x8b, 0x12, 0xd2, 0x77, 0x60, 0x8d, 0x57,
+	0xea, 0x06, 0x34, 0x38, 0x5f, 0xc8, 0x14, 0x4f, 0xc9, 0x69, 0x32, 0xd1, 0x7d, 0x03, 0xd6, 0x77,
+	0x26, 0xf3, 0x30, 0xa2, 0x41, 0x3a, 0x2c, 0x9f, 0x40, 0x5b, 0xc2, 0x59, 0x87, 0xdb, 0x4b, 0xc9,
+	0xd6, 0x2a, 0x8a, 0x28, 0x82, 0x2a, 0x6f, 0xb0, 0x1a, 0x19, 0x8b, 0x8e, 0xf2, 0x0d, 0x09, 0x6f,
+	0x19, 0xff, 0xa5, 0xc1, 0x9d, 0x8c, 0x04, 0x22, 0xf6, 0x67, 0x47, 0xc4, 0xba, 0x55, 0x84, 0x29,
+	0x38, 0x1f, 0x8f, 0x37, 0xf0, 0x88, 0xa5, 0xc4, 0x79, 0x14, 0x87, 0x3f, 0x6a, 0x68, 0x27, 0xa1,
+	0x1e, 0x85, 0x62, 0xf7, 0x80, 0xc1, 0x54, 0x04, 0x72, 0xf6, 0xcd, 0x42, 0xb8, 0x1d, 0x46, 0x22,
+	0x05, 0x90, 0x21, 0xdc, 0x0e, 0x23, 0x9e, 0x01, 0xbc, 0x07, 0x6d, 0xb9, 0x05, 0x70, 0x0a, 0x1e,
+	0x7b, 0x96, 0x04, 0x90, 0x13, 0x7d, 0x0c, 0xf5, 0x90, 0x29, 0x08, 0x43, 0x37, 0xe6, 0xdf, 0x6b,
+	0x62, 0x17, 0x55, 0x95, 0x67, 0x4a, 0x1a, 0xe3, 0x2f, 0x35, 0xa8, 0x9b, 0xf6, 0x38, 0xda, 0xe7,
+	0xc5, 0x17, 0xce, 0x57, 0x14, 0x99, 0x58, 0x23, 0x16, 0xb4, 0xa4, 0x08, 0x8a, 0xb0, 0xab, 0x19,
+	0xdf, 0x59, 0xdb, 0x26, 0xfb, 0x8e, 0xa3, 0x45, 0x45, 0x89, 0x16, 0x0f, 0x01, 0xe8, 0x65, 0x44,
+	0xbd, 0x90, 0x25, 0xa0, 0x55, 0x86, 0x51, 0x20, 0x62, 0x53, 0xa3, 0x9e, 0x43, 0x1d, 0xcb, 0x8e,
+	0xc4, 0x71, 0x15, 0x24, 0xa8, 0x17, 0x19, 0xff, 0x58, 0x82, 0x2e, 0x8a, 0xd7, 0x63, 0x20, 0xb1,
+	0x55, 0x49, 0x97, 0xfa, 0x2e, 0x74, 0xd8, 0x9b, 0xb8, 0x91, 0x3f, 0xb1, 0xd4, 0x07, 0x4f, 0x65,
+	0x73, 0x45, 0xc2, 0xd5, 0x87, 0x55, 0xd9, 0x49, 0x24, 0x0e, 0xc0, 0x2f, 0x79, 0x44, 0x8b, 0x1d,
+	0xb4, 0x03, 0x7a, 0x6e, 0x4d, 0x7c, 0xbe, 0x5d, 0x5e, 0x09, 0x1b, 0x2d, 0x21, 0x74, 0xdf, 0x3f,
+	0xe1, 0xa9, 0xaa, 0x01, 0xed, 0x98, 0x8a, 0xb1, 0xe6, 0xe6, 0x6a, 0x09, 0xa2, 0x21, 0x8e, 0xf0,
+	0x41, 0x72, 0x30, 0xa8, 0xa9, 0x55, 0x2a, 0xa1, 0xf0, 0xe4, 0x9c, 0xf0, 0x14, 0xd6, 0x84, 0xcf,
+	0x8c, 0xfc, 0xe9, 0xd4, 0x95, 0x1e, 0xc0, 0x33, 0xf6, 0x55, 0x8e, 0xda, 0x61, 0x18, 0x6e, 0x64,
+	0xee, 0x89, 0x0d, 0x26, 0x36, 0x7a, 0x22, 0x81, 0x0a, 0x4b, 0x2a, 0x9a, 0x5c, 0xf7, 0xf8, 0x6d,
+	0xfc, 0xbb, 0x06, 0xf7, 0x0a, 0x54, 0x27, 0x7c, 0xf9, 0x57, 0xd4, 0xdd, 0x3d, 0x68, 0x30, 0x4f,
+	0xc5, 0x4d, 0x97, 0xef, 0xa8, 0x75, 0x6c, 0xa3, 0x17, 0x75, 0xa1, 0x1e, 0xce, 0x47, 0x23, 0x74,
+	0x7c, 0xbe, 0xb0, 0x64, 0x93, 0x3c, 0x81, 0x8e, 0xe7, 0x5b, 0x01, 0x8d, 0x82, 0x2b, 0xeb, 0xd8,
+	0x1e, 0x9d, 0xf9, 0xe3, 0x31, 0xd3, 0x5a, 0xc3, 0x5c, 0xf6, 0x7c, 0x13, 0xc1, 0xcf, 0x39, 0x54,
+	0xcc, 0xaf, 0x96, 0x9b, 0x5f, 0x5d, 0x99, 0xdf, 0x9f, 0x95, 0x60, 0x03, 0xe7, 0x27, 0xbc, 0x01,
+	0x83, 0xd0, 0xb7, 0xe4, 0x18, 0x98, 0x88, 0xd9, 0x9e, 0xe3, 0xc6, 0xc7, 0xeb, 0x25, 0x33, 0x01,
+	0xa0, 0x7b, 0xc8, 0xa9, 0x0b, 0x33, 0x09, 0xf7, 0x10, 0x0a, 0xe0, 0x16, 0x32, 0xa0, 0x1d, 0x53,
+	0xa9, 0xee, 0x21, 0x88, 0x98, 0x7b, 0x7c, 0x22, 0xad, 0x1e, 0x9e, 0xba, 0x33, 0x8b, 0x6d, 0xf8,
+	0x63, 0x71, 0x00, 0x6f, 0x98, 0x24, 0x41, 0x0d, 0x05, 0x46, 0xa8, 0xa5, 0x9e, 0x53, 0x4b, 0x43,
+	0x51, 0xcb, 0x3f, 0x68, 0x70, 0x37, 0xa7, 0x96, 0x6f, 0xc7, 0xe8, 0x78, 0x49, 0x4f, 0xf9, 0x6b,
+	0x3c, 0x76, 0x02, 0x61, 0x0d, 0xb4, 0xf7, 0x09, 0x9e, 0xae, 0xa9, 0x23, 0xed, 0x2d, 0x9a, 0x42,
+	0xdc, 0x6a, 0x4e, 0xdc, 0x9a, 0x22, 0xee, 0xdf, 0x94, 0x41, 0x47, 0x71, 0xf7, 0xbc, 0x30, 0xb2,
+	0x27, 0x93, 0x81, 0x67, 0xcf, 0xc2, 0x53, 0x3f, 0xfa, 0x06, 0x96, 0xfc, 0x2e, 0x74, 0x42, 0xd1,
+	0x3b, 0x26, 0xe5, 0x07, 0xc6, 0x15, 0x09, 0xcf, 0x4e, 0xae, 0x5c, 0x18, 0x0d, 0x2a, 0xd9, 0x68,
+	0x90, 0x31, 0x77, 0xf5, 0x36, 0xe6, 0xae, 0xe5, 0xcd, 0x1d, 0xab, 0xaf, 0xae, 0xaa, 0xef, 0x31,
+	0xb4, 0x47, 0xbe, 0x37, 0x76, 0x4f, 0xe6, 0x41, 0x92, 0x16, 0x2e, 0x99, 0x69, 0x20, 0xba, 0x4a,
+	0x0a, 0x90, 0x3a, 0x25, 0x92, 0x14, 0x6a, 0x4f, 0x46, 0x6d, 0x56, 0x65, 0xe4, 0x95, 0x53, 0xf6,
+	0x1d, 0x47, 0xe8, 0x96, 0x12, 0xa1, 0xb9, 0x8d, 0x96, 0x72, 0x36, 0x6a, 0x2b, 0x36, 0xfa, 0x0b,
+	0x0d, 0x36, 0x0b, 0x6d, 0xf4, 0xed, 0xb8, 0x95, 0x12, 0x30, 0xca, 0xe9, 0x80, 0xc1, 0x85, 0xab,
+	0xe4, 0x84, 0xab, 0x2a, 0xc2, 0x8d, 0xe1, 0x0e, 0xca, 0x86, 0xc7, 0x3a, 0x7f, 0x1e, 0x1d, 0xf8,
+	0x17, 0xdf, 0xc0, 0x75, 0xf8, 0x38, 0xa5, 0xdc, 0x38, 0x65, 0x65, 0x9c, 0x13, 0xd8, 0xc8, 0x8e,
+	0xf3, 0xf6, 0xd3, 0xbf, 0xc5, 0x40, 0xdb, 0xff, 0xda, 0xe6, 0x27, 0x20, 0xdc, 0xa9, 0xdd, 0x11,
+	0x25, 0x3d, 0x80, 0xe4, 0x79, 0x2a, 0xb9, 0x2b, 0x76, 0xf3, 0xec, 0x2b, 0x57, 0xbd, 0x9b, 0x47,
+	0x88, 0x8c, 0xea, 0x1d, 0xf2, 0x0c, 0x1a, 0xf2, 0x05, 0x28, 0xb9, 0x23, 0x8f, 0xac, 0xa9, 0x57,
+	0xa9, 0xfa, 0x46, 0x16, 0x1c, 0x77, 0xee, 0x01, 0x24, 0x4f, 0x09, 0xe5, 0xf8, 0xb9, 0x17, 0x89,
+	0x7a, 0x37, 0x8f, 0x50, 0x59, 0x24, 0xd7, 0x8e, 0x92, 0x45, 0xee, 0x11, 0xa1, 0xde, 0xcd, 0x23,
+	0x62, 0x16, 0xbf, 0x0d, 0x0d, 0xf9, 0xac, 0x4f, 0x4e, 0x21, 0xf3, 0x50, 0x50, 0xdf, 0xc8, 0x82,
+	0x65, 0xe7, 0xef, 0x69, 0x28, 0x41, 0xf2, 0x66, 0x4f, 0x4a, 0x90, 0x7b, 0xf3, 0xa7, 0x77, 0xf3,
+	0x08, 0x75, 0x12, 0x83, 0x1c, 0x8b, 0xc1, 0x22, 0x16, 0x83, 0x22, 0x16, 0xcf, 0x70, 0x12, 0x67,
+	0x34, 0x3d, 0x89, 0x33, 0x5a, 0x38, 0x89, 0xb3, 0x02, 0x25, 0x26, 0x17, 0x83, 0xb1, 0x1d, 0xb2,
+	0x37, 0x8e, 0x7a, 0x37, 0x8f, 0x50, 0xc7, 0x97, 0xf7, 0x68, 0x72, 0xfc, 0xcc, 0xbd, 0xa0, 0xbe,
+	0x91, 0x05, 0xc7, 0x9d, 0x3f, 0x87, 0x25, 0xf5, 0x3e, 0x96, 0xdc, 0xcb, 0x5d, 0xbc, 0xc6, 0x4c,
+	0xf4, 0x22, 0x54, 0xcc, 0xe8, 0x18, 0xee, 0x14, 0x5e, 0x8b, 0x13, 0x43, 0xba, 0xd0, 0xe2, 0x5b,
+	0x7a, 0xfd, 0xbd, 0x6b, 0x69, 0xd4, 0x31, 0x0a, 0x2f, 0xba, 0xe5, 0x18, 0xd7, 0x5d, 0xab, 0xeb,
+	0xef, 0x5d, 0x4b, 0xa3, 0x2a, 0x44, 0x7d, 0x61, 0x23, 0x15, 0x52, 0xf0, 0x64, 0x47, 0xd7, 0x8b,
+	0x50, 0x31, 0xa3, 0xdf, 0x81, 0x66, 0xfc, 0x42, 0x86, 0x6c, 0xc4, 0x2e, 0x98, 0x66, 0x71, 0x37,
+	0x07, 0x57, 0x05, 0x51, 0x1f, 0xb4, 0x48, 0x41, 0x0a, 0x1e, 0xc6, 0xe8, 0x7a, 0x11, 0x2a, 0x66,
+	0xb4, 0x0b, 0x2d, 0xe5, 0x65, 0x0a, 0x11, 0xae, 0x94, 0x7f, 0x14, 0xa3, 0xdf, 0x2b, 0xc0, 0xa8,
+	0xe2, 0xa8, 0x2f, 0x47, 0xa4, 0x38, 0x05, 0xcf, 0x56, 0x74, 0xbd, 0x08, 0x15, 0x33, 0x1a, 0x40,
+	0x87, 0x1f, 0x11, 0x93, 0x37, 0x1f, 0xe4, 0x81, 0x74, 0xad, 0xc2, 0xc7, 0x25, 0xfa, 0xc3, 0x45,
+	0xe8, 0x98, 0xe9, 0xef, 0xc1, 0x6a, 0xee, 0x41, 0x1a, 0x79, 0x28, 0x4b, 0x08, 0xc5, 0xcf, 0xde,
+	0xf4, 0x47, 0x0b, 0xf1, 0x4a, 0x8c, 0x39, 0x82, 0x4e, 0xf6, 0x09, 0x98, 0x14, 0x77, 0xc1, 0xb3,
+	0x34, 0xfd, 0xe1, 0x22, 0xb4, 0x64, 0xfb, 0x44, 0xc3, 0x45, 0x2b, 0x9f, 0x08, 0xca, 0x45, 0x9b,
+	0x79, 0x63, 0xa8, 0x6f, 0x64, 0xc1, 0xea, 0x8a, 0x97, 0x4f, 0x3f, 0x65, 0xe7, 0xcc, 0x7b, 0x58,
+	0x7d, 0x23, 0x0b, 0x8e, 0x3b, 0x7f, 0x06, 0xad, 0xe4, 0x75, 0x6f, 0x28, 0x43, 0x4e, 0xee, 0xf9,
+	0xb0, 0xde, 0xcd, 0x23, 0x12, 0xf9, 0xbf, 0xa7, 0x6d, 0xff, 0x4f, 0x19, 0x3a, 0x2c, 0x18, 0x3a,
+	0x53, 0xd7, 0x93, 0xdb, 0xda, 0x2b, 0x58, 0xc9, 0x5c, 0xc0, 0x93, 0xfb, 0xc9, 0x25, 0x60, 0xfe,
+	0xc6, 0x5e, 0x7f, 0xb0, 0x00, 0x1b, 0x8b, 0xfb, 0xbb, 0xd0, 0x4e, 0x5d, 0xd8, 0x12, 0xe9, 0xec,
+	0x05, 0x97, 0xf1, 0xfa, 0x66, 0x21, 0x4e, 0x0d, 0xb6, 0xc9, 0x45, 0x4f, 0xbc, 0xe9, 0x66, 0x2f,
+	0x84, 0xf4, 0x6e, 0x1e, 0x11, 0xb3, 0x78, 0x09, 0xcb, 0xe9, 0x0b, 0x32, 0xb2, 0x99, 0x6c, 0x50,
+	0xb9, 0xcb, 0x2e, 0xfd, 0x7e, 0x31, 0x32, 0x66, 0xf7, 0x1a, 0x56, 0x73, 0xb7, 0x59, 0x24, 0x76,
+	0xf7, 0xe2, 0x1b, 0x34, 0xfd, 0xd1, 0x42, 0x7c, 0xcc, 0xf7, 0x0f, 0x60, 0xad, 0xe0, 0x9a, 0x87,
+	0x6c, 0x89, 0x9e, 0x0b, 0xef, 0x87, 0xf4, 0x77, 0xaf, 0xa1, 0x90, 0xdc, 0xb7, 0xff, 0x49, 0x03,
+	0xa2, 0x56, 0xc7, 0x85, 0xf1, 0xf7, 0xa1, 0x9d, 0xba, 0x01, 0x90, 0xa6, 0x2a, 0xba, 0x4a, 0xd0,
+	0x37, 0x0b, 0x71, 0xca, 0xc2, 0x63, 0xaa, 0xc9, 0x54, 0xe0, 0x13, 0xd5, 0x14, 0x97, 0xf9, 0xf5,
+	0x47, 0x0b, 0xf1, 0xb1, 0xf0, 0xff, 0xa1, 0xc1, 0xb2, 0x52, 0x36, 0x41, 0xc1, 0x9f, 0x41, 0x43,
+	0x16, 0xbc, 0xe4, 0x7a, 0xca, 0x54, 0xcf, 0xf4, 0x8d, 0x2c, 0x38, 0xbd, 0x83, 0x26, 0x25, 0xaf,
+	0x64, 0x07, 0xcd, 0x55, 0xcb, 0x74, 0xbd, 0x08, 0xa5, 0x7a, 0x7a, 0xaa, 0x42, 0x25, 0xd5, 0x57,
+	0x54, 0x38, 0xd3, 0x37, 0x0b, 0x71, 0xf1, 0x24, 0xff, 0xad, 0x04, 0x6d, 0x96, 0xd8, 0xe2, 0x1e,
+	0x87, 0x0b, 0x98, 0x98, 0xd0, 0x4e, 0xd5, 0x0c, 0x62, 0x55, 0x2e, 0xa8, 0xc3, 0xe8, 0x8f, 0x16,
+	0xe2, 0x63, 0x89, 0xf7, 0xa1, 0x25, 0xa8, 0x51, 0x29, 0xe4, 0x7e, 0xd2, 0x23, 0x7f, 0x7c, 0xd7,
+	0x1f, 0x2c, 0xc0, 0xc6, 0xdc, 0x7e, 0x06, 0x2b, 0x99, 0xb3, 0x48, 0xec, 0xaf, 0x0b, 0x8f, 0x92,
+	0xfa, 0xbb, 0xd7, 0x50, 0x28, 0xe1, 0x76, 0x0f, 0x20, 0xc9, 0xf1, 0xc9, 0x66, 0xd2, 0x29, 0x77,
+	0xc2, 0xd0, 0xef, 0x17, 0x23, 0x25, 0xb3, 0xe7, 0x8d, 0x9f, 0xd5, 0xf8, 0xef, 0x37, 0x8f, 0x6b,
+	0xec, 0x20, 0xf0, 0xfd, 0xff, 0x1b, 0x00, 0xca, 0xeb, 0xad, 0x68, 0xd5, 0x39, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BlogServiceClient is the client API for BlogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlogServiceClient interface {
+	CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error)
+	ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error)
+	UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
+	DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error)
+	ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error)
+	GetBlogAcl(ctx context.Context, in *GetBlogAclRequest, opts ...grpc.CallOption) (*GetBlogAclResponse, error)
+	SetBlogAcl(ctx context.Context, in *SetBlogAclRequest, opts ...grpc.CallOption) (*SetBlogAclResponse, error)
+	LikeBlog(ctx context.Context, in *LikeBlogRequest, opts ...grpc.CallOption) (*LikeBlogResponse, error)
+	UnlikeBlog(ctx context.Context, in *UnlikeBlogRequest, opts ...grpc.CallOption) (*UnlikeBlogResponse, error)
+	TopBlogs(ctx context.Context, in *TopBlogsRequest, opts ...grpc.CallOption) (*TopBlogsResponse, error)
+	RelatedBlogs(ctx context.Context, in *RelatedBlogsRequest, opts ...grpc.CallOption) (*RelatedBlogsResponse, error)
+	UpsertBlogTranslation(ctx context.Context, in *UpsertBlogTranslationRequest, opts ...grpc.CallOption) (*UpsertBlogTranslationResponse, error)
+	DeleteBlogTranslation(ctx context.Context, in *DeleteBlogTranslationRequest, opts ...grpc.CallOption) (*DeleteBlogTranslationResponse, error)
+	CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*CreateSeriesResponse, error)
+	GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*GetSeriesResponse, error)
+	DeleteSeries(ctx context.Context, in *DeleteSeriesRequest, opts ...grpc.CallOption) (*DeleteSeriesResponse, error)
+	// adding a blog to a series needs the right to edit the blog
+	AddToSeries(ctx context.Context, in *AddToSeriesRequest, opts ...grpc.CallOption) (*AddToSeriesResponse, error)
+	MoveInSeries(ctx context.Context, in *MoveInSeriesRequest, opts ...grpc.CallOption) (*MoveInSeriesResponse, error)
+	RemoveFromSeries(ctx context.Context, in *RemoveFromSeriesRequest, opts ...grpc.CallOption) (*RemoveFromSeriesResponse, error)
+	// sends the whole content of a blog in pieces, from an offset
+	StreamBlogContent(ctx context.Context, in *StreamBlogContentRequest, opts ...grpc.CallOption) (BlogService_StreamBlogContentClient, error)
+	// uploads the content of a blog in pieces, owner and editors only. An
+	// interrupted upload resumes from the offset it reached.
+	WriteBlogContent(ctx context.Context, opts ...grpc.CallOption) (BlogService_WriteBlogContentClient, error)
+	// storage used by an author and by every blog, with the configured quota
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+	// line-level diff of the content of a blog between two moments
+	DiffBlog(ctx context.Context, in *DiffBlogRequest, opts ...grpc.CallOption) (*DiffBlogResponse, error)
+	// creates the blogs sent one after the other, like CreateBlog, and
+	// answers each one in turn. A blog that fails doesn't end the stream.
+	ImportBlogs(ctx context.Context, opts ...grpc.CallOption) (BlogService_ImportBlogsClient, error)
+}
+
+type blogServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBlogServiceClient(cc *grpc.ClientConn) BlogServiceClient {
+	return &blogServiceClient{cc}
+}
+
+func (c *blogServiceClient) CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error) {
+	out := new(CreateBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/CreateBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error) {
+	out := new(ReadBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ReadBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UpdateBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error) {
+	out := new(DeleteBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (BlogService_ListBlogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[0], "/blog.BlogService/ListBlog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceListBlogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_ListBlogClient interface {
+	Recv() (*ListBlogResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceListBlogClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceListBlogClient) Recv() (*ListBlogResponse, error) {
+	m := new(ListBlogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) GetBlogAcl(ctx context.Context, in *GetBlogAclRequest, opts ...grpc.CallOption) (*GetBlogAclResponse, error) {
+	out := new(GetBlogAclResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/GetBlogAcl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) SetBlogAcl(ctx context.Context, in *SetBlogAclRequest, opts ...grpc.CallOption) (*SetBlogAclResponse, error) {
+	out := new(SetBlogAclResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/SetBlogAcl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) LikeBlog(ctx context.Context, in *LikeBlogRequest, opts ...grpc.CallOption) (*LikeBlogResponse, error) {
+	out := new(LikeBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/LikeBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UnlikeBlog(ctx context.Context, in *UnlikeBlogRequest, opts ...grpc.CallOption) (*UnlikeBlogResponse, error) {
+	out := new(UnlikeBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UnlikeBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) TopBlogs(ctx context.Context, in *TopBlogsRequest, opts ...grpc.CallOption) (*TopBlogsResponse, error) {
+	out := new(TopBlogsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/TopBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RelatedBlogs(ctx context.Context, in *RelatedBlogsRequest, opts ...grpc.CallOption) (*RelatedBlogsResponse, error) {
+	out := new(RelatedBlogsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/RelatedBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UpsertBlogTranslation(ctx context.Context, in *UpsertBlogTranslationRequest, opts ...grpc.CallOption) (*UpsertBlogTranslationResponse, error) {
+	out := new(UpsertBlogTranslationResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UpsertBlogTranslation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DeleteBlogTranslation(ctx context.Context, in *DeleteBlogTranslationRequest, opts ...grpc.CallOption) (*DeleteBlogTranslationResponse, error) {
+	out := new(DeleteBlogTranslationResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteBlogTranslation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*CreateSeriesResponse, error) {
+	out := new(CreateSeriesResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/CreateSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*GetSeriesResponse, error) {
+	out := new(GetSeriesResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/GetSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DeleteSeries(ctx context.Context, in *DeleteSeriesRequest, opts ...grpc.CallOption) (*DeleteSeriesResponse, error) {
+	out := new(DeleteSeriesResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) AddToSeries(ctx context.Context, in *AddToSeriesRequest, opts ...grpc.CallOption) (*AddToSeriesResponse, error) {
+	out := new(AddToSeriesResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/AddToSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) MoveInSeries(ctx context.Context, in *MoveInSeriesRequest, opts ...grpc.CallOption) (*MoveInSeriesResponse, error) {
+	out := new(MoveInSeriesResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/MoveInSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RemoveFromSeries(ctx context.Context, in *RemoveFromSeriesRequest, opts ...grpc.CallOption) (*RemoveFromSeriesResponse, error) {
+	out := new(RemoveFromSeriesResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/RemoveFromSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) StreamBlogContent(ctx context.Context, in *StreamBlogContentRequest, opts ...grpc.CallOption) (BlogService_StreamBlogContentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[1], "/blog.BlogService/StreamBlogContent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceStreamBlogContentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_StreamBlogContentClient interface {
+	Recv() (*StreamBlogContentResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceStreamBlogContentClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceStreamBlogContentClient) Recv() (*StreamBlogContentResponse, error) {
+	m := new(StreamBlogContentResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) WriteBlogContent(ctx context.Context, opts ...grpc.CallOption) (BlogService_WriteBlogContentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[2], "/blog.BlogService/WriteBlogContent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceWriteBlogContentClient{stream}
+	return x, nil
+}
+
+type BlogService_WriteBlogContentClient interface {
+	Send(*WriteBlogContentRequest) error
+	CloseAndRecv() (*WriteBlogContentResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceWriteBlogContentClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceWriteBlogContentClient) Send(m *WriteBlogContentRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *blogServiceWriteBlogContentClient) CloseAndRecv() (*WriteBlogContentResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteBlogContentResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/GetUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DiffBlog(ctx context.Context, in *DiffBlogRequest, opts ...grpc.CallOption) (*DiffBlogResponse, error) {
+	out := new(DiffBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DiffBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ImportBlogs(ctx context.Context, opts ...grpc.CallOption) (BlogService_ImportBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[3], "/blog.BlogService/ImportBlogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceImportBlogsClient{stream}
+	return x, nil
+}
+
+type BlogService_ImportBlogsClient interface {
+	Send(*ImportBlogRequest) error
+	Recv() (*ImportBlogResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceImportBlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceImportBlogsClient) Send(m *ImportBlogRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *blogServiceImportBlogsClient) Recv() (*ImportBlogResponse, error) {
+	m := new(ImportBlogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlogServiceServer is the server API for BlogService service.
+type BlogServiceServer interface {
+	CreateBlog(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error)
+	ReadBlog(context.Context, *ReadBlogRequest) (*ReadBlogResponse, error)
+	UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
+	DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
+	ListBlog(*ListBlogRequest, BlogService_ListBlogServer) error
+	GetBlogAcl(context.Context, *GetBlogAclRequest) (*GetBlogAclResponse, error)
+	SetBlogAcl(context.Context, *SetBlogAclRequest) (*SetBlogAclResponse, error)
+	LikeBlog(context.Context, *LikeBlogRequest) (*LikeBlogResponse, error)
+	UnlikeBlog(context.Context, *UnlikeBlogRequest) (*UnlikeBlogResponse, error)
+	TopBlogs(context.Context, *TopBlogsRequest) (*TopBlogsResponse, error)
+	RelatedBlogs(context.Context, *RelatedBlogsRequest) (*RelatedBlogsResponse, error)
+	UpsertBlogTranslation(context.Context, *UpsertBlogTranslationRequest) (*UpsertBlogTranslationResponse, error)
+	DeleteBlogTranslation(context.Context, *DeleteBlogTranslationRequest) (*DeleteBlogTranslationResponse, error)
+	CreateSeries(context.Context, *CreateSeriesRequest) (*CreateSeriesResponse, error)
+	GetSeries(context.Context, *GetSeriesRequest) (*GetSeriesResponse, error)
+	DeleteSeries(context.Context, *DeleteSeriesRequest) (*DeleteSeriesResponse, error)
+	// adding a blog to a series needs the right to edit the blog
+	AddToSeries(context.Context, *AddToSeriesRequest) (*AddToSeriesResponse, error)
+	MoveInSeries(context.Context, *MoveInSeriesRequest) (*MoveInSeriesResponse, error)
+	RemoveFromSeries(context.Context, *RemoveFromSeriesRequest) (*RemoveFromSeriesResponse, error)
+	// sends the whole content of a blog in pieces, from an offset
+	StreamBlogContent(*StreamBlogContentRequest, BlogService_StreamBlogContentServer) error
+	// uploads the content of a blog in pieces, owner and editors only. An
+	// interrupted upload resumes from the offset it reached.
+	WriteBlogContent(BlogService_WriteBlogContentServer) error
+	// storage used by an author and by every blog, with the configured quota
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	// line-level diff of the content of a blog between two moments
+	DiffBlog(context.Context, *DiffBlogRequest) (*DiffBlogResponse, error)
+	// creates the blogs sent one after the other, like CreateBlog, and
+	// answers each one in turn. A blog that fails doesn't end the stream.
+	ImportBlogs(BlogService_ImportBlogsServer) error
+}
+
+// UnimplementedBlogServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedBlogServiceServer struct {
+}
+
+func (*UnimplementedBlogServiceServer) CreateBlog(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) ReadBlog(ctx context.Context, req *ReadBlogRequest) (*ReadBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) UpdateBlog(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBlog not implemented")
 }
 func (*UnimplementedBlogServiceServer) DeleteBlog(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBlog not implemented")
 }
-func (*UnimplementedBlogServiceServer) ListBlog(req *ListBlogRequest, srv BlogService_ListBlogServer) error {
-	return status.Errorf(codes.Unimplemented, "method ListBlog not implemented")
+func (*UnimplementedBlogServiceServer) ListBlog(req *ListBlogRequest, srv BlogService_ListBlogServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) GetBlogAcl(ctx context.Context, req *GetBlogAclRequest) (*GetBlogAclResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlogAcl not implemented")
+}
+func (*UnimplementedBlogServiceServer) SetBlogAcl(ctx context.Context, req *SetBlogAclRequest) (*SetBlogAclResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBlogAcl not implemented")
+}
+func (*UnimplementedBlogServiceServer) LikeBlog(ctx context.Context, req *LikeBlogRequest) (*LikeBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LikeBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) UnlikeBlog(ctx context.Context, req *UnlikeBlogRequest) (*UnlikeBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlikeBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) TopBlogs(ctx context.Context, req *TopBlogsRequest) (*TopBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopBlogs not implemented")
+}
+func (*UnimplementedBlogServiceServer) RelatedBlogs(ctx context.Context, req *RelatedBlogsRequest) (*RelatedBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelatedBlogs not implemented")
+}
+func (*UnimplementedBlogServiceServer) UpsertBlogTranslation(ctx context.Context, req *UpsertBlogTranslationRequest) (*UpsertBlogTranslationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertBlogTranslation not implemented")
+}
+func (*UnimplementedBlogServiceServer) DeleteBlogTranslation(ctx context.Context, req *DeleteBlogTranslationRequest) (*DeleteBlogTranslationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBlogTranslation not implemented")
+}
+func (*UnimplementedBlogServiceServer) CreateSeries(ctx context.Context, req *CreateSeriesRequest) (*CreateSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeries not implemented")
+}
+func (*UnimplementedBlogServiceServer) GetSeries(ctx context.Context, req *GetSeriesRequest) (*GetSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeries not implemented")
+}
+func (*UnimplementedBlogServiceServer) DeleteSeries(ctx context.Context, req *DeleteSeriesRequest) (*DeleteSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSeries not implemented")
+}
+func (*UnimplementedBlogServiceServer) AddToSeries(ctx context.Context, req *AddToSeriesRequest) (*AddToSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToSeries not implemented")
+}
+func (*UnimplementedBlogServiceServer) MoveInSeries(ctx context.Context, req *MoveInSeriesRequest) (*MoveInSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveInSeries not implemented")
+}
+func (*UnimplementedBlogServiceServer) RemoveFromSeries(ctx context.Context, req *RemoveFromSeriesRequest) (*RemoveFromSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromSeries not implemented")
+}
+func (*UnimplementedBlogServiceServer) StreamBlogContent(req *StreamBlogContentRequest, srv BlogService_StreamBlogContentServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBlogContent not implemented")
+}
+func (*UnimplementedBlogServiceServer) WriteBlogContent(srv BlogService_WriteBlogContentServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteBlogContent not implemented")
+}
+func (*UnimplementedBlogServiceServer) GetUsage(ctx context.Context, req *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (*UnimplementedBlogServiceServer) DiffBlog(ctx context.Context, req *DiffBlogRequest) (*DiffBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffBlog not implemented")
+}
+func (*UnimplementedBlogServiceServer) ImportBlogs(srv BlogService_ImportBlogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportBlogs not implemented")
+}
+
+func RegisterBlogServiceServer(s *grpc.Server, srv BlogServiceServer) {
+	s.RegisterService(&_BlogService_serviceDesc, srv)
+}
+
+func _BlogService_CreateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).CreateBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/CreateBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).CreateBlog(ctx, req.(*CreateBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ReadBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ReadBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ReadBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ReadBlog(ctx, req.(*ReadBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UpdateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UpdateBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/UpdateBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UpdateBlog(ctx, req.(*UpdateBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DeleteBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DeleteBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/DeleteBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DeleteBlog(ctx, req.(*DeleteBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListBlog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBlogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).ListBlog(m, &blogServiceListBlogServer{stream})
+}
+
+type BlogService_ListBlogServer interface {
+	Send(*ListBlogResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceListBlogServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceListBlogServer) Send(m *ListBlogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_GetBlogAcl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlogAclRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetBlogAcl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/GetBlogAcl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetBlogAcl(ctx, req.(*GetBlogAclRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_SetBlogAcl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBlogAclRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).SetBlogAcl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/SetBlogAcl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).SetBlogAcl(ctx, req.(*SetBlogAclRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_LikeBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).LikeBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/LikeBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).LikeBlog(ctx, req.(*LikeBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UnlikeBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlikeBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UnlikeBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/UnlikeBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UnlikeBlog(ctx, req.(*UnlikeBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_TopBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).TopBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/TopBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).TopBlogs(ctx, req.(*TopBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RelatedBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelatedBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RelatedBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/RelatedBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RelatedBlogs(ctx, req.(*RelatedBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UpsertBlogTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertBlogTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UpsertBlogTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/UpsertBlogTranslation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UpsertBlogTranslation(ctx, req.(*UpsertBlogTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DeleteBlogTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBlogTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DeleteBlogTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/DeleteBlogTranslation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DeleteBlogTranslation(ctx, req.(*DeleteBlogTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_CreateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).CreateSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/CreateSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).CreateSeries(ctx, req.(*CreateSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_GetSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/GetSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetSeries(ctx, req.(*GetSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DeleteSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DeleteSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/DeleteSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DeleteSeries(ctx, req.(*DeleteSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_AddToSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).AddToSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/AddToSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).AddToSeries(ctx, req.(*AddToSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_MoveInSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveInSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).MoveInSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/MoveInSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).MoveInSeries(ctx, req.(*MoveInSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RemoveFromSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RemoveFromSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/RemoveFromSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RemoveFromSeries(ctx, req.(*RemoveFromSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_StreamBlogContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBlogContentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).StreamBlogContent(m, &blogServiceStreamBlogContentServer{stream})
+}
+
+type BlogService_StreamBlogContentServer interface {
+	Send(*StreamBlogContentResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceStreamBlogContentServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceStreamBlogContentServer) Send(m *StreamBlogContentResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_WriteBlogContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlogServiceServer).WriteBlogContent(&blogServiceWriteBlogContentServer{stream})
+}
+
+type BlogService_WriteBlogContentServer interface {
+	SendAndClose(*WriteBlogContentResponse) error
+	Recv() (*WriteBlogContentRequest, error)
+	grpc.ServerStream
+}
+
+type blogServiceWriteBlogContentServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceWriteBlogContentServer) SendAndClose(m *WriteBlogContentResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *blogServiceWriteBlogContentServer) Recv() (*WriteBlogContentRequest, error) {
+	m := new(WriteBlogContentRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BlogService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/GetUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DiffBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DiffBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/DiffBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DiffBlog(ctx, req.(*DiffBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ImportBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlogServiceServer).ImportBlogs(&blogServiceImportBlogsServer{stream})
+}
+
+type BlogService_ImportBlogsServer interface {
+	Send(*ImportBlogResponse) error
+	Recv() (*ImportBlogRequest, error)
+	grpc.ServerStream
+}
+
+type blogServiceImportBlogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceImportBlogsServer) Send(m *ImportBlogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *blogServiceImportBlogsServer) Recv() (*ImportBlogRequest, error) {
+	m := new(ImportBlogRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _BlogService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.BlogService",
+	HandlerType: (*BlogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBlog",
+			Handler:    _BlogService_CreateBlog_Handler,
+		},
+		{
+			MethodName: "ReadBlog",
+			Handler:    _BlogService_ReadBlog_Handler,
+		},
+		{
+			MethodName: "UpdateBlog",
+			Handler:    _BlogService_UpdateBlog_Handler,
+		},
+		{
+			MethodName: "DeleteBlog",
+			Handler:    _BlogService_DeleteBlog_Handler,
+		},
+		{
+			MethodName: "GetBlogAcl",
+			Handler:    _BlogService_GetBlogAcl_Handler,
+		},
+		{
+			MethodName: "SetBlogAcl",
+			Handler:    _BlogService_SetBlogAcl_Handler,
+		},
+		{
+			MethodName: "LikeBlog",
+			Handler:    _BlogService_LikeBlog_Handler,
+		},
+		{
+			MethodName: "UnlikeBlog",
+			Handler:    _BlogService_UnlikeBlog_Handler,
+		},
+		{
+			MethodName: "TopBlogs",
+			Handler:    _BlogService_TopBlogs_Handler,
+		},
+		{
+			MethodName: "RelatedBlogs",
+			Handler:    _BlogService_RelatedBlogs_Handler,
+		},
+		{
+			MethodName: "UpsertBlogTranslation",
+			Handler:    _BlogService_UpsertBlogTranslation_Handler,
+		},
+		{
+			MethodName: "DeleteBlogTranslation",
+			Handler:    _BlogService_DeleteBlogTranslation_Handler,
+		},
+		{
+			MethodName: "CreateSeries",
+			Handler:    _BlogService_CreateSeries_Handler,
+		},
+		{
+			MethodName: "GetSeries",
+			Handler:    _BlogService_GetSeries_Handler,
+		},
+		{
+			MethodName: "DeleteSeries",
+			Handler:    _BlogService_DeleteSeries_Handler,
+		},
+		{
+			MethodName: "AddToSeries",
+			Handler:    _BlogService_AddToSeries_Handler,
+		},
+		{
+			MethodName: "MoveInSeries",
+			Handler:    _BlogService_MoveInSeries_Handler,
+		},
+		{
+			MethodName: "RemoveFromSeries",
+			Handler:    _BlogService_RemoveFromSeries_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _BlogService_GetUsage_Handler,
+		},
+		{
+			MethodName: "DiffBlog",
+			Handler:    _BlogService_DiffBlog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListBlog",
+			Handler:       _BlogService_ListBlog_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamBlogContent",
+			Handler:       _BlogService_StreamBlogContent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteBlogContent",
+			Handler:       _BlogService_WriteBlogContent_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportBlogs",
+			Handler:       _BlogService_ImportBlogs_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "blog/blogpb/blog.proto",
+}
+
+// BlogAdminServiceClient is the client API for BlogAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlogAdminServiceClient interface {
+	// Copies the live data into a fresh file and swaps it in, giving back the
+	// pages freed by deletes. Other requests wait while it runs.
+	CompactDatabase(ctx context.Context, in *CompactDatabaseRequest, opts ...grpc.CallOption) (*CompactDatabaseResponse, error)
+	DatabaseStats(ctx context.Context, in *DatabaseStatsRequest, opts ...grpc.CallOption) (*DatabaseStatsResponse, error)
+	// Counters of the ReadBlog cache
+	CacheStats(ctx context.Context, in *CacheStatsRequest, opts ...grpc.CallOption) (*CacheStatsResponse, error)
+	// Writes held for review by moderation
+	ListQuarantine(ctx context.Context, in *ListQuarantineRequest, opts ...grpc.CallOption) (*ListQuarantineResponse, error)
+	ResolveQuarantine(ctx context.Context, in *ResolveQuarantineRequest, opts ...grpc.CallOption) (*ResolveQuarantineResponse, error)
+	// Switches every database file to a new data key and re-encrypts the
+	// stored values with it, a batch at a time while requests keep running
+	RotateEncryptionKey(ctx context.Context, in *RotateEncryptionKeyRequest, opts ...grpc.CallOption) (*RotateEncryptionKeyResponse, error)
+}
+
+type blogAdminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBlogAdminServiceClient(cc *grpc.ClientConn) BlogAdminServiceClient {
+	return &blogAdminServiceClient{cc}
+}
+
+func (c *blogAdminServiceClient) CompactDatabase(ctx context.Context, in *CompactDatabaseRequest, opts ...grpc.CallOption) (*CompactDatabaseResponse, error) {
+	out := new(CompactDatabaseResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogAdminService/CompactDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogAdminServiceClient) DatabaseStats(ctx context.Context, in *DatabaseStatsRequest, opts ...grpc.CallOption) (*DatabaseStatsResponse, error) {
+	out := new(DatabaseStatsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogAdminService/DatabaseStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogAdminServiceClient) CacheStats(ctx context.Context, in *CacheStatsRequest, opts ...grpc.CallOption) (*CacheStatsResponse, error) {
+	out := new(CacheStatsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogAdminService/CacheStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogAdminServiceClient) ListQuarantine(ctx context.Context, in *ListQuarantineRequest, opts ...grpc.CallOption) (*ListQuarantineResponse, error) {
+	out := new(ListQuarantineResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogAdminService/ListQuarantine", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogAdminServiceClient) ResolveQuarantine(ctx context.Context, in *ResolveQuarantineRequest, opts ...grpc.CallOption) (*ResolveQuarantineResponse, error) {
+	out := new(ResolveQuarantineResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogAdminService/ResolveQuarantine", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogAdminServiceClient) RotateEncryptionKey(ctx context.Context, in *RotateEncryptionKeyRequest, opts ...grpc.CallOption) (*RotateEncryptionKeyResponse, error) {
+	out := new(RotateEncryptionKeyResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogAdminService/RotateEncryptionKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlogAdminServiceServer is the server API for BlogAdminService service.
+type BlogAdminServiceServer interface {
+	// Copies the live data into a fresh file and swaps it in, giving back the
+	// pages freed by deletes. Other requests wait while it runs.
+	CompactDatabase(context.Context, *CompactDatabaseRequest) (*CompactDatabaseResponse, error)
+	DatabaseStats(context.Context, *DatabaseStatsRequest) (*DatabaseStatsResponse, error)
+	// Counters of the ReadBlog cache
+	CacheStats(context.Context, *CacheStatsRequest) (*CacheStatsResponse, error)
+	// Writes held for review by moderation
+	ListQuarantine(context.Context, *ListQuarantineRequest) (*ListQuarantineResponse, error)
+	ResolveQuarantine(context.Context, *ResolveQuarantineRequest) (*ResolveQuarantineResponse, error)
+	// Switches every database file to a new data key and re-encrypts the
+	// stored values with it, a batch at a time while requests keep running
+	RotateEncryptionKey(context.Context, *RotateEncryptionKeyRequest) (*RotateEncryptionKeyResponse, error)
+}
+
+// UnimplementedBlogAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedBlogAdminServiceServer struct {
+}
+
+func (*UnimplementedBlogAdminServiceServer) CompactDatabase(ctx context.Context, req *CompactDatabaseRequest) (*CompactDatabaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompactDatabase not implemented")
+}
+func (*UnimplementedBlogAdminServiceServer) DatabaseStats(ctx context.Context, req *DatabaseStatsRequest) (*DatabaseStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DatabaseStats not implemented")
+}
+func (*UnimplementedBlogAdminServiceServer) CacheStats(ctx context.Context, req *CacheStatsRequest) (*CacheStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CacheStats not implemented")
+}
+func (*UnimplementedBlogAdminServiceServer) ListQuarantine(ctx context.Context, req *ListQuarantineRequest) (*ListQuarantineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQuarantine not implemented")
+}
+func (*UnimplementedBlogAdminServiceServer) ResolveQuarantine(ctx context.Context, req *ResolveQuarantineRequest) (*ResolveQuarantineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveQuarantine not implemented")
+}
+func (*UnimplementedBlogAdminServiceServer) RotateEncryptionKey(ctx context.Context, req *RotateEncryptionKeyRequest) (*RotateEncryptionKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateEncryptionKey not implemented")
+}
+
+func RegisterBlogAdminServiceServer(s *grpc.Server, srv BlogAdminServiceServer) {
+	s.RegisterService(&_BlogAdminService_serviceDesc, srv)
+}
+
+func _BlogAdminService_CompactDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactDatabaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogAdminServiceServer).CompactDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogAdminService/CompactDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogAdminServiceServer).CompactDatabase(ctx, req.(*CompactDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogAdminService_DatabaseStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DatabaseStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogAdminServiceServer).DatabaseStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogAdminService/DatabaseStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogAdminServiceServer).DatabaseStats(ctx, req.(*DatabaseStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogAdminService_CacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogAdminServiceServer).CacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogAdminService/CacheStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogAdminServiceServer).CacheStats(ctx, req.(*CacheStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogAdminService_ListQuarantine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuarantineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogAdminServiceServer).ListQuarantine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogAdminService/ListQuarantine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogAdminServiceServer).ListQuarantine(ctx, req.(*ListQuarantineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogAdminService_ResolveQuarantine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveQuarantineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogAdminServiceServer).ResolveQuarantine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogAdminService/ResolveQuarantine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogAdminServiceServer).ResolveQuarantine(ctx, req.(*ResolveQuarantineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogAdminService_RotateEncryptionKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateEncryptionKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogAdminServiceServer).RotateEncryptionKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogAdminService/RotateEncryptionKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogAdminServiceServer).RotateEncryptionKey(ctx, req.(*RotateEncryptionKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BlogAdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.BlogAdminService",
+	HandlerType: (*BlogAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CompactDatabase",
+			Handler:    _BlogAdminService_CompactDatabase_Handler,
+		},
+		{
+			MethodName: "DatabaseStats",
+			Handler:    _BlogAdminService_DatabaseStats_Handler,
+		},
+		{
+			MethodName: "CacheStats",
+			Handler:    _BlogAdminService_CacheStats_Handler,
+		},
+		{
+			MethodName: "ListQuarantine",
+			Handler:    _BlogAdminService_ListQuarantine_Handler,
+		},
+		{
+			MethodName: "ResolveQuarantine",
+			Handler:    _BlogAdminService_ResolveQuarantine_Handler,
+		},
+		{
+			MethodName: "RotateEncryptionKey",
+			Handler:    _BlogAdminService_RotateEncryptionKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "blog/blogpb/blog.proto",
+}
+
+// ReplicationServiceClient is the client API for ReplicationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReplicationServiceClient interface {
+	// Streams the changelog from from_seq on and keeps streaming new entries as
+	// they are written. Heartbeats are sent while there is nothing new.
+	StreamChanges(ctx context.Context, in *StreamChangesRequest, opts ...grpc.CallOption) (ReplicationService_StreamChangesClient, error)
+	ReplicationStatus(ctx context.Context, in *ReplicationStatusRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error)
+}
+
+type replicationServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewReplicationServiceClient(cc *grpc.ClientConn) ReplicationServiceClient {
+	return &replicationServiceClient{cc}
+}
+
+func (c *replicationServiceClient) StreamChanges(ctx context.Context, in *StreamChangesRequest, opts ...grpc.CallOption) (ReplicationService_StreamChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ReplicationService_serviceDesc.Streams[0], "/blog.ReplicationService/StreamChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replicationServiceStreamChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReplicationService_StreamChangesClient interface {
+	Recv() (*StreamChangesResponse, error)
+	grpc.ClientStream
 }
 
-func RegisterBlogServiceServer(s *grpc.Server, srv BlogServiceServer) {
-	s.RegisterService(&_BlogService_serviceDesc, srv)
+type replicationServiceStreamChangesClient struct {
+	grpc.ClientStream
 }
 
-func _BlogService_CreateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBlogRequest)
+func (x *replicationServiceStreamChangesClient) Recv() (*StreamChangesResponse, error) {
+	m := new(StreamChangesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *replicationServiceClient) ReplicationStatus(ctx context.Context, in *ReplicationStatusRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error) {
+	out := new(ReplicationStatusResponse)
+	err := c.cc.Invoke(ctx, "/blog.ReplicationService/ReplicationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplicationServiceServer is the server API for ReplicationService service.
+type ReplicationServiceServer interface {
+	// Streams the changelog from from_seq on and keeps streaming new entries as
+	// they are written. Heartbeats are sent while there is nothing new.
+	StreamChanges(*StreamChangesRequest, ReplicationService_StreamChangesServer) error
+	ReplicationStatus(context.Context, *ReplicationStatusRequest) (*ReplicationStatusResponse, error)
+}
+
+// UnimplementedReplicationServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedReplicationServiceServer struct {
+}
+
+func (*UnimplementedReplicationServiceServer) StreamChanges(req *StreamChangesRequest, srv ReplicationService_StreamChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamChanges not implemented")
+}
+func (*UnimplementedReplicationServiceServer) ReplicationStatus(ctx context.Context, req *ReplicationStatusRequest) (*ReplicationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplicationStatus not implemented")
+}
+
+func RegisterReplicationServiceServer(s *grpc.Server, srv ReplicationServiceServer) {
+	s.RegisterService(&_ReplicationService_serviceDesc, srv)
+}
+
+func _ReplicationService_StreamChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplicationServiceServer).StreamChanges(m, &replicationServiceStreamChangesServer{stream})
+}
+
+type ReplicationService_StreamChangesServer interface {
+	Send(*StreamChangesResponse) error
+	grpc.ServerStream
+}
+
+type replicationServiceStreamChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *replicationServiceStreamChangesServer) Send(m *StreamChangesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReplicationService_ReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicationStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServiceServer).CreateBlog(ctx, in)
+		return srv.(ReplicationServiceServer).ReplicationStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blog.BlogService/CreateBlog",
+		FullMethod: "/blog.ReplicationService/ReplicationStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).CreateBlog(ctx, req.(*CreateBlogRequest))
+		return srv.(ReplicationServiceServer).ReplicationStatus(ctx, req.(*ReplicationStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlogService_ReadBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadBlogRequest)
+var _ReplicationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.ReplicationService",
+	HandlerType: (*ReplicationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReplicationStatus",
+			Handler:    _ReplicationService_ReplicationStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamChanges",
+			Handler:       _ReplicationService_StreamChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "blog/blogpb/blog.proto",
+}
+
+// ClusterServiceClient is the client API for ClusterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ClusterServiceClient interface {
+	AddVoter(ctx context.Context, in *AddVoterRequest, opts ...grpc.CallOption) (*AddVoterResponse, error)
+	RemoveServer(ctx context.Context, in *RemoveServerRequest, opts ...grpc.CallOption) (*RemoveServerResponse, error)
+	ClusterStatus(ctx context.Context, in *ClusterStatusRequest, opts ...grpc.CallOption) (*ClusterStatusResponse, error)
+}
+
+type clusterServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewClusterServiceClient(cc *grpc.ClientConn) ClusterServiceClient {
+	return &clusterServiceClient{cc}
+}
+
+func (c *clusterServiceClient) AddVoter(ctx context.Context, in *AddVoterRequest, opts ...grpc.CallOption) (*AddVoterResponse, error) {
+	out := new(AddVoterResponse)
+	err := c.cc.Invoke(ctx, "/blog.ClusterService/AddVoter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) RemoveServer(ctx context.Context, in *RemoveServerRequest, opts ...grpc.CallOption) (*RemoveServerResponse, error) {
+	out := new(RemoveServerResponse)
+	err := c.cc.Invoke(ctx, "/blog.ClusterService/RemoveServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) ClusterStatus(ctx context.Context, in *ClusterStatusRequest, opts ...grpc.CallOption) (*ClusterStatusResponse, error) {
+	out := new(ClusterStatusResponse)
+	err := c.cc.Invoke(ctx, "/blog.ClusterService/ClusterStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClusterServiceServer is the server API for ClusterService service.
+type ClusterServiceServer interface {
+	AddVoter(context.Context, *AddVoterRequest) (*AddVoterResponse, error)
+	RemoveServer(context.Context, *RemoveServerRequest) (*RemoveServerResponse, error)
+	ClusterStatus(context.Context, *ClusterStatusRequest) (*ClusterStatusResponse, error)
+}
+
+// UnimplementedClusterServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedClusterServiceServer struct {
+}
+
+func (*UnimplementedClusterServiceServer) AddVoter(ctx context.Context, req *AddVoterRequest) (*AddVoterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddVoter not implemented")
+}
+func (*UnimplementedClusterServiceServer) RemoveServer(ctx context.Context, req *RemoveServerRequest) (*RemoveServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveServer not implemented")
+}
+func (*UnimplementedClusterServiceServer) ClusterStatus(ctx context.Context, req *ClusterStatusRequest) (*ClusterStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClusterStatus not implemented")
+}
+
+func RegisterClusterServiceServer(s *grpc.Server, srv ClusterServiceServer) {
+	s.RegisterService(&_ClusterService_serviceDesc, srv)
+}
+
+func _ClusterService_AddVoter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddVoterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServiceServer).ReadBlog(ctx, in)
+		return srv.(ClusterServiceServer).AddVoter(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blog.BlogService/ReadBlog",
+		FullMethod: "/blog.ClusterService/AddVoter",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).ReadBlog(ctx, req.(*ReadBlogRequest))
+		return srv.(ClusterServiceServer).AddVoter(ctx, req.(*AddVoterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlogService_UpdateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateBlogRequest)
+func _ClusterService_RemoveServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveServerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServiceServer).UpdateBlog(ctx, in)
+		return srv.(ClusterServiceServer).RemoveServer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blog.BlogService/UpdateBlog",
+		FullMethod: "/blog.ClusterService/RemoveServer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).UpdateBlog(ctx, req.(*UpdateBlogRequest))
+		return srv.(ClusterServiceServer).RemoveServer(ctx, req.(*RemoveServerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlogService_DeleteBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBlogRequest)
+func _ClusterService_ClusterStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlogServiceServer).DeleteBlog(ctx, in)
+		return srv.(ClusterServiceServer).ClusterStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blog.BlogService/DeleteBlog",
+		FullMethod: "/blog.ClusterService/ClusterStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).DeleteBlog(ctx, req.(*DeleteBlogRequest))
+		return srv.(ClusterServiceServer).ClusterStatus(ctx, req.(*ClusterStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlogService_ListBlog_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListBlogRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+var _ClusterService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.ClusterService",
+	HandlerType: (*ClusterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddVoter",
+			Handler:    _ClusterService_AddVoter_Handler,
+		},
+		{
+			MethodName: "RemoveServer",
+			Handler:    _ClusterService_RemoveServer_Handler,
+		},
+		{
+			MethodName: "ClusterStatus",
+			Handler:    _ClusterService_ClusterStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "blog/blogpb/blog.proto",
+}
+
+// RaftTransportClient is the client API for RaftTransport service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RaftTransportClient interface {
+	AppendEntries(ctx context.Context, in *RaftAppendEntriesRequest, opts ...grpc.CallOption) (*RaftAppendEntriesResponse, error)
+	RequestVote(ctx context.Context, in *RaftRequestVoteRequest, opts ...grpc.CallOption) (*RaftRequestVoteResponse, error)
+	InstallSnapshot(ctx context.Context, opts ...grpc.CallOption) (RaftTransport_InstallSnapshotClient, error)
+	TimeoutNow(ctx context.Context, in *RaftTimeoutNowRequest, opts ...grpc.CallOption) (*RaftTimeoutNowResponse, error)
+}
+
+type raftTransportClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRaftTransportClient(cc *grpc.ClientConn) RaftTransportClient {
+	return &raftTransportClient{cc}
+}
+
+func (c *raftTransportClient) AppendEntries(ctx context.Context, in *RaftAppendEntriesRequest, opts ...grpc.CallOption) (*RaftAppendEntriesResponse, error) {
+	out := new(RaftAppendEntriesResponse)
+	err := c.cc.Invoke(ctx, "/blog.RaftTransport/AppendEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return srv.(BlogServiceServer).ListBlog(m, &blogServiceListBlogServer{stream})
+	return out, nil
 }
 
-type BlogService_ListBlogServer interface {
-	Send(*ListBlogResponse) error
+func (c *raftTransportClient) RequestVote(ctx context.Context, in *RaftRequestVoteRequest, opts ...grpc.CallOption) (*RaftRequestVoteResponse, error) {
+	out := new(RaftRequestVoteResponse)
+	err := c.cc.Invoke(ctx, "/blog.RaftTransport/RequestVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftTransportClient) InstallSnapshot(ctx context.Context, opts ...grpc.CallOption) (RaftTransport_InstallSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RaftTransport_serviceDesc.Streams[0], "/blog.RaftTransport/InstallSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &raftTransportInstallSnapshotClient{stream}
+	return x, nil
+}
+
+type RaftTransport_InstallSnapshotClient interface {
+	Send(*RaftInstallSnapshotRequest) error
+	CloseAndRecv() (*RaftInstallSnapshotResponse, error)
+	grpc.ClientStream
+}
+
+type raftTransportInstallSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *raftTransportInstallSnapshotClient) Send(m *RaftInstallSnapshotRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *raftTransportInstallSnapshotClient) CloseAndRecv() (*RaftInstallSnapshotResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RaftInstallSnapshotResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *raftTransportClient) TimeoutNow(ctx context.Context, in *RaftTimeoutNowRequest, opts ...grpc.CallOption) (*RaftTimeoutNowResponse, error) {
+	out := new(RaftTimeoutNowResponse)
+	err := c.cc.Invoke(ctx, "/blog.RaftTransport/TimeoutNow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RaftTransportServer is the server API for RaftTransport service.
+type RaftTransportServer interface {
+	AppendEntries(context.Context, *RaftAppendEntriesRequest) (*RaftAppendEntriesResponse, error)
+	RequestVote(context.Context, *RaftRequestVoteRequest) (*RaftRequestVoteResponse, error)
+	InstallSnapshot(RaftTransport_InstallSnapshotServer) error
+	TimeoutNow(context.Context, *RaftTimeoutNowRequest) (*RaftTimeoutNowResponse, error)
+}
+
+// UnimplementedRaftTransportServer can be embedded to have forward compatible implementations.
+type UnimplementedRaftTransportServer struct {
+}
+
+func (*UnimplementedRaftTransportServer) AppendEntries(ctx context.Context, req *RaftAppendEntriesRequest) (*RaftAppendEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
+func (*UnimplementedRaftTransportServer) RequestVote(ctx context.Context, req *RaftRequestVoteRequest) (*RaftRequestVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (*UnimplementedRaftTransportServer) InstallSnapshot(srv RaftTransport_InstallSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method InstallSnapshot not implemented")
+}
+func (*UnimplementedRaftTransportServer) TimeoutNow(ctx context.Context, req *RaftTimeoutNowRequest) (*RaftTimeoutNowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TimeoutNow not implemented")
+}
+
+func RegisterRaftTransportServer(s *grpc.Server, srv RaftTransportServer) {
+	s.RegisterService(&_RaftTransport_serviceDesc, srv)
+}
+
+func _RaftTransport_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftAppendEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftTransportServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.RaftTransport/AppendEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftTransportServer).AppendEntries(ctx, req.(*RaftAppendEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftTransport_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftRequestVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftTransportServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.RaftTransport/RequestVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftTransportServer).RequestVote(ctx, req.(*RaftRequestVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftTransport_InstallSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RaftTransportServer).InstallSnapshot(&raftTransportInstallSnapshotServer{stream})
+}
+
+type RaftTransport_InstallSnapshotServer interface {
+	SendAndClose(*RaftInstallSnapshotResponse) error
+	Recv() (*RaftInstallSnapshotRequest, error)
 	grpc.ServerStream
 }
 
-type blogServiceListBlogServer struct {
+type raftTransportInstallSnapshotServer struct {
 	grpc.ServerStream
 }
 
-func (x *blogServiceListBlogServer) Send(m *ListBlogResponse) error {
+func (x *raftTransportInstallSnapshotServer) SendAndClose(m *RaftInstallSnapshotResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-var _BlogService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "blog.BlogService",
-	HandlerType: (*BlogServiceServer)(nil),
+func (x *raftTransportInstallSnapshotServer) Recv() (*RaftInstallSnapshotRequest, error) {
+	m := new(RaftInstallSnapshotRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _RaftTransport_TimeoutNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftTimeoutNowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftTransportServer).TimeoutNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.RaftTransport/TimeoutNow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftTransportServer).TimeoutNow(ctx, req.(*RaftTimeoutNowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RaftTransport_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.RaftTransport",
+	HandlerType: (*RaftTransportServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateBlog",
-			Handler:    _BlogService_CreateBlog_Handler,
-		},
-		{
-			MethodName: "ReadBlog",
-			Handler:    _BlogService_ReadBlog_Handler,
+			MethodName: "AppendEntries",
+			Handler:    _RaftTransport_AppendEntries_Handler,
 		},
 		{
-			MethodName: "UpdateBlog",
-			Handler:    _BlogService_UpdateBlog_Handler,
+			MethodName: "RequestVote",
+			Handler:    _RaftTransport_RequestVote_Handler,
 		},
 		{
-			MethodName: "DeleteBlog",
-			Handler:    _BlogService_DeleteBlog_Handler,
+			MethodName: "TimeoutNow",
+			Handler:    _RaftTransport_TimeoutNow_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "ListBlog",
-			Handler:       _BlogService_ListBlog_Handler,
-			ServerStreams: true,
+			StreamName:    "InstallSnapshot",
+			Handler:       _RaftTransport_InstallSnapshot_Handler,
+			ClientStreams: true,
 		},
 	},
 	Metadata: "blog/blogpb/blog.proto",
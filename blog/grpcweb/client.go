@@ -0,0 +1,201 @@
+// Package grpcweb is a client of the gRPC-Web protocol, which it speaks over
+// plain HTTP/1.1 the way a browser does.
+package grpcweb
+
+import(
+  "bufio"
+  "bytes"
+  "context"
+  "encoding/base64"
+  "encoding/binary"
+  "fmt"
+  "io"
+  "io/ioutil"
+  "net/http"
+  "net/textproto"
+  "strconv"
+  "strings"
+
+  "github.com/golang/protobuf/proto"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+const (
+  grpcWebContentType     = "application/grpc-web+proto"
+  grpcWebTextContentType = "application/grpc-web-text+proto"
+
+  // flags of the 5 bytes prefix of every gRPC-Web frame
+  dataFrame    = 0x00
+  trailerFrame = 0x80
+)
+
+// Client speaks the gRPC-Web protocol over plain HTTP/1.1, the same way a
+// browser does. When text is true messages are base64 encoded
+// (application/grpc-web-text), otherwise they are sent in binary.
+type Client struct {
+  baseURL string
+  text    bool
+  http    *http.Client
+}
+
+// NewClient returns a client of the gRPC-Web endpoint at baseURL, e.g.
+// "http://localhost:8082".
+func NewClient(baseURL string, text bool) *Client {
+  return &Client{
+    baseURL: strings.TrimSuffix(baseURL, "/"),
+    text:    text,
+    http:    http.DefaultClient,
+  }
+}
+
+// Invoke calls method, e.g. "/blog.BlogService/ReadBlog", and calls onMessage
+// for every response message, which allows server streaming RPCs. newResponse
+// must return an empty message of the response type.
+func (c *Client) Invoke(ctx context.Context, method string, req proto.Message, newResponse func() proto.Message, onMessage func(proto.Message) error) error {
+  payload, err := proto.Marshal(req)
+  if err != nil {
+    return err
+  }
+  body := &bytes.Buffer{}
+  writeFrame(body, dataFrame, payload)
+
+  contentType := grpcWebContentType
+  var reqBody io.Reader = body
+  if c.text {
+    contentType = grpcWebTextContentType
+    reqBody = strings.NewReader(base64.StdEncoding.EncodeToString(body.Bytes()))
+  }
+  httpReq, err := http.NewRequest(http.MethodPost, c.baseURL+method, reqBody)
+  if err != nil {
+    return err
+  }
+  httpReq = httpReq.WithContext(ctx)
+  httpReq.Header.Set("Content-Type", contentType)
+  httpReq.Header.Set("Accept", contentType)
+  httpReq.Header.Set("X-Grpc-Web", "1")
+
+  res, err := c.http.Do(httpReq)
+  if err != nil {
+    return status.Error(codes.Unavailable, err.Error())
+  }
+  defer res.Body.Close()
+  if res.StatusCode != http.StatusOK {
+    msg, _ := ioutil.ReadAll(res.Body)
+    return status.Error(codes.Unknown, fmt.Sprintf("HTTP %v: %s", res.StatusCode, msg))
+  }
+  // trailers-only responses carry the status in the headers
+  if err := statusFromHeader(textproto.MIMEHeader(res.Header)); err != nil {
+    return err
+  }
+
+  var r io.Reader = bufio.NewReader(res.Body)
+  if c.text {
+    r = &base64Reader{r: r}
+  }
+  for {
+    flag, payload, err := readFrame(r)
+    if err == io.EOF {
+      return status.Error(codes.Internal, "Stream ended without trailers")
+    }
+    if err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Malformed response: %v", err))
+    }
+    if flag&trailerFrame != 0 {
+      trailer, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(append(payload, '\r', '\n')))).ReadMIMEHeader()
+      if err != nil && err != io.EOF {
+        return status.Error(codes.Internal, fmt.Sprintf("Malformed trailers: %v", err))
+      }
+      return statusFromHeader(trailer)
+    }
+    msg := newResponse()
+    if err := proto.Unmarshal(payload, msg); err != nil {
+      return status.Error(codes.Internal, fmt.Sprintf("Error while Unmarshaling: %v", err))
+    }
+    if err := onMessage(msg); err != nil {
+      return err
+    }
+  }
+}
+
+func writeFrame(w io.Writer, flag byte, payload []byte) {
+  header := make([]byte, 5)
+  header[0] = flag
+  binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+  w.Write(header)
+  w.Write(payload)
+}
+
+func readFrame(r io.Reader) (byte, []byte, error) {
+  header := make([]byte, 5)
+  if _, err := io.ReadFull(r, header); err != nil {
+    return 0, nil, err
+  }
+  payload := make([]byte, binary.BigEndian.Uint32(header[1:]))
+  if _, err := io.ReadFull(r, payload); err != nil {
+    if err == io.EOF {
+      err = io.ErrUnexpectedEOF
+    }
+    return 0, nil, err
+  }
+  return header[0], payload, nil
+}
+
+// statusFromHeader returns the error described by the grpc-status and
+// grpc-message keys, or nil if there is no status or it is OK.
+func statusFromHeader(h textproto.MIMEHeader) error {
+  code := h.Get("Grpc-Status")
+  if code == "" {
+    return nil
+  }
+  n, err := strconv.Atoi(code)
+  if err != nil {
+    return status.Error(codes.Internal, fmt.Sprintf("Malformed grpc-status %q", code))
+  }
+  return status.Error(codes.Code(n), decodeGrpcMessage(h.Get("Grpc-Message")))
+}
+
+// decodeGrpcMessage undoes the percent encoding applied to grpc-message.
+func decodeGrpcMessage(msg string) string {
+  var buf bytes.Buffer
+  for i := 0; i < len(msg); i++ {
+    if msg[i] == '%' && i+2 < len(msg) {
+      if b, err := strconv.ParseUint(msg[i+1:i+3], 16, 8); err == nil {
+        buf.WriteByte(byte(b))
+        i += 2
+        continue
+      }
+    }
+    buf.WriteByte(msg[i])
+  }
+  return buf.String()
+}
+
+// base64Reader decodes a gRPC-Web text body. The server flushes the encoder
+// after every frame so the body is a concatenation of padded base64 chunks,
+// which is why it is decoded in groups of 4 characters.
+type base64Reader struct {
+  r   io.Reader
+  buf []byte
+}
+
+func (b *base64Reader) Read(p []byte) (int, error) {
+  for len(b.buf) == 0 {
+    quantum := make([]byte, 4)
+    if _, err := io.ReadFull(b.r, quantum); err != nil {
+      if err == io.ErrUnexpectedEOF {
+        return 0, fmt.Errorf("truncated base64 body")
+      }
+      return 0, err
+    }
+    decoded := make([]byte, 3)
+    n, err := base64.StdEncoding.Decode(decoded, quantum)
+    if err != nil {
+      return 0, err
+    }
+    b.buf = decoded[:n]
+  }
+  n := copy(p, b.buf)
+  b.buf = b.buf[n:]
+  return n, nil
+}
@@ -5,9 +5,11 @@ import(
 	"log"
 	"context"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/villegasl/go_grpc_course/calculator/calculatorpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/codes"
@@ -31,6 +33,14 @@ func main() {
 	// doBiDiStreaming(c)
 
 	doErrorUnary(c)
+
+	// doBigInt(c)
+
+	// doEvaluate(c)
+
+	// doEvaluateSession(c)
+
+	// doIsPrime(c)
 }
 
 func doUnary(c calculatorpb.CalculatorServiceClient) {
@@ -49,6 +59,7 @@ func doUnary(c calculatorpb.CalculatorServiceClient) {
 func doServerStreaming(c calculatorpb.CalculatorServiceClient) {
 	req := &calculatorpb.DecompositeNumberRequest {
 		Number: 12390392840,
+		ReportProgress: true,
 	}
 
 	resStream, err := c.DecompositeNumber(context.Background(), req)
@@ -63,6 +74,10 @@ func doServerStreaming(c calculatorpb.CalculatorServiceClient) {
 		if err != nil {
 			log.Fatalf("Error while reading stream: %v", err)
 		}
+		if progress := msg.GetProgress(); progress != nil {
+			log.Printf("%v: %v left after %v iterations", progress.GetStage(), progress.GetRemaining(), progress.GetIterations())
+			continue
+		}
 		log.Printf("%v ", msg.GetPrimeFactor())
 	}
 }
@@ -170,4 +185,104 @@ func doErrorCall(c calculatorpb.CalculatorServiceClient, n int32) {
 		}
 	}
 	log.Printf("Result of SquareRoot of %v: %v\n", n, res.GetNumberRoot())
-}
\ No newline at end of file
+}
+func doBigInt(c calculatorpb.CalculatorServiceClient) {
+	log.Printf("Starting arbitrary-precision unary RPCs\n")
+
+	// int64 would overflow on both
+	a, b := "9223372036854775807", "9223372036854775807"
+
+	sum, err := c.BigAdd(context.Background(), &calculatorpb.BigIntRequest{A: a, B: b})
+	if err != nil {
+		log.Fatalf("Error while calling BigAdd RPC: %v", err)
+	}
+	log.Printf("%v + %v = %v\n", a, b, sum.GetResult())
+
+	div, err := c.BigDivide(context.Background(), &calculatorpb.BigIntRequest{A: sum.GetResult(), B: "1000"})
+	if err != nil {
+		log.Fatalf("Error while calling BigDivide RPC: %v", err)
+	}
+	log.Printf("%v / 1000 = %v remainder %v\n", sum.GetResult(), div.GetQuotient(), div.GetRemainder())
+
+	pow, err := c.BigModPow(context.Background(), &calculatorpb.BigModPowRequest{Base: "2", Exponent: "1000", Modulus: "1000000007"})
+	if err != nil {
+		log.Fatalf("Error while calling BigModPow RPC: %v", err)
+	}
+	log.Printf("2^1000 mod 1000000007 = %v\n", pow.GetResult())
+
+	// error call
+	_, err = c.BigMultiply(context.Background(), &calculatorpb.BigIntRequest{A: "12e3", B: "4"})
+	if resErr, ok := status.FromError(err); ok && resErr.Code() == codes.InvalidArgument {
+		fmt.Println("Error message from server:", resErr.Message())
+	}
+}
+
+func doEvaluate(c calculatorpb.CalculatorServiceClient) {
+	log.Printf("Starting to do an Evaluate unary RPC\n")
+
+	res, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{
+		Expression: "3*(4+sqrt(16))/2 + x",
+		Variables:  []*calculatorpb.Variable{{Name: "x", Value: 0.5}},
+	})
+	if err != nil {
+		log.Fatalf("Error while calling Evaluate RPC: %v", err)
+	}
+	log.Printf("Result: %v\n", res.GetResult())
+
+	// error call
+	_, err = c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: "3*(4+"})
+	resErr := status.Convert(err)
+	fmt.Println("Error message from server:", resErr.Message())
+	for _, detail := range resErr.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fmt.Printf("%v: %v\n", violation.GetField(), violation.GetDescription())
+			}
+		}
+	}
+}
+
+func doEvaluateSession(c calculatorpb.CalculatorServiceClient) {
+	log.Printf("Starting bidirectional streaming Evaluate session\n")
+	lines := []string{"r = 2", "area = pi*r^2", "area / 2", "ans + oops", "sqrt(ans"}
+
+	stream, err := c.EvaluateSession(context.Background())
+	if err != nil {
+		log.Fatalf("Error while opening stream: %v", err)
+	}
+	// one response per line, so lines can be sent and read in turn
+	for _, line := range lines {
+		if err := stream.Send(&calculatorpb.EvaluateSessionRequest{Line: line}); err != nil {
+			log.Fatalf("Error while sending request: %v", err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			log.Fatalf("Error while receiving response: %v", err)
+		}
+		switch {
+		case res.GetError() != "" && res.GetErrorColumn() == 0:
+			log.Printf("> %v\n%v\n", line, res.GetError())
+		case res.GetError() != "":
+			log.Printf("> %v\n%v^ %v\n", line, strings.Repeat(" ", int(res.GetErrorColumn())+1), res.GetError())
+		case res.GetAssigned() != "":
+			log.Printf("> %v\n%v = %v\n", line, res.GetAssigned(), res.GetResult())
+		default:
+			log.Printf("> %v\n%v\n", line, res.GetResult())
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		log.Fatalf("Error while closing the stream: %v", err)
+	}
+}
+
+func doIsPrime(c calculatorpb.CalculatorServiceClient) {
+	log.Printf("Starting to do an IsPrime unary RPC\n")
+
+	for _, n := range []int64{9223372036854775783, 9223372036854775807} {
+		res, err := c.IsPrime(context.Background(), &calculatorpb.IsPrimeRequest{Number: n})
+		if err != nil {
+			log.Fatalf("Error while calling IsPrime RPC: %v", err)
+		}
+		log.Printf("%v is prime: %v\n", n, res.GetIsPrime())
+	}
+}
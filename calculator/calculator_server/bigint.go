@@ -0,0 +1,180 @@
+package main
+
+import(
+	"context"
+	"log"
+	"math/big"
+
+	"github.com/villegasl/go_grpc_course/calculator/calculatorpb"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// maxDigits bounds the operands of the arbitrary-precision RPCs
+	maxDigits = 10000
+	// maxResultBits bounds the result of BigPower, about 300000 digits
+	maxResultBits = 1 << 20
+)
+
+// parseBig parses the decimal integer s, the operand called name, or returns
+// an INVALID_ARGUMENT error
+func parseBig(name, s string) (*big.Int, error) {
+	digits := s
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		digits = digits[1:]
+	}
+	if len(digits) > maxDigits {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"%v has %v digits, more than the %v allowed", name, len(digits), maxDigits,
+		)
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		if len(s) > 20 {
+			s = s[:20] + "..."
+		}
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"%v is not a decimal integer: %q", name, s,
+		)
+	}
+	return n, nil
+}
+
+// parseBigs parses the operands a and b of req
+func parseBigs(req *calculatorpb.BigIntRequest) (*big.Int, *big.Int, error) {
+	a, err := parseBig("a", req.GetA())
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := parseBig("b", req.GetB())
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
+
+func (*server) BigAdd(ctx context.Context, req *calculatorpb.BigIntRequest) (*calculatorpb.BigIntResponse, error) {
+	log.Printf("Received BigAdd RPC\n")
+	a, b, err := parseBigs(req)
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.BigIntResponse{
+		Result: a.Add(a, b).String(),
+	}, nil
+}
+
+func (*server) BigSubtract(ctx context.Context, req *calculatorpb.BigIntRequest) (*calculatorpb.BigIntResponse, error) {
+	log.Printf("Received BigSubtract RPC\n")
+	a, b, err := parseBigs(req)
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.BigIntResponse{
+		Result: a.Sub(a, b).String(),
+	}, nil
+}
+
+func (*server) BigMultiply(ctx context.Context, req *calculatorpb.BigIntRequest) (*calculatorpb.BigIntResponse, error) {
+	log.Printf("Received BigMultiply RPC\n")
+	a, b, err := parseBigs(req)
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.BigIntResponse{
+		Result: a.Mul(a, b).String(),
+	}, nil
+}
+
+// This RPC will throw an exception if b is zero
+func (*server) BigDivide(ctx context.Context, req *calculatorpb.BigIntRequest) (*calculatorpb.BigDivideResponse, error) {
+	log.Printf("Received BigDivide RPC\n")
+	a, b, err := parseBigs(req)
+	if err != nil {
+		return nil, err
+	}
+	if b.Sign() == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Division by zero",
+		)
+	}
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	return &calculatorpb.BigDivideResponse{
+		Quotient:  q.String(),
+		Remainder: r.String(),
+	}, nil
+}
+
+// This RPC will throw an exception if the exponent is negative or the
+// result would be larger than maxResultBits
+func (*server) BigPower(ctx context.Context, req *calculatorpb.BigPowerRequest) (*calculatorpb.BigIntResponse, error) {
+	log.Printf("Received BigPower RPC\n")
+	base, err := parseBig("base", req.GetBase())
+	if err != nil {
+		return nil, err
+	}
+	exp, err := parseBig("exponent", req.GetExponent())
+	if err != nil {
+		return nil, err
+	}
+	if exp.Sign() < 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Received a negative exponent",
+		)
+	}
+	// 0, 1 and -1 stay small whatever the exponent, other bases take at
+	// least exponent*(bits-1)+1 bits
+	abs := new(big.Int).Abs(base)
+	if abs.BitLen() > 1 {
+		bits := new(big.Int).Mul(exp, big.NewInt(int64(abs.BitLen()-1)))
+		if bits.Cmp(big.NewInt(maxResultBits)) >= 0 {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"The result would have more than %v bits", maxResultBits,
+			)
+		}
+	}
+	return &calculatorpb.BigIntResponse{
+		Result: new(big.Int).Exp(base, exp, nil).String(),
+	}, nil
+}
+
+// This RPC will throw an exception if the exponent is negative or the
+// modulus isn't positive. The result is between 0 and modulus-1
+func (*server) BigModPow(ctx context.Context, req *calculatorpb.BigModPowRequest) (*calculatorpb.BigIntResponse, error) {
+	log.Printf("Received BigModPow RPC\n")
+	base, err := parseBig("base", req.GetBase())
+	if err != nil {
+		return nil, err
+	}
+	exp, err := parseBig("exponent", req.GetExponent())
+	if err != nil {
+		return nil, err
+	}
+	mod, err := parseBig("modulus", req.GetModulus())
+	if err != nil {
+		return nil, err
+	}
+	if exp.Sign() < 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Received a negative exponent",
+		)
+	}
+	if mod.Sign() <= 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Received a modulus that isn't positive",
+		)
+	}
+	// Exp takes the sign of a negative base into its result
+	base.Mod(base, mod)
+	return &calculatorpb.BigIntResponse{
+		Result: new(big.Int).Exp(base, exp, mod).String(),
+	}, nil
+}
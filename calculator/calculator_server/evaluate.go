@@ -0,0 +1,510 @@
+package main
+
+import(
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/villegasl/go_grpc_course/calculator/calculatorpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// maxExpression bounds the length of an expression
+	maxExpression = 10000
+	// maxDepth bounds the nesting of parentheses, calls and unary operators
+	maxDepth = 200
+)
+
+// constants are the variables every expression starts with
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+type function struct {
+	args int
+	fn   func(args []float64) float64
+}
+
+func unary(fn func(float64) float64) function {
+	return function{1, func(args []float64) float64 { return fn(args[0]) }}
+}
+
+func binary(fn func(float64, float64) float64) function {
+	return function{2, func(args []float64) float64 { return fn(args[0], args[1]) }}
+}
+
+var functions = map[string]function{
+	"sqrt":  unary(math.Sqrt),
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"asin":  unary(math.Asin),
+	"acos":  unary(math.Acos),
+	"atan":  unary(math.Atan),
+	"log":   unary(math.Log),
+	"log10": unary(math.Log10),
+	"exp":   unary(math.Exp),
+	"abs":   unary(math.Abs),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"pow":   binary(math.Pow),
+	"min":   binary(math.Min),
+	"max":   binary(math.Max),
+}
+
+// exprError is an error of an expression at byte pos, or of the whole
+// expression if pos is negative
+type exprError struct {
+	pos int
+	msg string
+}
+
+func (e *exprError) Error() string {
+	if e.pos < 0 {
+		return e.msg
+	}
+	return fmt.Sprintf("column %v: %v", e.pos+1, e.msg)
+}
+
+func errorAt(pos int, format string, args ...interface{}) error {
+	return &exprError{pos, fmt.Sprintf(format, args...)}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// tokenize splits s into numbers, identifiers and the one character
+// operators, ending with a tokEOF token
+func tokenize(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isDigit(c) || c == '.':
+			start := i
+			for i < len(s) && (isDigit(s[i]) || s[i] == '.') {
+				i++
+			}
+			// exponent, as in 1.5e-3
+			if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+				j := i + 1
+				if j < len(s) && (s[j] == '+' || s[j] == '-') {
+					j++
+				}
+				if j < len(s) && isDigit(s[j]) {
+					for i = j; i < len(s) && isDigit(s[i]); i++ {
+					}
+				}
+			}
+			// numbers out of range are left to the parser
+			if _, err := strconv.ParseFloat(s[start:i], 64); err != nil && err.(*strconv.NumError).Err != strconv.ErrRange {
+				return nil, errorAt(start, "malformed number %q", s[start:i])
+			}
+			toks = append(toks, token{tokNumber, s[start:i], start})
+		case isLetter(c):
+			start := i
+			for i < len(s) && (isLetter(s[i]) || isDigit(s[i])) {
+				i++
+			}
+			toks = append(toks, token{tokIdent, s[start:i], start})
+		case strings.IndexByte("+-*/%^(),=", c) >= 0:
+			toks = append(toks, token{tokOp, s[i : i+1], i})
+			i++
+		default:
+			return nil, errorAt(i, "unexpected character %q", c)
+		}
+	}
+	return append(toks, token{tokEOF, "", len(s)}), nil
+}
+
+// parser evaluates the expression of its tokens by recursive descent, with
+// the grammar
+//
+//	expr    = term {("+" | "-") term}
+//	term    = unary {("*" | "/" | "%") unary}
+//	unary   = ("-" | "+") unary | power
+//	power   = primary ["^" unary]
+//	primary = number | name | name "(" expr {"," expr} ")" | "(" expr ")"
+//
+// so that ^ binds tighter than unary minus, -2^2 is -4, and is right
+// associative
+type parser struct {
+	toks  []token
+	i     int
+	vars  map[string]float64
+	depth int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == tokOp && t.text == op
+}
+
+func (p *parser) expect(op string) error {
+	if !p.isOp(op) {
+		return p.unexpected("expected %q", op)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) unexpected(format string, args ...interface{}) error {
+	t := p.peek()
+	found := fmt.Sprintf("%q", t.text)
+	if t.kind == tokEOF {
+		found = "end of expression"
+	}
+	return errorAt(t.pos, "%v, found %v", fmt.Sprintf(format, args...), found)
+}
+
+// enter counts one more level of nesting at pos, leave one less. Deep
+// nesting is rejected before it exhausts the stack.
+func (p *parser) enter(pos int) error {
+	p.depth++
+	if p.depth > maxDepth {
+		return errorAt(pos, "expression nested more than %v levels deep", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// finite returns an error at pos if v, the result of what, isn't a finite
+// number
+func finite(pos int, v float64, what string) (float64, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errorAt(pos, "%v is not a finite number", what)
+	}
+	return v, nil
+}
+
+func (p *parser) expr() (float64, error) {
+	if err := p.enter(p.peek().pos); err != nil {
+		return 0, err
+	}
+	defer p.leave()
+	v, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.next()
+		w, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+		if op.text == "+" {
+			v += w
+		} else {
+			v -= w
+		}
+		if v, err = finite(op.pos, v, "the result of "+op.text); err != nil {
+			return 0, err
+		}
+	}
+	return v, nil
+}
+
+func (p *parser) term() (float64, error) {
+	v, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOp("*") || p.isOp("/") || p.isOp("%") {
+		op := p.next()
+		w, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		if op.text != "*" && w == 0 {
+			return 0, errorAt(op.pos, "division by zero")
+		}
+		switch op.text {
+		case "*":
+			v *= w
+		case "/":
+			v /= w
+		case "%":
+			v = math.Mod(v, w)
+		}
+		if v, err = finite(op.pos, v, "the result of "+op.text); err != nil {
+			return 0, err
+		}
+	}
+	return v, nil
+}
+
+func (p *parser) unary() (float64, error) {
+	if p.isOp("-") || p.isOp("+") {
+		op := p.next()
+		if err := p.enter(op.pos); err != nil {
+			return 0, err
+		}
+		defer p.leave()
+		v, err := p.unary()
+		if op.text == "-" {
+			v = -v
+		}
+		return v, err
+	}
+	return p.power()
+}
+
+func (p *parser) power() (float64, error) {
+	v, err := p.primary()
+	if err != nil || !p.isOp("^") {
+		return v, err
+	}
+	op := p.next()
+	if err := p.enter(op.pos); err != nil {
+		return 0, err
+	}
+	defer p.leave()
+	w, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	return finite(op.pos, math.Pow(v, w), "the result of ^")
+}
+
+func (p *parser) primary() (float64, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokNumber:
+		p.next()
+		// tokenize checked it parses, it may still be out of range
+		v, _ := strconv.ParseFloat(t.text, 64)
+		return finite(t.pos, v, t.text)
+	case t.kind == tokIdent:
+		p.next()
+		if p.isOp("(") {
+			return p.call(t)
+		}
+		v, ok := p.vars[t.text]
+		if !ok {
+			return 0, errorAt(t.pos, "unknown variable %q", t.text)
+		}
+		return v, nil
+	case p.isOp("("):
+		p.next()
+		v, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		return v, p.expect(")")
+	}
+	return 0, p.unexpected("expected a number, a variable or \"(\"")
+}
+
+// call evaluates the call of function name, whose "(" is the next token
+func (p *parser) call(name token) (float64, error) {
+	f, ok := functions[name.text]
+	if !ok {
+		return 0, errorAt(name.pos, "unknown function %q", name.text)
+	}
+	p.next()
+	var args []float64
+	for {
+		v, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, v)
+		if !p.isOp(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect(")"); err != nil {
+		return 0, err
+	}
+	if len(args) != f.args {
+		return 0, errorAt(name.pos, "%v takes %v arguments, got %v", name.text, f.args, len(args))
+	}
+	return finite(name.pos, f.fn(args), name.text+"("+formatArgs(args)+")")
+}
+
+func formatArgs(args []float64) string {
+	s := make([]string, len(args))
+	for i, arg := range args {
+		s[i] = strconv.FormatFloat(arg, 'g', -1, 64)
+	}
+	return strings.Join(s, ", ")
+}
+
+// evaluate returns the value of line with the variables of vars. If line
+// is an assignment, the name it assigns is returned too.
+func evaluate(line string, vars map[string]float64, assignments bool) (float64, string, error) {
+	if len(line) > maxExpression {
+		return 0, "", errorAt(-1, "the expression is longer than %v characters", maxExpression)
+	}
+	toks, err := tokenize(line)
+	if err != nil {
+		return 0, "", err
+	}
+	p := &parser{toks: toks, vars: vars}
+	name := ""
+	if assignments && len(toks) > 2 && toks[0].kind == tokIdent && toks[1].kind == tokOp && toks[1].text == "=" {
+		name = toks[0].text
+		p.i = 2
+	}
+	v, err := p.expr()
+	if err != nil {
+		return 0, "", err
+	}
+	if p.peek().kind != tokEOF {
+		return 0, "", p.unexpected("expected an operator")
+	}
+	return v, name, nil
+}
+
+// bindVariables adds the variables of a request to vars, after checking
+// their names are ones expressions can use
+func bindVariables(vars map[string]float64, variables []*calculatorpb.Variable) error {
+	for i, variable := range variables {
+		name := variable.GetName()
+		toks, err := tokenize(name)
+		if err != nil || len(toks) != 2 || toks[0].kind != tokIdent {
+			return &variableError{i, name}
+		}
+		if math.IsNaN(variable.GetValue()) || math.IsInf(variable.GetValue(), 0) {
+			return &variableError{i, name}
+		}
+		vars[name] = variable.GetValue()
+	}
+	return nil
+}
+
+type variableError struct {
+	index int
+	name  string
+}
+
+func (e *variableError) Error() string {
+	return fmt.Sprintf("variable %q must be named with letters, digits and _, not starting with a digit, and have a finite value", e.name)
+}
+
+func newVariables() map[string]float64 {
+	vars := make(map[string]float64)
+	for name, v := range constants {
+		vars[name] = v
+	}
+	return vars
+}
+
+// evaluateError turns an error of evaluate or bindVariables into an
+// INVALID_ARGUMENT status whose BadRequest detail points at the field, and
+// column, of the error
+func evaluateError(err error) error {
+	violation := &errdetails.BadRequest_FieldViolation{
+		Field:       "expression",
+		Description: err.Error(),
+	}
+	if ve, ok := err.(*variableError); ok {
+		violation.Field = fmt.Sprintf("variables[%v].name", ve.index)
+	}
+	st := status.New(codes.InvalidArgument, err.Error())
+	withDetails, detailsErr := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{violation},
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// This RPC will throw an exception if the expression is malformed or its
+// result isn't a finite number
+func (*server) Evaluate(ctx context.Context, req *calculatorpb.EvaluateRequest) (*calculatorpb.EvaluateResponse, error) {
+	log.Printf("Received Evaluate RPC: %q\n", req.GetExpression())
+	vars := newVariables()
+	if err := bindVariables(vars, req.GetVariables()); err != nil {
+		return nil, evaluateError(err)
+	}
+	result, _, err := evaluate(req.GetExpression(), vars, false)
+	if err != nil {
+		return nil, evaluateError(err)
+	}
+	return &calculatorpb.EvaluateResponse{
+		Result: result,
+	}, nil
+}
+
+// Bidirectional Streaming API
+func (*server) EvaluateSession(stream calculatorpb.CalculatorService_EvaluateSessionServer) error {
+	log.Printf("EvaluateSession function was invoked\n")
+
+	vars := newVariables()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		res := &calculatorpb.EvaluateSessionResponse{}
+		err = bindVariables(vars, req.GetVariables())
+		if err == nil {
+			res.Result, res.Assigned, err = evaluate(req.GetLine(), vars, true)
+		}
+		if err != nil {
+			res.Error = err.Error()
+			if ee, ok := err.(*exprError); ok {
+				res.Error = ee.msg
+				res.ErrorColumn = int32(ee.pos + 1)
+			}
+		} else {
+			if res.Assigned != "" {
+				vars[res.Assigned] = res.Result
+			}
+			vars["ans"] = res.Result
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import(
+	"context"
+	"math/bits"
+	"sort"
+
+	"github.com/villegasl/go_grpc_course/calculator/calculatorpb"
+)
+
+const (
+	// sieveLimit bounds the primes tried by trial division. A number with
+	// no factor below it and smaller than its square is prime.
+	sieveLimit = 1 << 12
+	// rhoBatch is how many steps of Pollard's rho share one gcd, and how
+	// often the context is checked
+	rhoBatch = 128
+	// progressEvery is how many steps of Pollard's rho separate two
+	// progress reports
+	progressEvery = 1 << 16
+)
+
+// smallPrimes are the primes below sieveLimit, by the sieve of Eratosthenes
+var smallPrimes = sieve(sieveLimit)
+
+func sieve(limit int) []uint64 {
+	composite := make([]bool, limit)
+	var primes []uint64
+	for i := 2; i < limit; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, uint64(i))
+		for j := i * i; j < limit; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi%m, lo, m)
+	return rem
+}
+
+func powMod(a, e, m uint64) uint64 {
+	result := uint64(1) % m
+	a %= m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMod(result, a, m)
+		}
+		a = mulMod(a, a, m)
+	}
+	return result
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// millerRabinBases make the Miller-Rabin test exact for every 64-bit number
+var millerRabinBases = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// isPrime tells whether n is prime with the Miller-Rabin test
+func isPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range millerRabinBases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	// n-1 = d*2^s with d odd
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= uint(s)
+	for _, a := range millerRabinBases {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for i := 1; i < s && composite; i++ {
+			x = mulMod(x, x, n)
+			composite = x != n-1
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// factorizer finds the prime factors of a number, calling found with each
+// one in ascending order and progress, if set, as the search goes
+type factorizer struct {
+	ctx        context.Context
+	found      func(p uint64) error
+	progress   func(*calculatorpb.FactorizationProgress) error
+	iterations uint64
+}
+
+func (f *factorizer) report(stage string, remaining uint64) error {
+	if f.progress == nil {
+		return nil
+	}
+	return f.progress(&calculatorpb.FactorizationProgress{
+		Stage:      stage,
+		Remaining:  int64(remaining),
+		Iterations: f.iterations,
+	})
+}
+
+// factorize divides n by the small primes, then splits what is left with
+// Pollard's rho until only primes remain
+func (f *factorizer) factorize(n uint64) error {
+	if n < 2 {
+		return nil
+	}
+	if err := f.report("trial division", n); err != nil {
+		return err
+	}
+	for _, p := range smallPrimes {
+		if p*p > n {
+			break
+		}
+		for n%p == 0 {
+			if err := f.found(p); err != nil {
+				return err
+			}
+			n /= p
+		}
+	}
+	if n == 1 {
+		return nil
+	}
+	if n < sieveLimit*sieveLimit || isPrime(n) {
+		return f.found(n)
+	}
+
+	f.iterations = 0
+	if err := f.report("pollard rho", n); err != nil {
+		return err
+	}
+	// every factor left is above the small primes, so sending them sorted
+	// keeps the whole stream in ascending order
+	var primes []uint64
+	composites := []uint64{n}
+	for len(composites) > 0 {
+		m := composites[len(composites)-1]
+		composites = composites[:len(composites)-1]
+		if isPrime(m) {
+			primes = append(primes, m)
+			continue
+		}
+		d, err := f.rho(m)
+		if err != nil {
+			return err
+		}
+		composites = append(composites, d, m/d)
+	}
+	sort.Slice(primes, func(i, j int) bool {
+		return primes[i] < primes[j]
+	})
+	for _, p := range primes {
+		if err := f.found(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rho returns a nontrivial divisor of the odd composite n, with Brent's
+// variant of Pollard's rho, trying other polynomials x^2+c when one fails
+func (f *factorizer) rho(n uint64) (uint64, error) {
+	for c := uint64(1); ; c++ {
+		d, err := f.brent(n, c)
+		if err != nil {
+			return 0, err
+		}
+		if d != n {
+			return d, nil
+		}
+	}
+}
+
+func (f *factorizer) brent(n, c uint64) (uint64, error) {
+	next := func(x uint64) uint64 {
+		return (mulMod(x, x, n) + c) % n
+	}
+	diff := func(a, b uint64) uint64 {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+	y, q, g := uint64(2), uint64(1), uint64(1)
+	var x, ys uint64
+	for r := uint64(1); g == 1; r *= 2 {
+		x = y
+		for i := uint64(0); i < r; i++ {
+			y = next(y)
+		}
+		for k := uint64(0); k < r && g == 1; k += rhoBatch {
+			ys = y
+			for i := uint64(0); i < rhoBatch && i < r-k; i++ {
+				y = next(y)
+				q = mulMod(q, diff(x, y), n)
+			}
+			g = gcd(q, n)
+
+			before := f.iterations
+			f.iterations += rhoBatch
+			if err := f.ctx.Err(); err != nil {
+				return 0, err
+			}
+			if f.iterations/progressEvery != before/progressEvery {
+				if err := f.report("pollard rho", n); err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+	// the batch overshot, step through it again one gcd at a time
+	if g == n {
+		for g = 1; g == 1; {
+			ys = next(ys)
+			g = gcd(diff(x, ys), n)
+		}
+	}
+	return g, nil
+}
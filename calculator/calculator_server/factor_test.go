@@ -0,0 +1,147 @@
+package main
+
+import(
+	"context"
+	"fmt"
+	"testing"
+)
+
+// largestPrimeBelow returns the largest prime below 2^k
+func largestPrimeBelow(k uint) uint64 {
+	n := uint64(1)<<k - 1
+	for !isPrime(n) {
+		n -= 2
+	}
+	return n
+}
+
+// semiprime returns the product of the two largest primes below 2^k
+func semiprime(k uint) (uint64, uint64, uint64) {
+	p := largestPrimeBelow(k)
+	q := p - 2
+	for !isPrime(q) {
+		q -= 2
+	}
+	return p * q, q, p
+}
+
+func TestIsPrime(t *testing.T) {
+	for _, tc := range []struct {
+		n    uint64
+		want bool
+	}{
+		{0, false},
+		{1, false},
+		{2, true},
+		{3, true},
+		{4, false},
+		{97, true},
+		{4093, true},
+		{4099, true},
+		// Carmichael numbers, which fool the Fermat test
+		{561, false},
+		{41041, false},
+		// strong pseudoprimes to base 2
+		{2047, false},
+		{3215031751, false},
+		{1<<63 - 1, false},
+		{18446744073709551557, true},
+		{4294967291 * 4294967291, false},
+	} {
+		if got := isPrime(tc.n); got != tc.want {
+			t.Errorf("isPrime(%v) = %v, want %v", tc.n, got, tc.want)
+		}
+	}
+}
+
+// factors returns what factorize finds for n, in the order found.
+func factors(t *testing.T, n uint64) []uint64 {
+	t.Helper()
+	var found []uint64
+	f := &factorizer{
+		ctx: context.Background(),
+		found: func(p uint64) error {
+			found = append(found, p)
+			return nil
+		},
+	}
+	if err := f.factorize(n); err != nil {
+		t.Fatalf("factorize(%v): %v", n, err)
+	}
+	return found
+}
+
+func TestFactorize(t *testing.T) {
+	for _, tc := range []struct {
+		n    uint64
+		want []uint64
+	}{
+		{1, nil},
+		{2, []uint64{2}},
+		{1 << 10, []uint64{2, 2, 2, 2, 2, 2, 2, 2, 2, 2}},
+		{1 << 63, repeat(2, 63)},
+		// squares of primes above sieveLimit
+		{4099 * 4099, []uint64{4099, 4099}},
+		{4294967291 * 4294967291, []uint64{4294967291, 4294967291}},
+		{1000003 * 1000033 * 1000037, []uint64{1000003, 1000033, 1000037}},
+		{1<<63 - 1, []uint64{7, 7, 73, 127, 337, 92737, 649657}},
+		{3215031751, []uint64{151, 751, 28351}},
+		{18446744073709551557, []uint64{18446744073709551557}},
+	} {
+		got := factors(t, tc.n)
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("factorize(%v) = %v, want %v", tc.n, got, tc.want)
+		}
+		product := uint64(1)
+		for i, p := range got {
+			if i > 0 && p < got[i-1] {
+				t.Errorf("factorize(%v) = %v, not in ascending order", tc.n, got)
+			}
+			product *= p
+		}
+		if tc.n > 1 && product != tc.n {
+			t.Errorf("the factors %v of %v multiply to %v", got, tc.n, product)
+		}
+	}
+}
+
+func repeat(p uint64, n int) []uint64 {
+	var list []uint64
+	for i := 0; i < n; i++ {
+		list = append(list, p)
+	}
+	return list
+}
+
+func BenchmarkFactorizeSemiprime(b *testing.B) {
+	for _, k := range []uint{16, 24, 28, 31} {
+		n, p, q := semiprime(k)
+		b.Run(fmt.Sprintf("%vbit", 2*k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var factors []uint64
+				f := &factorizer{
+					ctx: context.Background(),
+					found: func(factor uint64) error {
+						factors = append(factors, factor)
+						return nil
+					},
+				}
+				if err := f.factorize(n); err != nil {
+					b.Fatal(err)
+				}
+				if len(factors) != 2 || factors[0] != p || factors[1] != q {
+					b.Fatalf("%v factored into %v, want [%v %v]", n, factors, p, q)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkIsPrime(b *testing.B) {
+	p := largestPrimeBelow(63)
+	for i := 0; i < b.N; i++ {
+		if !isPrime(p) {
+			b.Fatalf("%v is prime", p)
+		}
+	}
+}
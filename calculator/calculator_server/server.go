@@ -32,25 +32,41 @@ func (*server) Sum(ctx context.Context, req *calculatorpb.SumRequest) (*calculat
 func (*server) DecompositeNumber(req *calculatorpb.DecompositeNumberRequest, stream calculatorpb.CalculatorService_DecompositeNumberServer) error {
 	fmt.Printf("DecompositeNumber function was invoked with: %v\n", req)
 
-	number := req.GetNumber()
-	res := new(calculatorpb.DecompositeNumberResponse)
-
-	divisor := int64(2)
-	for number > 1 {
-		if number % divisor == 0 {
-			// this is a factor
-			res.PrimeFactor = divisor
-			stream.Send(res)
-			// divide number by divisor so that we have the rest of the number left
-			number = number / divisor 
-		} else {
-			divisor++
-			fmt.Printf("Divisor has increased to %v\n", divisor)
+	f := &factorizer{
+		ctx: stream.Context(),
+		found: func(p uint64) error {
+			return stream.Send(&calculatorpb.DecompositeNumberResponse{
+				PrimeFactor: int64(p),
+			})
+		},
+	}
+	if req.GetReportProgress() {
+		f.progress = func(progress *calculatorpb.FactorizationProgress) error {
+			return stream.Send(&calculatorpb.DecompositeNumberResponse{
+				Progress: progress,
+			})
+		}
+	}
+	// numbers below 2 have no prime factors
+	if number := req.GetNumber(); number > 1 {
+		if err := f.factorize(uint64(number)); err != nil {
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err()
+			}
+			return err
 		}
 	}
 	return nil
 }
 
+func (*server) IsPrime(ctx context.Context, req *calculatorpb.IsPrimeRequest) (*calculatorpb.IsPrimeResponse, error) {
+	log.Printf("Received IsPrime RPC\n")
+	number := req.GetNumber()
+	return &calculatorpb.IsPrimeResponse{
+		IsPrime: number > 1 && isPrime(uint64(number)),
+	}, nil
+}
+
 // Client Streaming API
 func (*server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
 	fmt.Printf("ComputeAverage function was invoked")
@@ -111,7 +111,9 @@ func (m *SumResponse) GetResult() int64 {
 }
 
 type DecompositeNumberRequest struct {
-	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// also send progress messages while large factors are searched
+	ReportProgress       bool     `protobuf:"varint,2,opt,name=report_progress,json=reportProgress,proto3" json:"report_progress,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -149,11 +151,21 @@ func (m *DecompositeNumberRequest) GetNumber() int64 {
 	return 0
 }
 
+func (m *DecompositeNumberRequest) GetReportProgress() bool {
+	if m != nil {
+		return m.ReportProgress
+	}
+	return false
+}
+
+// The prime factors are sent in ascending order, once per multiplicity.
+// Progress messages, only sent when asked for, have no prime_factor.
 type DecompositeNumberResponse struct {
-	PrimeFactor          int64    `protobuf:"varint,1,opt,name=prime_factor,json=primeFactor,proto3" json:"prime_factor,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PrimeFactor          int64                  `protobuf:"varint,1,opt,name=prime_factor,json=primeFactor,proto3" json:"prime_factor,omitempty"`
+	Progress             *FactorizationProgress `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *DecompositeNumberResponse) Reset()         { *m = DecompositeNumberResponse{} }
@@ -188,6 +200,146 @@ func (m *DecompositeNumberResponse) GetPrimeFactor() int64 {
 	return 0
 }
 
+func (m *DecompositeNumberResponse) GetProgress() *FactorizationProgress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+type FactorizationProgress struct {
+	Stage                string   `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	Remaining            int64    `protobuf:"varint,2,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Iterations           uint64   `protobuf:"varint,3,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FactorizationProgress) Reset()         { *m = FactorizationProgress{} }
+func (m *FactorizationProgress) String() string { return proto.CompactTextString(m) }
+func (*FactorizationProgress) ProtoMessage()    {}
+func (*FactorizationProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{4}
+}
+
+func (m *FactorizationProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FactorizationProgress.Unmarshal(m, b)
+}
+func (m *FactorizationProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FactorizationProgress.Marshal(b, m, deterministic)
+}
+func (m *FactorizationProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FactorizationProgress.Merge(m, src)
+}
+func (m *FactorizationProgress) XXX_Size() int {
+	return xxx_messageInfo_FactorizationProgress.Size(m)
+}
+func (m *FactorizationProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_FactorizationProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FactorizationProgress proto.InternalMessageInfo
+
+func (m *FactorizationProgress) GetStage() string {
+	if m != nil {
+		return m.Stage
+	}
+	return ""
+}
+
+func (m *FactorizationProgress) GetRemaining() int64 {
+	if m != nil {
+		return m.Remaining
+	}
+	return 0
+}
+
+func (m *FactorizationProgress) GetIterations() uint64 {
+	if m != nil {
+		return m.Iterations
+	}
+	return 0
+}
+
+type IsPrimeRequest struct {
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsPrimeRequest) Reset()         { *m = IsPrimeRequest{} }
+func (m *IsPrimeRequest) String() string { return proto.CompactTextString(m) }
+func (*IsPrimeRequest) ProtoMessage()    {}
+func (*IsPrimeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{5}
+}
+
+func (m *IsPrimeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsPrimeRequest.Unmarshal(m, b)
+}
+func (m *IsPrimeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsPrimeRequest.Marshal(b, m, deterministic)
+}
+func (m *IsPrimeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsPrimeRequest.Merge(m, src)
+}
+func (m *IsPrimeRequest) XXX_Size() int {
+	return xxx_messageInfo_IsPrimeRequest.Size(m)
+}
+func (m *IsPrimeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsPrimeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsPrimeRequest proto.InternalMessageInfo
+
+func (m *IsPrimeRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type IsPrimeResponse struct {
+	IsPrime              bool     `protobuf:"varint,1,opt,name=is_prime,json=isPrime,proto3" json:"is_prime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsPrimeResponse) Reset()         { *m = IsPrimeResponse{} }
+func (m *IsPrimeResponse) String() string { return proto.CompactTextString(m) }
+func (*IsPrimeResponse) ProtoMessage()    {}
+func (*IsPrimeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{6}
+}
+
+func (m *IsPrimeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsPrimeResponse.Unmarshal(m, b)
+}
+func (m *IsPrimeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsPrimeResponse.Marshal(b, m, deterministic)
+}
+func (m *IsPrimeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsPrimeResponse.Merge(m, src)
+}
+func (m *IsPrimeResponse) XXX_Size() int {
+	return xxx_messageInfo_IsPrimeResponse.Size(m)
+}
+func (m *IsPrimeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsPrimeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsPrimeResponse proto.InternalMessageInfo
+
+func (m *IsPrimeResponse) GetIsPrime() bool {
+	if m != nil {
+		return m.IsPrime
+	}
+	return false
+}
+
 type ComputeAverageRequest struct {
 	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -199,7 +351,7 @@ func (m *ComputeAverageRequest) Reset()         { *m = ComputeAverageRequest{} }
 func (m *ComputeAverageRequest) String() string { return proto.CompactTextString(m) }
 func (*ComputeAverageRequest) ProtoMessage()    {}
 func (*ComputeAverageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f42938f8c8365cf, []int{4}
+	return fileDescriptor_7f42938f8c8365cf, []int{7}
 }
 
 func (m *ComputeAverageRequest) XXX_Unmarshal(b []byte) error {
@@ -238,7 +390,7 @@ func (m *ComputeAverageResponse) Reset()         { *m = ComputeAverageResponse{}
 func (m *ComputeAverageResponse) String() string { return proto.CompactTextString(m) }
 func (*ComputeAverageResponse) ProtoMessage()    {}
 func (*ComputeAverageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f42938f8c8365cf, []int{5}
+	return fileDescriptor_7f42938f8c8365cf, []int{8}
 }
 
 func (m *ComputeAverageResponse) XXX_Unmarshal(b []byte) error {
@@ -277,7 +429,7 @@ func (m *FindMaximumRequest) Reset()         { *m = FindMaximumRequest{} }
 func (m *FindMaximumRequest) String() string { return proto.CompactTextString(m) }
 func (*FindMaximumRequest) ProtoMessage()    {}
 func (*FindMaximumRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f42938f8c8365cf, []int{6}
+	return fileDescriptor_7f42938f8c8365cf, []int{9}
 }
 
 func (m *FindMaximumRequest) XXX_Unmarshal(b []byte) error {
@@ -316,7 +468,7 @@ func (m *FindMaximumResponse) Reset()         { *m = FindMaximumResponse{} }
 func (m *FindMaximumResponse) String() string { return proto.CompactTextString(m) }
 func (*FindMaximumResponse) ProtoMessage()    {}
 func (*FindMaximumResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f42938f8c8365cf, []int{7}
+	return fileDescriptor_7f42938f8c8365cf, []int{10}
 }
 
 func (m *FindMaximumResponse) XXX_Unmarshal(b []byte) error {
@@ -355,7 +507,7 @@ func (m *SquareRootRequest) Reset()         { *m = SquareRootRequest{} }
 func (m *SquareRootRequest) String() string { return proto.CompactTextString(m) }
 func (*SquareRootRequest) ProtoMessage()    {}
 func (*SquareRootRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f42938f8c8365cf, []int{8}
+	return fileDescriptor_7f42938f8c8365cf, []int{11}
 }
 
 func (m *SquareRootRequest) XXX_Unmarshal(b []byte) error {
@@ -376,48 +528,539 @@ func (m *SquareRootRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SquareRootRequest proto.InternalMessageInfo
 
-func (m *SquareRootRequest) GetNumber() int32 {
+func (m *SquareRootRequest) GetNumber() int32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type SquareRootResponse struct {
+	NumberRoot           float64  `protobuf:"fixed64,1,opt,name=number_root,json=numberRoot,proto3" json:"number_root,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SquareRootResponse) Reset()         { *m = SquareRootResponse{} }
+func (m *SquareRootResponse) String() string { return proto.CompactTextString(m) }
+func (*SquareRootResponse) ProtoMessage()    {}
+func (*SquareRootResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{12}
+}
+
+func (m *SquareRootResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SquareRootResponse.Unmarshal(m, b)
+}
+func (m *SquareRootResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SquareRootResponse.Marshal(b, m, deterministic)
+}
+func (m *SquareRootResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SquareRootResponse.Merge(m, src)
+}
+func (m *SquareRootResponse) XXX_Size() int {
+	return xxx_messageInfo_SquareRootResponse.Size(m)
+}
+func (m *SquareRootResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SquareRootResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SquareRootResponse proto.InternalMessageInfo
+
+func (m *SquareRootResponse) GetNumberRoot() float64 {
+	if m != nil {
+		return m.NumberRoot
+	}
+	return 0
+}
+
+// Operands of the arbitrary-precision RPCs are decimal integers, with an
+// optional sign, of at most 10000 digits.
+type BigIntRequest struct {
+	A                    string   `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B                    string   `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigIntRequest) Reset()         { *m = BigIntRequest{} }
+func (m *BigIntRequest) String() string { return proto.CompactTextString(m) }
+func (*BigIntRequest) ProtoMessage()    {}
+func (*BigIntRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{13}
+}
+
+func (m *BigIntRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigIntRequest.Unmarshal(m, b)
+}
+func (m *BigIntRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigIntRequest.Marshal(b, m, deterministic)
+}
+func (m *BigIntRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigIntRequest.Merge(m, src)
+}
+func (m *BigIntRequest) XXX_Size() int {
+	return xxx_messageInfo_BigIntRequest.Size(m)
+}
+func (m *BigIntRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigIntRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigIntRequest proto.InternalMessageInfo
+
+func (m *BigIntRequest) GetA() string {
+	if m != nil {
+		return m.A
+	}
+	return ""
+}
+
+func (m *BigIntRequest) GetB() string {
+	if m != nil {
+		return m.B
+	}
+	return ""
+}
+
+type BigIntResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigIntResponse) Reset()         { *m = BigIntResponse{} }
+func (m *BigIntResponse) String() string { return proto.CompactTextString(m) }
+func (*BigIntResponse) ProtoMessage()    {}
+func (*BigIntResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{14}
+}
+
+func (m *BigIntResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigIntResponse.Unmarshal(m, b)
+}
+func (m *BigIntResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigIntResponse.Marshal(b, m, deterministic)
+}
+func (m *BigIntResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigIntResponse.Merge(m, src)
+}
+func (m *BigIntResponse) XXX_Size() int {
+	return xxx_messageInfo_BigIntResponse.Size(m)
+}
+func (m *BigIntResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigIntResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigIntResponse proto.InternalMessageInfo
+
+func (m *BigIntResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+// The quotient is truncated towards zero and the remainder has the sign of
+// a, like the / and % operators of Go.
+type BigDivideResponse struct {
+	Quotient             string   `protobuf:"bytes,1,opt,name=quotient,proto3" json:"quotient,omitempty"`
+	Remainder            string   `protobuf:"bytes,2,opt,name=remainder,proto3" json:"remainder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigDivideResponse) Reset()         { *m = BigDivideResponse{} }
+func (m *BigDivideResponse) String() string { return proto.CompactTextString(m) }
+func (*BigDivideResponse) ProtoMessage()    {}
+func (*BigDivideResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{15}
+}
+
+func (m *BigDivideResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigDivideResponse.Unmarshal(m, b)
+}
+func (m *BigDivideResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigDivideResponse.Marshal(b, m, deterministic)
+}
+func (m *BigDivideResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigDivideResponse.Merge(m, src)
+}
+func (m *BigDivideResponse) XXX_Size() int {
+	return xxx_messageInfo_BigDivideResponse.Size(m)
+}
+func (m *BigDivideResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigDivideResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigDivideResponse proto.InternalMessageInfo
+
+func (m *BigDivideResponse) GetQuotient() string {
+	if m != nil {
+		return m.Quotient
+	}
+	return ""
+}
+
+func (m *BigDivideResponse) GetRemainder() string {
+	if m != nil {
+		return m.Remainder
+	}
+	return ""
+}
+
+type BigPowerRequest struct {
+	Base                 string   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Exponent             string   `protobuf:"bytes,2,opt,name=exponent,proto3" json:"exponent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigPowerRequest) Reset()         { *m = BigPowerRequest{} }
+func (m *BigPowerRequest) String() string { return proto.CompactTextString(m) }
+func (*BigPowerRequest) ProtoMessage()    {}
+func (*BigPowerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{16}
+}
+
+func (m *BigPowerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigPowerRequest.Unmarshal(m, b)
+}
+func (m *BigPowerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigPowerRequest.Marshal(b, m, deterministic)
+}
+func (m *BigPowerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigPowerRequest.Merge(m, src)
+}
+func (m *BigPowerRequest) XXX_Size() int {
+	return xxx_messageInfo_BigPowerRequest.Size(m)
+}
+func (m *BigPowerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigPowerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigPowerRequest proto.InternalMessageInfo
+
+func (m *BigPowerRequest) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *BigPowerRequest) GetExponent() string {
+	if m != nil {
+		return m.Exponent
+	}
+	return ""
+}
+
+type BigModPowRequest struct {
+	Base                 string   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Exponent             string   `protobuf:"bytes,2,opt,name=exponent,proto3" json:"exponent,omitempty"`
+	Modulus              string   `protobuf:"bytes,3,opt,name=modulus,proto3" json:"modulus,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BigModPowRequest) Reset()         { *m = BigModPowRequest{} }
+func (m *BigModPowRequest) String() string { return proto.CompactTextString(m) }
+func (*BigModPowRequest) ProtoMessage()    {}
+func (*BigModPowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{17}
+}
+
+func (m *BigModPowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigModPowRequest.Unmarshal(m, b)
+}
+func (m *BigModPowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigModPowRequest.Marshal(b, m, deterministic)
+}
+func (m *BigModPowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigModPowRequest.Merge(m, src)
+}
+func (m *BigModPowRequest) XXX_Size() int {
+	return xxx_messageInfo_BigModPowRequest.Size(m)
+}
+func (m *BigModPowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigModPowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BigModPowRequest proto.InternalMessageInfo
+
+func (m *BigModPowRequest) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *BigModPowRequest) GetExponent() string {
+	if m != nil {
+		return m.Exponent
+	}
+	return ""
+}
+
+func (m *BigModPowRequest) GetModulus() string {
+	if m != nil {
+		return m.Modulus
+	}
+	return ""
+}
+
+type Variable struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Variable) Reset()         { *m = Variable{} }
+func (m *Variable) String() string { return proto.CompactTextString(m) }
+func (*Variable) ProtoMessage()    {}
+func (*Variable) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{18}
+}
+
+func (m *Variable) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Variable.Unmarshal(m, b)
+}
+func (m *Variable) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Variable.Marshal(b, m, deterministic)
+}
+func (m *Variable) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Variable.Merge(m, src)
+}
+func (m *Variable) XXX_Size() int {
+	return xxx_messageInfo_Variable.Size(m)
+}
+func (m *Variable) XXX_DiscardUnknown() {
+	xxx_messageInfo_Variable.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Variable proto.InternalMessageInfo
+
+func (m *Variable) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Variable) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+// Expressions combine numbers and variables with + - * / % ^ (power),
+// parentheses, unary minus and the functions sqrt, sin, cos, tan, asin,
+// acos, atan, log (natural), log10, exp, abs, floor, ceil, pow, min and
+// max. pi and e are predefined, variables of the same name replace them.
+type EvaluateRequest struct {
+	Expression           string      `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	Variables            []*Variable `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *EvaluateRequest) Reset()         { *m = EvaluateRequest{} }
+func (m *EvaluateRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateRequest) ProtoMessage()    {}
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{19}
+}
+
+func (m *EvaluateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateRequest.Unmarshal(m, b)
+}
+func (m *EvaluateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateRequest.Marshal(b, m, deterministic)
+}
+func (m *EvaluateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateRequest.Merge(m, src)
+}
+func (m *EvaluateRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateRequest.Size(m)
+}
+func (m *EvaluateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateRequest proto.InternalMessageInfo
+
+func (m *EvaluateRequest) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *EvaluateRequest) GetVariables() []*Variable {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	Result               float64  `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvaluateResponse) Reset()         { *m = EvaluateResponse{} }
+func (m *EvaluateResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateResponse) ProtoMessage()    {}
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{20}
+}
+
+func (m *EvaluateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateResponse.Unmarshal(m, b)
+}
+func (m *EvaluateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateResponse.Marshal(b, m, deterministic)
+}
+func (m *EvaluateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateResponse.Merge(m, src)
+}
+func (m *EvaluateResponse) XXX_Size() int {
+	return xxx_messageInfo_EvaluateResponse.Size(m)
+}
+func (m *EvaluateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateResponse proto.InternalMessageInfo
+
+func (m *EvaluateResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+// A line is an expression, or an assignment such as "x = 2*y" binding x for
+// the next lines of the session. The result of every line is also bound to
+// ans. variables are bound before the line is evaluated.
+type EvaluateSessionRequest struct {
+	Line                 string      `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
+	Variables            []*Variable `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *EvaluateSessionRequest) Reset()         { *m = EvaluateSessionRequest{} }
+func (m *EvaluateSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateSessionRequest) ProtoMessage()    {}
+func (*EvaluateSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{21}
+}
+
+func (m *EvaluateSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateSessionRequest.Unmarshal(m, b)
+}
+func (m *EvaluateSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateSessionRequest.Marshal(b, m, deterministic)
+}
+func (m *EvaluateSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateSessionRequest.Merge(m, src)
+}
+func (m *EvaluateSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateSessionRequest.Size(m)
+}
+func (m *EvaluateSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateSessionRequest proto.InternalMessageInfo
+
+func (m *EvaluateSessionRequest) GetLine() string {
+	if m != nil {
+		return m.Line
+	}
+	return ""
+}
+
+func (m *EvaluateSessionRequest) GetVariables() []*Variable {
 	if m != nil {
-		return m.Number
+		return m.Variables
 	}
-	return 0
+	return nil
 }
 
-type SquareRootResponse struct {
-	NumberRoot           float64  `protobuf:"fixed64,1,opt,name=number_root,json=numberRoot,proto3" json:"number_root,omitempty"`
+// A line that fails has error set, and error_column, counted from 1, when
+// the error is at a place of the line. The session goes on.
+type EvaluateSessionResponse struct {
+	Result               float64  `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+	Assigned             string   `protobuf:"bytes,2,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorColumn          int32    `protobuf:"varint,4,opt,name=error_column,json=errorColumn,proto3" json:"error_column,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SquareRootResponse) Reset()         { *m = SquareRootResponse{} }
-func (m *SquareRootResponse) String() string { return proto.CompactTextString(m) }
-func (*SquareRootResponse) ProtoMessage()    {}
-func (*SquareRootResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f42938f8c8365cf, []int{9}
+func (m *EvaluateSessionResponse) Reset()         { *m = EvaluateSessionResponse{} }
+func (m *EvaluateSessionResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateSessionResponse) ProtoMessage()    {}
+func (*EvaluateSessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{22}
 }
 
-func (m *SquareRootResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SquareRootResponse.Unmarshal(m, b)
+func (m *EvaluateSessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateSessionResponse.Unmarshal(m, b)
 }
-func (m *SquareRootResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SquareRootResponse.Marshal(b, m, deterministic)
+func (m *EvaluateSessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateSessionResponse.Marshal(b, m, deterministic)
 }
-func (m *SquareRootResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SquareRootResponse.Merge(m, src)
+func (m *EvaluateSessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateSessionResponse.Merge(m, src)
 }
-func (m *SquareRootResponse) XXX_Size() int {
-	return xxx_messageInfo_SquareRootResponse.Size(m)
+func (m *EvaluateSessionResponse) XXX_Size() int {
+	return xxx_messageInfo_EvaluateSessionResponse.Size(m)
 }
-func (m *SquareRootResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SquareRootResponse.DiscardUnknown(m)
+func (m *EvaluateSessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateSessionResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SquareRootResponse proto.InternalMessageInfo
+var xxx_messageInfo_EvaluateSessionResponse proto.InternalMessageInfo
 
-func (m *SquareRootResponse) GetNumberRoot() float64 {
+func (m *EvaluateSessionResponse) GetResult() float64 {
 	if m != nil {
-		return m.NumberRoot
+		return m.Result
+	}
+	return 0
+}
+
+func (m *EvaluateSessionResponse) GetAssigned() string {
+	if m != nil {
+		return m.Assigned
+	}
+	return ""
+}
+
+func (m *EvaluateSessionResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *EvaluateSessionResponse) GetErrorColumn() int32 {
+	if m != nil {
+		return m.ErrorColumn
 	}
 	return 0
 }
@@ -427,12 +1070,25 @@ func init() {
 	proto.RegisterType((*SumResponse)(nil), "calculator.SumResponse")
 	proto.RegisterType((*DecompositeNumberRequest)(nil), "calculator.DecompositeNumberRequest")
 	proto.RegisterType((*DecompositeNumberResponse)(nil), "calculator.DecompositeNumberResponse")
+	proto.RegisterType((*FactorizationProgress)(nil), "calculator.FactorizationProgress")
+	proto.RegisterType((*IsPrimeRequest)(nil), "calculator.IsPrimeRequest")
+	proto.RegisterType((*IsPrimeResponse)(nil), "calculator.IsPrimeResponse")
 	proto.RegisterType((*ComputeAverageRequest)(nil), "calculator.ComputeAverageRequest")
 	proto.RegisterType((*ComputeAverageResponse)(nil), "calculator.ComputeAverageResponse")
 	proto.RegisterType((*FindMaximumRequest)(nil), "calculator.FindMaximumRequest")
 	proto.RegisterType((*FindMaximumResponse)(nil), "calculator.FindMaximumResponse")
 	proto.RegisterType((*SquareRootRequest)(nil), "calculator.SquareRootRequest")
 	proto.RegisterType((*SquareRootResponse)(nil), "calculator.SquareRootResponse")
+	proto.RegisterType((*BigIntRequest)(nil), "calculator.BigIntRequest")
+	proto.RegisterType((*BigIntResponse)(nil), "calculator.BigIntResponse")
+	proto.RegisterType((*BigDivideResponse)(nil), "calculator.BigDivideResponse")
+	proto.RegisterType((*BigPowerRequest)(nil), "calculator.BigPowerRequest")
+	proto.RegisterType((*BigModPowRequest)(nil), "calculator.BigModPowRequest")
+	proto.RegisterType((*Variable)(nil), "calculator.Variable")
+	proto.RegisterType((*EvaluateRequest)(nil), "calculator.EvaluateRequest")
+	proto.RegisterType((*EvaluateResponse)(nil), "calculator.EvaluateResponse")
+	proto.RegisterType((*EvaluateSessionRequest)(nil), "calculator.EvaluateSessionRequest")
+	proto.RegisterType((*EvaluateSessionResponse)(nil), "calculator.EvaluateSessionResponse")
 }
 
 func init() {
@@ -440,33 +1096,66 @@ func init() {
 }
 
 var fileDescriptor_7f42938f8c8365cf = []byte{
-	// 407 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x5d, 0x8b, 0xd3, 0x40,
-	0x14, 0x35, 0x46, 0x2b, 0xdc, 0x2c, 0x8b, 0xbd, 0x62, 0x8c, 0x01, 0x77, 0xdd, 0xc1, 0xc5, 0x82,
-	0xb2, 0xad, 0x51, 0xc1, 0x27, 0x41, 0x2b, 0x7d, 0x6b, 0x1f, 0x12, 0x9f, 0xf4, 0xa1, 0x4c, 0xd3,
-	0x51, 0x02, 0x9d, 0x4e, 0x3a, 0x99, 0x29, 0xfd, 0x63, 0xfe, 0x3f, 0x69, 0x32, 0x69, 0x26, 0xfd,
-	0xd8, 0xbe, 0xcd, 0x3d, 0x73, 0x3e, 0x42, 0xce, 0x1d, 0xe8, 0xa5, 0x74, 0x91, 0xea, 0x05, 0x55,
-	0x42, 0xf6, 0x9b, 0x63, 0x3e, 0xb3, 0x86, 0xbb, 0x5c, 0x0a, 0x25, 0x10, 0x1a, 0x84, 0x7c, 0x02,
-	0x48, 0x34, 0x8f, 0xd9, 0x4a, 0xb3, 0x42, 0x21, 0xc2, 0xa3, 0x89, 0xe6, 0x1f, 0x02, 0xe7, 0xb5,
-	0xd3, 0x73, 0xe3, 0xf2, 0x6c, 0xb0, 0x28, 0x78, 0xb8, 0xc3, 0x22, 0x72, 0x0b, 0x5e, 0xa9, 0x2a,
-	0x72, 0xb1, 0x2c, 0x18, 0xfa, 0xd0, 0x89, 0x59, 0xa1, 0x17, 0xca, 0x08, 0xcd, 0x44, 0x22, 0x08,
-	0x7e, 0xb0, 0x54, 0xf0, 0x5c, 0x14, 0x99, 0x62, 0x13, 0xcd, 0x67, 0x4c, 0xd6, 0x51, 0x3e, 0x74,
-	0x96, 0x25, 0x50, 0x6b, 0xaa, 0x89, 0x7c, 0x85, 0x97, 0x47, 0x34, 0x26, 0xe8, 0x06, 0x2e, 0x72,
-	0x99, 0x71, 0x36, 0xfd, 0x43, 0x53, 0x25, 0x6a, 0xa9, 0x57, 0x62, 0xa3, 0x12, 0x22, 0x7d, 0x78,
-	0x3e, 0x14, 0x3c, 0xd7, 0x8a, 0x7d, 0x5b, 0x33, 0x49, 0xff, 0xb2, 0x73, 0x81, 0x11, 0xf8, 0xfb,
-	0x02, 0x93, 0x16, 0xc0, 0x13, 0x5a, 0x41, 0xa5, 0xc4, 0x89, 0xeb, 0x91, 0xbc, 0x07, 0x1c, 0x65,
-	0xcb, 0xf9, 0x98, 0x6e, 0x32, 0xde, 0xfc, 0xbd, 0x76, 0xc2, 0xe3, 0x5d, 0xc2, 0x5b, 0x78, 0xd6,
-	0x62, 0x1b, 0xfb, 0xa7, 0xe0, 0x72, 0xba, 0x31, 0xdc, 0xed, 0x91, 0xbc, 0x83, 0x6e, 0xb2, 0xd2,
-	0x54, 0xb2, 0x58, 0x08, 0x75, 0xce, 0xf5, 0x33, 0xa0, 0x4d, 0x36, 0xa6, 0xd7, 0xe0, 0x55, 0xf7,
-	0x53, 0x29, 0x84, 0x32, 0xdf, 0x0d, 0x15, 0xb4, 0x25, 0x46, 0xff, 0x5c, 0xe8, 0x0e, 0x77, 0xfd,
-	0x27, 0x4c, 0xae, 0xb3, 0x94, 0xe1, 0x17, 0x70, 0x13, 0xcd, 0xd1, 0xbf, 0xb3, 0x96, 0xa5, 0xd9,
-	0x8b, 0xf0, 0xc5, 0x01, 0x5e, 0xc5, 0x91, 0x07, 0x38, 0x87, 0xee, 0x41, 0x5f, 0xf8, 0xc6, 0xe6,
-	0x9f, 0x5a, 0x81, 0xf0, 0xf6, 0x0c, 0xab, 0xce, 0x18, 0x38, 0xf8, 0x1b, 0x2e, 0xdb, 0x25, 0xe1,
-	0x8d, 0x2d, 0x3e, 0xda, 0x78, 0x48, 0xee, 0xa3, 0xd4, 0xe6, 0x3d, 0x07, 0x7f, 0x82, 0x67, 0xf5,
-	0x83, 0x57, 0xb6, 0xec, 0xb0, 0xe6, 0xf0, 0xfa, 0xe4, 0x7d, 0xe3, 0x39, 0x70, 0x70, 0x0c, 0xd0,
-	0xf4, 0x83, 0xaf, 0x5a, 0x7f, 0x70, 0xbf, 0xe4, 0xf0, 0xea, 0xd4, 0x75, 0x6d, 0xf9, 0xfd, 0xf2,
-	0xd7, 0x85, 0xfd, 0xaa, 0x67, 0x9d, 0xf2, 0x2d, 0x7f, 0xfc, 0x1f, 0x00, 0x00, 0xff, 0xff, 0x1e,
-	0x18, 0x54, 0x01, 0xf7, 0x03, 0x00, 0x00,
+	// 935 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5d, 0x6f, 0xdb, 0x36,
+	0x14, 0xad, 0xe6, 0x34, 0xb1, 0xaf, 0xb3, 0x38, 0xe1, 0x5a, 0xd7, 0x51, 0xd3, 0xb4, 0xe5, 0x56,
+	0xd4, 0x58, 0x8b, 0xb6, 0xf3, 0x3a, 0x60, 0x2f, 0x7b, 0x88, 0x93, 0x66, 0xe8, 0x83, 0x8b, 0x40,
+	0x1e, 0xf6, 0xb0, 0x0e, 0x70, 0x69, 0x8b, 0x13, 0x88, 0x49, 0xa2, 0x42, 0x51, 0x4e, 0x36, 0x60,
+	0xaf, 0xfb, 0x81, 0xfb, 0x45, 0x03, 0x29, 0x52, 0x1f, 0x8e, 0x12, 0x0f, 0xd9, 0x1b, 0xef, 0xe5,
+	0xe1, 0x39, 0x57, 0x24, 0xef, 0x11, 0x61, 0xb8, 0x20, 0xe1, 0x22, 0x0b, 0x89, 0xe4, 0xe2, 0x75,
+	0x39, 0x4c, 0xe6, 0x95, 0xe0, 0x55, 0x22, 0xb8, 0xe4, 0x08, 0xca, 0x0c, 0x7e, 0x0b, 0x30, 0xcd,
+	0x22, 0x8f, 0x9e, 0x67, 0x34, 0x95, 0x08, 0xc1, 0xc6, 0x87, 0x2c, 0xfa, 0x66, 0xe0, 0x3c, 0x71,
+	0x86, 0x2d, 0x4f, 0x8f, 0x4d, 0x6e, 0x34, 0xf8, 0xac, 0xc8, 0x8d, 0xf0, 0x33, 0xe8, 0xea, 0x55,
+	0x69, 0xc2, 0xe3, 0x94, 0xa2, 0x3e, 0x6c, 0x7a, 0x34, 0xcd, 0x42, 0x69, 0x16, 0x9a, 0x08, 0x7f,
+	0x84, 0xc1, 0x09, 0x5d, 0xf0, 0x28, 0xe1, 0x29, 0x93, 0xf4, 0x43, 0x16, 0xcd, 0xa9, 0xb0, 0x52,
+	0x7d, 0xd8, 0x8c, 0x75, 0xc2, 0xae, 0xc9, 0x23, 0xf4, 0x1c, 0x7a, 0x82, 0x26, 0x5c, 0xc8, 0x59,
+	0x22, 0x78, 0x20, 0x68, 0x9a, 0x6a, 0xe5, 0xb6, 0xb7, 0x93, 0xa7, 0xcf, 0x4c, 0x16, 0xff, 0x05,
+	0xfb, 0x0d, 0xe4, 0xa6, 0xa2, 0xa7, 0xb0, 0x9d, 0x08, 0x16, 0xd1, 0xd9, 0x6f, 0x64, 0x21, 0xb9,
+	0xd5, 0xe8, 0xea, 0xdc, 0xa9, 0x4e, 0xa1, 0x1f, 0xa0, 0x5d, 0x53, 0xe8, 0x8e, 0x9e, 0xbe, 0xaa,
+	0x6c, 0x55, 0x8e, 0x62, 0x7f, 0x12, 0xc9, 0x78, 0x6c, 0x45, 0xbd, 0x62, 0x09, 0xfe, 0x1d, 0xee,
+	0x37, 0x42, 0xd0, 0x3d, 0xb8, 0x9b, 0x4a, 0x12, 0x50, 0xad, 0xd9, 0xf1, 0xf2, 0x00, 0x1d, 0x40,
+	0x47, 0xd0, 0x88, 0xb0, 0x98, 0xc5, 0x81, 0xd9, 0xca, 0x32, 0x81, 0x0e, 0x01, 0x98, 0xa4, 0x42,
+	0x13, 0xa5, 0x83, 0xd6, 0x13, 0x67, 0xb8, 0xe1, 0x55, 0x32, 0x78, 0x08, 0x3b, 0xef, 0xd3, 0x33,
+	0x55, 0xfc, 0x9a, 0xed, 0xc3, 0x2f, 0xa1, 0x57, 0x20, 0xcd, 0x5e, 0xec, 0x43, 0x9b, 0xa5, 0x33,
+	0xfd, 0xe9, 0x1a, 0xdc, 0xf6, 0xb6, 0x58, 0x0e, 0xc1, 0xaf, 0xe1, 0xfe, 0x31, 0x8f, 0x92, 0x4c,
+	0xd2, 0xa3, 0x25, 0x15, 0x24, 0x58, 0x4b, 0x3f, 0x82, 0xfe, 0xea, 0x02, 0xa3, 0x32, 0x80, 0x2d,
+	0x92, 0xa7, 0xf4, 0x12, 0xc7, 0xb3, 0x21, 0x7e, 0x09, 0xe8, 0x94, 0xc5, 0xfe, 0x84, 0x5c, 0xb2,
+	0x28, 0x8b, 0x9a, 0x15, 0xee, 0x16, 0x0a, 0xcf, 0xe1, 0x8b, 0x1a, 0xda, 0xd0, 0xef, 0x42, 0x2b,
+	0x22, 0x97, 0x06, 0xab, 0x86, 0xf8, 0x05, 0xec, 0x4d, 0xcf, 0x33, 0x22, 0xa8, 0xc7, 0xb9, 0x5c,
+	0xc7, 0xfa, 0x1d, 0xa0, 0x2a, 0xd8, 0x90, 0x3e, 0x86, 0x6e, 0x3e, 0x3f, 0x13, 0x9c, 0x4b, 0x53,
+	0x37, 0xe4, 0x29, 0x05, 0xc4, 0x2f, 0xe0, 0xf3, 0x31, 0x0b, 0xde, 0xc7, 0x05, 0xff, 0x36, 0x38,
+	0xc4, 0x1c, 0xac, 0x43, 0x54, 0x34, 0xd7, 0x87, 0xd9, 0xf1, 0x9c, 0xb9, 0x3a, 0x24, 0x0b, 0x2e,
+	0xfb, 0x42, 0x94, 0x7d, 0xd1, 0xf1, 0x4c, 0x84, 0x27, 0xb0, 0x37, 0x66, 0xc1, 0x09, 0x5b, 0x32,
+	0xbf, 0xdc, 0x40, 0x17, 0xda, 0xe7, 0x19, 0x97, 0x8c, 0xc6, 0x16, 0x5e, 0xc4, 0xe5, 0xed, 0xf1,
+	0xa9, 0x30, 0x82, 0x65, 0x02, 0x1f, 0x41, 0x6f, 0xcc, 0x82, 0x33, 0x7e, 0x51, 0x76, 0x17, 0x82,
+	0x8d, 0x39, 0x49, 0xed, 0x1d, 0xd4, 0x63, 0x25, 0x40, 0x2f, 0x13, 0x1e, 0x2b, 0x81, 0x9c, 0xa3,
+	0x88, 0xf1, 0xaf, 0xb0, 0x3b, 0x66, 0xc1, 0x84, 0xfb, 0x67, 0xfc, 0xe2, 0x96, 0x1c, 0xea, 0x06,
+	0x44, 0xdc, 0xcf, 0xc2, 0x2c, 0xbf, 0xc1, 0x1d, 0xcf, 0x86, 0xf8, 0x2d, 0xb4, 0x7f, 0x26, 0x82,
+	0x91, 0x79, 0x48, 0x15, 0x6b, 0x4c, 0xa2, 0x82, 0x55, 0x8d, 0x55, 0xcb, 0x2c, 0x49, 0x98, 0x51,
+	0x4d, 0xe9, 0x78, 0x79, 0x80, 0x29, 0xf4, 0xde, 0xa9, 0x11, 0x91, 0xc5, 0xb5, 0x3c, 0x04, 0xa0,
+	0x97, 0x89, 0x6a, 0x33, 0xc6, 0x63, 0x43, 0x51, 0xc9, 0xa0, 0x11, 0x74, 0x96, 0x46, 0x48, 0x35,
+	0x75, 0x6b, 0xd8, 0x1d, 0xdd, 0xab, 0x36, 0xb5, 0xad, 0xc2, 0x2b, 0x61, 0xf8, 0x6b, 0xd8, 0x2d,
+	0x65, 0x1a, 0x0f, 0xce, 0x29, 0x0e, 0xee, 0x13, 0xf4, 0x2d, 0x76, 0x9a, 0x4b, 0x56, 0x36, 0x2b,
+	0x64, 0x71, 0xf1, 0x59, 0x6a, 0x7c, 0xab, 0x6a, 0xfe, 0x76, 0xe0, 0xc1, 0x15, 0x89, 0x9b, 0xab,
+	0x52, 0x87, 0x42, 0xd2, 0x94, 0x05, 0x31, 0xf5, 0xed, 0xa1, 0xd8, 0x58, 0x6d, 0x2d, 0x15, 0x82,
+	0x0b, 0x73, 0x24, 0x79, 0xa0, 0xec, 0x51, 0x0f, 0x66, 0x0b, 0x1e, 0x66, 0x51, 0x3c, 0xd8, 0xd0,
+	0xcd, 0xd2, 0xd5, 0xb9, 0x63, 0x9d, 0x1a, 0xfd, 0xd3, 0x86, 0xbd, 0xe3, 0xa2, 0xd6, 0x29, 0x15,
+	0x4b, 0xb6, 0xa0, 0xe8, 0x7b, 0x68, 0x4d, 0xb3, 0x08, 0xf5, 0xab, 0x9f, 0x51, 0xfe, 0x3f, 0xdc,
+	0x07, 0x57, 0xf2, 0x79, 0xe9, 0xf8, 0x0e, 0xf2, 0x61, 0xef, 0x8a, 0x5d, 0xa3, 0xaf, 0xaa, 0xf8,
+	0xeb, 0x7e, 0x15, 0xee, 0xb3, 0x35, 0x28, 0xab, 0xf1, 0xc6, 0x41, 0x1f, 0x61, 0xa7, 0xee, 0x4f,
+	0xa8, 0x66, 0xea, 0x8d, 0x66, 0xe7, 0xe2, 0x9b, 0x20, 0x96, 0x7c, 0xe8, 0xa0, 0x9f, 0xa0, 0x5b,
+	0xb1, 0x26, 0x74, 0x58, 0xfb, 0x5d, 0x5c, 0x71, 0x38, 0xf7, 0xf1, 0xb5, 0xf3, 0x25, 0xe7, 0x1b,
+	0x07, 0x4d, 0x00, 0x4a, 0x6b, 0x42, 0x8f, 0x6a, 0x3b, 0xb8, 0xea, 0x6f, 0xee, 0xe1, 0x75, 0xd3,
+	0xc5, 0x3e, 0x1f, 0xc1, 0xe6, 0x98, 0x05, 0x47, 0xbe, 0x8f, 0xf6, 0xab, 0xd8, 0x9a, 0x8d, 0xb9,
+	0x6e, 0xd3, 0x54, 0x41, 0x71, 0x0a, 0xdd, 0x31, 0x0b, 0xa6, 0xd9, 0x5c, 0x0a, 0xb2, 0x90, 0xff,
+	0x97, 0x67, 0x92, 0x85, 0x92, 0x25, 0xe1, 0x1f, 0xb7, 0xe7, 0xf9, 0x11, 0x3a, 0x85, 0x5d, 0xde,
+	0xc4, 0xf2, 0x68, 0x65, 0xaa, 0x6e, 0xb0, 0xf8, 0x0e, 0x7a, 0x07, 0x6d, 0x6b, 0x94, 0xe8, 0xe1,
+	0x0a, 0xb8, 0x6a, 0x9f, 0xff, 0xa9, 0x9e, 0xdc, 0x2c, 0xd1, 0xc1, 0x0a, 0xb4, 0xe6, 0xa1, 0x6b,
+	0x89, 0xda, 0xb6, 0xd7, 0xeb, 0xf5, 0xac, 0xf8, 0x9e, 0x7b, 0xd0, 0x3c, 0x59, 0x10, 0x7d, 0x82,
+	0xde, 0x8a, 0x69, 0x20, 0xdc, 0xb4, 0xa4, 0x6e, 0x5a, 0xee, 0x97, 0x37, 0x62, 0x6a, 0xb7, 0xf4,
+	0x04, 0xb6, 0xcc, 0xbb, 0x02, 0xd5, 0xbe, 0xa9, 0xfe, 0x2c, 0x71, 0x1f, 0x36, 0xce, 0x59, 0xa6,
+	0xf1, 0xce, 0x2f, 0xdb, 0xd5, 0xa7, 0xe9, 0x7c, 0x53, 0x3f, 0x48, 0xbf, 0xfd, 0x77, 0x00, 0xbb,
+	0x2e, 0xc3, 0x44, 0xbc, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -493,6 +1182,22 @@ type CalculatorServiceClient interface {
 	// This RPC will throw an exception if the sent number is negative
 	// The error being send is of type INVALID_ARGUMENT
 	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+	// Arbitrary-precision arithmetic
+	// Malformed or too large operands are rejected with INVALID_ARGUMENT
+	BigAdd(ctx context.Context, in *BigIntRequest, opts ...grpc.CallOption) (*BigIntResponse, error)
+	BigSubtract(ctx context.Context, in *BigIntRequest, opts ...grpc.CallOption) (*BigIntResponse, error)
+	BigMultiply(ctx context.Context, in *BigIntRequest, opts ...grpc.CallOption) (*BigIntResponse, error)
+	BigDivide(ctx context.Context, in *BigIntRequest, opts ...grpc.CallOption) (*BigDivideResponse, error)
+	BigPower(ctx context.Context, in *BigPowerRequest, opts ...grpc.CallOption) (*BigIntResponse, error)
+	BigModPow(ctx context.Context, in *BigModPowRequest, opts ...grpc.CallOption) (*BigIntResponse, error)
+	// Expression evaluation
+	// Syntax errors are sent as INVALID_ARGUMENT with a BadRequest detail
+	// giving the column of the error
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// Bidirectional Streaming API keeping the variables between lines
+	EvaluateSession(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_EvaluateSessionClient, error)
+	// Deterministic Miller-Rabin test, numbers below 2 aren't prime
+	IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error)
 }
 
 type calculatorServiceClient struct {
@@ -618,6 +1323,109 @@ func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRoot
 	return out, nil
 }
 
+func (c *calculatorServiceClient) BigAdd(ctx context.Context, in *BigIntRequest, opts ...grpc.CallOption) (*BigIntResponse, error) {
+	out := new(BigIntResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigSubtract(ctx context.Context, in *BigIntRequest, opts ...grpc.CallOption) (*BigIntResponse, error) {
+	out := new(BigIntResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigSubtract", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigMultiply(ctx context.Context, in *BigIntRequest, opts ...grpc.CallOption) (*BigIntResponse, error) {
+	out := new(BigIntResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigMultiply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigDivide(ctx context.Context, in *BigIntRequest, opts ...grpc.CallOption) (*BigDivideResponse, error) {
+	out := new(BigDivideResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigDivide", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigPower(ctx context.Context, in *BigPowerRequest, opts ...grpc.CallOption) (*BigIntResponse, error) {
+	out := new(BigIntResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigPower", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigModPow(ctx context.Context, in *BigModPowRequest, opts ...grpc.CallOption) (*BigIntResponse, error) {
+	out := new(BigIntResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigModPow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Evaluate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) EvaluateSession(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_EvaluateSessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[3], "/calculator.CalculatorService/EvaluateSession", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceEvaluateSessionClient{stream}
+	return x, nil
+}
+
+type CalculatorService_EvaluateSessionClient interface {
+	Send(*EvaluateSessionRequest) error
+	Recv() (*EvaluateSessionResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceEvaluateSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceEvaluateSessionClient) Send(m *EvaluateSessionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceEvaluateSessionClient) Recv() (*EvaluateSessionResponse, error) {
+	m := new(EvaluateSessionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error) {
+	out := new(IsPrimeResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/IsPrime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CalculatorServiceServer is the server API for CalculatorService service.
 type CalculatorServiceServer interface {
 	// Unary API
@@ -632,6 +1440,22 @@ type CalculatorServiceServer interface {
 	// This RPC will throw an exception if the sent number is negative
 	// The error being send is of type INVALID_ARGUMENT
 	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
+	// Arbitrary-precision arithmetic
+	// Malformed or too large operands are rejected with INVALID_ARGUMENT
+	BigAdd(context.Context, *BigIntRequest) (*BigIntResponse, error)
+	BigSubtract(context.Context, *BigIntRequest) (*BigIntResponse, error)
+	BigMultiply(context.Context, *BigIntRequest) (*BigIntResponse, error)
+	BigDivide(context.Context, *BigIntRequest) (*BigDivideResponse, error)
+	BigPower(context.Context, *BigPowerRequest) (*BigIntResponse, error)
+	BigModPow(context.Context, *BigModPowRequest) (*BigIntResponse, error)
+	// Expression evaluation
+	// Syntax errors are sent as INVALID_ARGUMENT with a BadRequest detail
+	// giving the column of the error
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// Bidirectional Streaming API keeping the variables between lines
+	EvaluateSession(CalculatorService_EvaluateSessionServer) error
+	// Deterministic Miller-Rabin test, numbers below 2 aren't prime
+	IsPrime(context.Context, *IsPrimeRequest) (*IsPrimeResponse, error)
 }
 
 // UnimplementedCalculatorServiceServer can be embedded to have forward compatible implementations.
@@ -653,6 +1477,33 @@ func (*UnimplementedCalculatorServiceServer) FindMaximum(srv CalculatorService_F
 func (*UnimplementedCalculatorServiceServer) SquareRoot(ctx context.Context, req *SquareRootRequest) (*SquareRootResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SquareRoot not implemented")
 }
+func (*UnimplementedCalculatorServiceServer) BigAdd(ctx context.Context, req *BigIntRequest) (*BigIntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BigAdd not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BigSubtract(ctx context.Context, req *BigIntRequest) (*BigIntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BigSubtract not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BigMultiply(ctx context.Context, req *BigIntRequest) (*BigIntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BigMultiply not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BigDivide(ctx context.Context, req *BigIntRequest) (*BigDivideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BigDivide not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BigPower(ctx context.Context, req *BigPowerRequest) (*BigIntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BigPower not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BigModPow(ctx context.Context, req *BigModPowRequest) (*BigIntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BigModPow not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Evaluate(ctx context.Context, req *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) EvaluateSession(srv CalculatorService_EvaluateSessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method EvaluateSession not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) IsPrime(ctx context.Context, req *IsPrimeRequest) (*IsPrimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsPrime not implemented")
+}
 
 func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
 	s.RegisterService(&_CalculatorService_serviceDesc, srv)
@@ -767,6 +1618,176 @@ func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalculatorService_BigAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigIntRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigAdd(ctx, req.(*BigIntRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigSubtract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigIntRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigSubtract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigSubtract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigSubtract(ctx, req.(*BigIntRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigMultiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigIntRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigMultiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigMultiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigMultiply(ctx, req.(*BigIntRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigDivide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigIntRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigDivide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigDivide",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigDivide(ctx, req.(*BigIntRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigPower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigPowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigPower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigPower",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigPower(ctx, req.(*BigPowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigModPow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigModPowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigModPow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigModPow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigModPow(ctx, req.(*BigModPowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Evaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_EvaluateSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).EvaluateSession(&calculatorServiceEvaluateSessionServer{stream})
+}
+
+type CalculatorService_EvaluateSessionServer interface {
+	Send(*EvaluateSessionResponse) error
+	Recv() (*EvaluateSessionRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceEvaluateSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceEvaluateSessionServer) Send(m *EvaluateSessionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceEvaluateSessionServer) Recv() (*EvaluateSessionRequest, error) {
+	m := new(EvaluateSessionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CalculatorService_IsPrime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsPrimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).IsPrime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/IsPrime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).IsPrime(ctx, req.(*IsPrimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "calculator.CalculatorService",
 	HandlerType: (*CalculatorServiceServer)(nil),
@@ -779,6 +1800,38 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SquareRoot",
 			Handler:    _CalculatorService_SquareRoot_Handler,
 		},
+		{
+			MethodName: "BigAdd",
+			Handler:    _CalculatorService_BigAdd_Handler,
+		},
+		{
+			MethodName: "BigSubtract",
+			Handler:    _CalculatorService_BigSubtract_Handler,
+		},
+		{
+			MethodName: "BigMultiply",
+			Handler:    _CalculatorService_BigMultiply_Handler,
+		},
+		{
+			MethodName: "BigDivide",
+			Handler:    _CalculatorService_BigDivide_Handler,
+		},
+		{
+			MethodName: "BigPower",
+			Handler:    _CalculatorService_BigPower_Handler,
+		},
+		{
+			MethodName: "BigModPow",
+			Handler:    _CalculatorService_BigModPow_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _CalculatorService_Evaluate_Handler,
+		},
+		{
+			MethodName: "IsPrime",
+			Handler:    _CalculatorService_IsPrime_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -797,6 +1850,12 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "EvaluateSession",
+			Handler:       _CalculatorService_EvaluateSession_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "calculator/calculatorpb/calculator.proto",
 }